@@ -24,6 +24,130 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "获取令牌验签公钥集合(JWKS)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jwtauth.JSONWebKeySet"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "审计日志"
+                ],
+                "summary": "查询数据变更记录(按变更时间倒序)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "数据表(不含表名前缀，例如：user)",
+                        "name": "table",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "数据主键",
+                        "name": "recordID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "实际操作者ID",
+                        "name": "actorID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "变更类型(create:新增 update:修改 delete:删除)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始时间(格式：2006-01-02 15:04:05)",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束时间(格式：2006-01-02 15:04:05)",
+                        "name": "endTime",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.AuditLog"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/demos": {
             "get": {
                 "security": [
@@ -144,6 +268,78 @@ var doc = `{
                 }
             }
         },
+        "/api/v1/demos.deleted": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Demo"
+                ],
+                "summary": "查询回收站中的数据(已删除的数据)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值(名称)",
+                        "name": "queryValue",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.RecycleItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/demos/{id}": {
             "get": {
                 "security": [
@@ -169,6 +365,12 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/schema.Demo"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "资源版本号"
+                            }
                         }
                     },
                     "401": {
@@ -209,6 +411,13 @@ var doc = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "更新数据",
                         "name": "body",
@@ -238,6 +447,18 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -305,6 +526,13 @@ var doc = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -320,6 +548,18 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -347,6 +587,13 @@ var doc = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -362,6 +609,18 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -371,57 +630,105 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/menus": {
-            "get": {
+        "/api/v1/demos/{id}/restore": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "tags": [
-                    "菜单管理"
+                    "Demo"
                 ],
-                "summary": "查询数据",
+                "summary": "从回收站恢复数据",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "分页索引",
-                        "name": "current",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "分页大小",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "查询值",
-                        "name": "queryValue",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "状态(1:启用 2:禁用)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "显示状态(1:显示 2:隐藏)",
-                        "name": "showStatus",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "父级ID",
-                        "name": "parentID",
-                        "in": "query"
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/depts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "部门管理"
+                ],
+                "summary": "查询数据",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:禁用)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "父级ID",
+                        "name": "parentID",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -438,7 +745,7 @@ var doc = `{
                                         "list": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.Menu"
+                                                "$ref": "#/definitions/schema.Dept"
                                             }
                                         }
                                     }
@@ -467,7 +774,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "菜单管理"
+                    "部门管理"
                 ],
                 "summary": "创建数据",
                 "parameters": [
@@ -477,7 +784,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.Menu"
+                            "$ref": "#/definitions/schema.Dept"
                         }
                     }
                 ],
@@ -509,7 +816,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/menus.tree": {
+        "/api/v1/depts.deleted": {
             "get": {
                 "security": [
                     {
@@ -517,9 +824,81 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "菜单管理"
+                    "部门管理"
                 ],
-                "summary": "查询菜单树",
+                "summary": "查询回收站中的数据(已删除的数据)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值(名称)",
+                        "name": "queryValue",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.RecycleItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/depts.tree": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "部门管理"
+                ],
+                "summary": "查询部门树",
                 "parameters": [
                     {
                         "type": "integer",
@@ -548,7 +927,7 @@ var doc = `{
                                         "list": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.MenuTree"
+                                                "$ref": "#/definitions/schema.DeptTree"
                                             }
                                         }
                                     }
@@ -571,7 +950,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/menus/{id}": {
+        "/api/v1/depts/{id}": {
             "get": {
                 "security": [
                     {
@@ -579,7 +958,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "菜单管理"
+                    "部门管理"
                 ],
                 "summary": "查询指定数据",
                 "parameters": [
@@ -595,7 +974,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.Menu"
+                            "$ref": "#/definitions/schema.Dept"
                         }
                     },
                     "401": {
@@ -625,7 +1004,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "菜单管理"
+                    "部门管理"
                 ],
                 "summary": "更新数据",
                 "parameters": [
@@ -642,7 +1021,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.Menu"
+                            "$ref": "#/definitions/schema.Dept"
                         }
                     }
                 ],
@@ -680,7 +1059,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "菜单管理"
+                    "部门管理"
                 ],
                 "summary": "删除数据",
                 "parameters": [
@@ -714,7 +1093,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/menus/{id}/disable": {
+        "/api/v1/depts/{id}/disable": {
             "patch": {
                 "security": [
                     {
@@ -722,7 +1101,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "菜单管理"
+                    "部门管理"
                 ],
                 "summary": "禁用数据",
                 "parameters": [
@@ -756,7 +1135,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/menus/{id}/enable": {
+        "/api/v1/depts/{id}/enable": {
             "patch": {
                 "security": [
                     {
@@ -764,7 +1143,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "菜单管理"
+                    "部门管理"
                 ],
                 "summary": "启用数据",
                 "parameters": [
@@ -798,37 +1177,37 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/pub/current/menutree": {
-            "get": {
+        "/api/v1/depts/{id}/restore": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "tags": [
-                    "登录管理"
+                    "部门管理"
+                ],
+                "summary": "从回收站恢复数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "查询当前用户菜单树",
                 "responses": {
                     "200": {
-                        "description": "查询结果",
+                        "description": "{status:OK}",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/schema.ListResult"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "list": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/schema.MenuTree"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
                     "401": {
@@ -837,6 +1216,12 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -846,39 +1231,31 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/pub/current/password": {
-            "put": {
+        "/api/v1/lockouts/ips/{ip}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "tags": [
-                    "登录管理"
+                    "用户管理"
                 ],
-                "summary": "更新个人密码",
+                "summary": "查询指定IP的登录锁定状态",
                 "parameters": [
                     {
-                        "description": "请求参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.UpdatePasswordParam"
-                        }
+                        "type": "string",
+                        "description": "IP地址",
+                        "name": "ip",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{status:OK}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.StatusResult"
-                        }
-                    },
-                    "400": {
-                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
+                            "$ref": "#/definitions/schema.LoginLockout"
                         }
                     },
                     "401": {
@@ -894,24 +1271,31 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/pub/current/user": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "tags": [
-                    "登录管理"
+                    "用户管理"
+                ],
+                "summary": "解除指定IP的登录锁定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "IP地址",
+                        "name": "ip",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取当前用户信息",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "{status:OK}",
                         "schema": {
-                            "$ref": "#/definitions/schema.UserLoginInfo"
+                            "$ref": "#/definitions/schema.StatusResult"
                         }
                     },
                     "401": {
@@ -929,154 +1313,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/pub/login": {
-            "post": {
-                "tags": [
-                    "登录管理"
-                ],
-                "summary": "用户登录",
-                "parameters": [
-                    {
-                        "description": "请求参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.LoginParam"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/schema.LoginTokenInfo"
-                        }
-                    },
-                    "400": {
-                        "description": "{error:{code:0,message:无效的请求参数}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    },
-                    "500": {
-                        "description": "{error:{code:0,message:服务器错误}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/pub/login/captcha": {
-            "get": {
-                "produces": [
-                    "image/png"
-                ],
-                "tags": [
-                    "登录管理"
-                ],
-                "summary": "响应图形验证码",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "验证码ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "重新加载",
-                        "name": "reload",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "图形验证码"
-                    },
-                    "400": {
-                        "description": "{error:{code:0,message:无效的请求参数}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    },
-                    "500": {
-                        "description": "{error:{code:0,message:服务器错误}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/pub/login/captchaid": {
-            "get": {
-                "tags": [
-                    "登录管理"
-                ],
-                "summary": "获取验证码信息",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/schema.LoginCaptcha"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/pub/login/exit": {
-            "post": {
-                "tags": [
-                    "登录管理"
-                ],
-                "summary": "用户登出",
-                "responses": {
-                    "200": {
-                        "description": "{status:OK}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.StatusResult"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/pub/refresh-token": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "tags": [
-                    "登录管理"
-                ],
-                "summary": "刷新令牌",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/schema.LoginTokenInfo"
-                        }
-                    },
-                    "401": {
-                        "description": "{error:{code:0,message:未授权}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    },
-                    "500": {
-                        "description": "{error:{code:0,message:服务器错误}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/roles": {
+        "/api/v1/menus": {
             "get": {
                 "security": [
                     {
@@ -1084,7 +1321,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "角色管理"
+                    "菜单管理"
                 ],
                 "summary": "查询数据",
                 "parameters": [
@@ -1115,6 +1352,18 @@ var doc = `{
                         "description": "状态(1:启用 2:禁用)",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "显示状态(1:显示 2:隐藏)",
+                        "name": "showStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "父级ID",
+                        "name": "parentID",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1131,7 +1380,7 @@ var doc = `{
                                         "list": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.Role"
+                                                "$ref": "#/definitions/schema.Menu"
                                             }
                                         }
                                     }
@@ -1160,7 +1409,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "角色管理"
+                    "菜单管理"
                 ],
                 "summary": "创建数据",
                 "parameters": [
@@ -1170,7 +1419,7 @@ var doc = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.Role"
+                            "$ref": "#/definitions/schema.Menu"
                         }
                     }
                 ],
@@ -1202,7 +1451,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/roles.select": {
+        "/api/v1/menus.deleted": {
             "get": {
                 "security": [
                     {
@@ -1210,21 +1459,93 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "角色管理"
+                    "菜单管理"
                 ],
-                "summary": "查询选择数据",
+                "summary": "查询回收站中的数据(已删除的数据)",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "查询值",
+                        "description": "查询值(名称)",
                         "name": "queryValue",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.RecycleItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
                     },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/menus.tree": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "菜单管理"
+                ],
+                "summary": "查询菜单树",
+                "parameters": [
                     {
                         "type": "integer",
                         "description": "状态(1:启用 2:禁用)",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "父级ID",
+                        "name": "parentID",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1241,7 +1562,7 @@ var doc = `{
                                         "list": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.Role"
+                                                "$ref": "#/definitions/schema.MenuTree"
                                             }
                                         }
                                     }
@@ -1249,12 +1570,6 @@ var doc = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "{error:{code:0,message:未知的查询类型}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    },
                     "401": {
                         "description": "{error:{code:0,message:未授权}}",
                         "schema": {
@@ -1270,7 +1585,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/roles/{id}": {
+        "/api/v1/menus/{id}": {
             "get": {
                 "security": [
                     {
@@ -1278,7 +1593,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "角色管理"
+                    "菜单管理"
                 ],
                 "summary": "查询指定数据",
                 "parameters": [
@@ -1294,7 +1609,13 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.Role"
+                            "$ref": "#/definitions/schema.Menu"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "资源版本号"
+                            }
                         }
                     },
                     "401": {
@@ -1324,7 +1645,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "角色管理"
+                    "菜单管理"
                 ],
                 "summary": "更新数据",
                 "parameters": [
@@ -1335,21 +1656,28 @@ var doc = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "description": "更新数据",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.Role"
+                            "$ref": "#/definitions/schema.Menu"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "{status:OK}",
                         "schema": {
-                            "$ref": "#/definitions/schema.Role"
+                            "$ref": "#/definitions/schema.StatusResult"
                         }
                     },
                     "400": {
@@ -1364,6 +1692,18 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1379,7 +1719,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "角色管理"
+                    "菜单管理"
                 ],
                 "summary": "删除数据",
                 "parameters": [
@@ -1413,7 +1753,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/roles/{id}/disable": {
+        "/api/v1/menus/{id}/disable": {
             "patch": {
                 "security": [
                     {
@@ -1421,7 +1761,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "角色管理"
+                    "菜单管理"
                 ],
                 "summary": "禁用数据",
                 "parameters": [
@@ -1431,6 +1771,13 @@ var doc = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1446,6 +1793,18 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1455,7 +1814,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/roles/{id}/enable": {
+        "/api/v1/menus/{id}/enable": {
             "patch": {
                 "security": [
                     {
@@ -1463,7 +1822,7 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "角色管理"
+                    "菜单管理"
                 ],
                 "summary": "启用数据",
                 "parameters": [
@@ -1473,6 +1832,13 @@ var doc = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1488,6 +1854,18 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1497,50 +1875,82 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/users": {
-            "get": {
+        "/api/v1/menus/{id}/restore": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "tags": [
-                    "用户管理"
+                    "菜单管理"
                 ],
-                "summary": "查询数据",
+                "summary": "从回收站恢复数据",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "分页索引",
-                        "name": "current",
-                        "in": "query",
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "分页大小",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "查询值",
-                        "name": "queryValue",
-                        "in": "query"
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
                     },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permissions/apis": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "权限诊断"
+                ],
+                "summary": "查询用户或角色可调用的接口列表",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "角色ID(多个以英文逗号分隔)",
-                        "name": "roleIDs",
+                        "description": "用户ID",
+                        "name": "userID",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "状态(1:启用 2:停用)",
-                        "name": "status",
+                        "type": "string",
+                        "description": "角色ID",
+                        "name": "roleID",
                         "in": "query"
                     }
                 ],
@@ -1558,7 +1968,7 @@ var doc = `{
                                         "list": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/schema.UserShow"
+                                                "$ref": "#/definitions/schema.PermissionAPI"
                                             }
                                         }
                                     }
@@ -1566,12 +1976,24 @@ var doc = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "401": {
                         "description": "{error:{code:0,message:未授权}}",
                         "schema": {
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1579,33 +2001,47 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/permissions/explain": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "tags": [
-                    "用户管理"
+                    "权限诊断"
                 ],
-                "summary": "创建数据",
+                "summary": "诊断用户访问指定接口的判定结果(包括经过的角色、匹配的策略规则及授予权限的菜单动作资源)",
                 "parameters": [
                     {
-                        "description": "创建数据",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.User"
-                        }
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "userID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求路径",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求方式",
+                        "name": "method",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.IDResult"
+                            "$ref": "#/definitions/schema.PermissionExplain"
                         }
                     },
                     "400": {
@@ -1620,6 +2056,12 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1629,7 +2071,7 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/users/{id}": {
+        "/api/v1/pub/current/api-keys": {
             "get": {
                 "security": [
                     {
@@ -1637,23 +2079,29 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "用户管理"
-                ],
-                "summary": "查询指定数据",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "唯一标识",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "登录管理"
                 ],
+                "summary": "查询当前用户的API密钥",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询结果",
                         "schema": {
-                            "$ref": "#/definitions/schema.User"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.APIKey"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -1662,12 +2110,6 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
-                    "404": {
-                        "description": "{error:{code:0,message:资源不存在}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1676,31 +2118,24 @@ var doc = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "tags": [
-                    "用户管理"
+                    "登录管理"
                 ],
-                "summary": "更新数据",
+                "summary": "为当前用户创建API密钥(完整密钥只在创建时返回一次)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "唯一标识",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "更新数据",
+                        "description": "请求参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.User"
+                            "$ref": "#/definitions/schema.APIKeyCreateParam"
                         }
                     }
                 ],
@@ -1708,7 +2143,7 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.User"
+                            "$ref": "#/definitions/schema.APIKeyToken"
                         }
                     },
                     "400": {
@@ -1730,7 +2165,9 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/pub/current/api-keys/{id}": {
             "delete": {
                 "security": [
                     {
@@ -1738,13 +2175,13 @@ var doc = `{
                     }
                 ],
                 "tags": [
-                    "用户管理"
+                    "登录管理"
                 ],
-                "summary": "删除数据",
+                "summary": "撤销当前用户的API密钥",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "唯一标识",
+                        "description": "密钥ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1763,6 +2200,12 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1772,31 +2215,2945 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/users/{id}/disable": {
-            "patch": {
+        "/api/v1/pub/current/menutree": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
                 "tags": [
-                    "用户管理"
+                    "登录管理"
                 ],
-                "summary": "禁用数据",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "唯一标识",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                "summary": "查询当前用户菜单树",
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.MenuTree"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/mfa": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "查询当前用户的两步验证状态",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserMFAStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/mfa/totp": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "登记TOTP密钥(确认后才会启用两步验证)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserMFAEnrollResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "关闭两步验证",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserMFACodeParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/mfa/totp/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "确认并启用两步验证(返回一次性恢复码)",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserMFACodeParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserMFARecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "更新个人密码",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdatePasswordParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "查询当前用户的登录会话",
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.Session"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "撤销当前用户的所有会话",
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "撤销当前用户的指定会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/user": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "获取当前用户信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserLoginInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "429": {
+                        "description": "{error:{code:0,message:登录失败次数过多}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/captcha": {
+            "get": {
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "响应图形验证码",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "验证码ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "重新加载",
+                        "name": "reload",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "图形验证码"
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/captchaid": {
+            "get": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "获取验证码信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginCaptcha"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/exit": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "用户登出",
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/mfa": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "登录两步验证(使用挑战令牌及TOTP验证码或恢复码换取令牌)",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginMFAParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/oidc/{provider}": {
+            "get": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "获取第三方登录授权地址(前端跳转到授权地址，登录后携带code及state调用回调接口)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "身份提供者名称",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.OIDCAuthURL"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/oidc/{provider}/callback": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "第三方登录回调",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "身份提供者名称",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.OIDCCallbackParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/password": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "登录修改过期密码(使用挑战令牌设置新密码后换取令牌)",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginPasswordParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/password/reset": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "申请密码重置(重置令牌通过通知发送给用户)",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.PasswordResetRequestParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/password/reset/confirm": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "使用重置令牌设置新密码(成功后撤销用户的所有会话)",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.PasswordResetConfirmParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/refresh-token": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "刷新令牌",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RefreshTokenParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "查询数据",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:禁用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.Role"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "创建数据",
+                "parameters": [
+                    {
+                        "description": "创建数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.IDResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles.deleted": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "查询回收站中的数据(已删除的数据)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值(名称)",
+                        "name": "queryValue",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.RecycleItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles.select": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "查询选择数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:禁用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.Role"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:未知的查询类型}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "查询指定数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "资源版本号"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "更新数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "删除数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/disable": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "禁用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/enable": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "启用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/restore": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "从回收站恢复数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "查询数据",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:停用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.Tenant"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "创建数据",
+                "parameters": [
+                    {
+                        "description": "创建数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.TenantCreateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.IDResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants.deleted": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "查询回收站中的数据(已删除的数据)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值(名称)",
+                        "name": "queryValue",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.RecycleItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "查询指定数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.Tenant"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "更新数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Tenant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "删除数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/disable": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "禁用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/enable": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "启用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/restore": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "从回收站恢复数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user-roles/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询指定天数内即将到期的角色授权(按失效时间升序)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 7,
+                        "description": "到期天数(1-365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.UserRoleExpiring"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询数据",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色ID(多个以英文逗号分隔)",
+                        "name": "roleIDs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "所属部门ID",
+                        "name": "deptID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:停用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.UserShow"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "创建数据",
+                "parameters": [
+                    {
+                        "description": "创建数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.IDResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users.deleted": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询回收站中的数据(已删除的数据)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值(名称)",
+                        "name": "queryValue",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.RecycleItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询指定数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "资源版本号"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "删除数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询指定用户的API密钥",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.APIKey"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "为指定用户创建API密钥(完整密钥只在创建时返回一次)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.APIKeyCreateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.APIKeyToken"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/api-keys/{key_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "撤销指定用户的API密钥",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "密钥ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/disable": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "禁用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/enable": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "启用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源版本号(查询时返回的ETag)",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "412": {
+                        "description": "{error:{code:0,message:资源已被修改，请刷新后重试}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "428": {
+                        "description": "{error:{code:0,message:缺少资源版本(If-Match)}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "模拟指定用户登录(返回短期访问令牌，令牌同时记录被模拟用户及实际操作者，不提供刷新令牌)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "403": {
+                        "description": "{error:{code:0,message:模拟登录时不允许此操作}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/lockout": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询指定用户的登录锁定状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginLockout"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "解除指定用户的登录锁定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/restore": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "从回收站恢复数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "唯一标识",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询指定用户的登录会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{status:OK}",
+                        "description": "查询结果",
                         "schema": {
-                            "$ref": "#/definitions/schema.StatusResult"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/schema.ListResult"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "list": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/schema.Session"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -1805,6 +5162,12 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1812,10 +5175,8 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/users/{id}/enable": {
-            "patch": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
@@ -1824,11 +5185,11 @@ var doc = `{
                 "tags": [
                     "用户管理"
                 ],
-                "summary": "启用数据",
+                "summary": "强制指定用户下线",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "唯一标识",
+                        "description": "用户ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1847,6 +5208,12 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1855,21 +5222,311 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "schema.Demo": {
+        }
+    },
+    "definitions": {
+        "jwtauth.JSONWebKey": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "description": "签名算法",
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "曲线",
+                    "type": "string"
+                },
+                "e": {
+                    "description": "RSA指数",
+                    "type": "string"
+                },
+                "kid": {
+                    "description": "密钥ID",
+                    "type": "string"
+                },
+                "kty": {
+                    "description": "密钥类型",
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA模数",
+                    "type": "string"
+                },
+                "use": {
+                    "description": "用途",
+                    "type": "string"
+                },
+                "x": {
+                    "description": "曲线X坐标(Ed25519为公钥)",
+                    "type": "string"
+                },
+                "y": {
+                    "description": "曲线Y坐标",
+                    "type": "string"
+                }
+            }
+        },
+        "jwtauth.JSONWebKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jwtauth.JSONWebKey"
+                    }
+                }
+            }
+        },
+        "schema.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "到期时间(为空时永不过期)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "最后使用时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "可见前缀(用于识别密钥)",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "权限范围(为空时与所属用户的权限相同)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.APIKeyScope"
+                    }
+                },
+                "user_id": {
+                    "description": "所属用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.APIKeyCreateParam": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "到期时间(为空时永不过期)",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "权限范围(为空时与所属用户的权限相同)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.APIKeyScope"
+                    }
+                }
+            }
+        },
+        "schema.APIKeyScope": {
+            "type": "object",
+            "required": [
+                "method",
+                "path"
+            ],
+            "properties": {
+                "method": {
+                    "description": "请求方式(正则匹配，例如：GET|POST)",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "请求路径(例如：/api/v1/users/:id)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.APIKeyToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "到期时间(为空时永不过期)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "最后使用时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "可见前缀(用于识别密钥)",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "权限范围(为空时与所属用户的权限相同)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.APIKeyScope"
+                    }
+                },
+                "token": {
+                    "description": "完整密钥(只在创建时返回一次，请妥善保存)",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "所属用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.AuditChange": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "description": "字段名",
+                    "type": "string"
+                },
+                "new": {
+                    "description": "变更后的值",
+                    "type": "object"
+                },
+                "old": {
+                    "description": "变更前的值",
+                    "type": "object"
+                }
+            }
+        },
+        "schema.AuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "变更类型(create:新增 update:修改 delete:删除)",
+                    "type": "string"
+                },
+                "actor_id": {
+                    "description": "实际操作者ID",
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "字段变更",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.AuditChange"
+                    }
+                },
+                "created_at": {
+                    "description": "变更时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "数据主键",
+                    "type": "string"
+                },
+                "table": {
+                    "description": "数据表(不含表名前缀，例如：user)",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "跟踪ID",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "操作用户ID(模拟登录时为被模拟的用户)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.Demo": {
+            "type": "object",
+            "required": [
+                "code",
+                "name",
+                "status"
+            ],
+            "properties": {
+                "code": {
+                    "description": "编号",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "memo": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:停用)",
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "版本号",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.Dept": {
             "type": "object",
             "required": [
-                "code",
                 "name",
                 "status"
             ],
             "properties": {
-                "code": {
-                    "description": "编号",
-                    "type": "string"
-                },
                 "created_at": {
                     "description": "创建时间",
                     "type": "string"
@@ -1887,19 +5544,71 @@ var doc = `{
                     "type": "string"
                 },
                 "name": {
-                    "description": "名称",
+                    "description": "部门名称",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "父级ID",
+                    "type": "string"
+                },
+                "parent_path": {
+                    "description": "父级路径",
                     "type": "string"
                 },
+                "sequence": {
+                    "description": "排序值",
+                    "type": "integer"
+                },
                 "status": {
-                    "description": "状态(1:启用 2:停用)",
+                    "description": "状态(1:启用 2:禁用)",
                     "type": "integer"
                 },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
                 "updated_at": {
                     "description": "更新时间",
                     "type": "string"
                 }
             }
         },
+        "schema.DeptTree": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "子级树",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.DeptTree"
+                    }
+                },
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "部门名称",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "父级ID",
+                    "type": "string"
+                },
+                "parent_path": {
+                    "description": "父级路径",
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "排序值",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:禁用)",
+                    "type": "integer"
+                }
+            }
+        },
         "schema.ErrorItem": {
             "type": "object",
             "properties": {
@@ -1907,6 +5616,10 @@ var doc = `{
                     "description": "错误码",
                     "type": "integer"
                 },
+                "details": {
+                    "description": "错误详情",
+                    "type": "object"
+                },
                 "message": {
                     "description": "错误信息",
                     "type": "string"
@@ -1950,25 +5663,61 @@ var doc = `{
                 }
             }
         },
+        "schema.LoginLockout": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "description": "统计窗口内的失败次数",
+                    "type": "integer"
+                },
+                "locked": {
+                    "description": "是否处于锁定状态",
+                    "type": "boolean"
+                },
+                "locked_for": {
+                    "description": "剩余锁定时长(单位秒)",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.LoginMFAParam": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "TOTP验证码或恢复码",
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "description": "挑战令牌",
+                    "type": "string"
+                }
+            }
+        },
         "schema.LoginParam": {
             "type": "object",
             "required": [
-                "captcha_code",
-                "captcha_id",
                 "password",
                 "user_name"
             ],
             "properties": {
                 "captcha_code": {
-                    "description": "验证码",
+                    "description": "验证码(登录失败次数达到阈值后必填)",
                     "type": "string"
                 },
                 "captcha_id": {
-                    "description": "验证码ID",
+                    "description": "验证码ID(登录失败次数达到阈值后必填)",
                     "type": "string"
                 },
                 "password": {
-                    "description": "密码(md5加密)",
+                    "description": "密码",
+                    "type": "string"
+                },
+                "tenant_code": {
+                    "description": "租户编号(为空时登录默认租户)",
                     "type": "string"
                 },
                 "user_name": {
@@ -1977,6 +5726,23 @@ var doc = `{
                 }
             }
         },
+        "schema.LoginPasswordParam": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "password_token"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "新密码(需符合密码策略)",
+                    "type": "string"
+                },
+                "password_token": {
+                    "description": "挑战令牌",
+                    "type": "string"
+                }
+            }
+        },
         "schema.LoginTokenInfo": {
             "type": "object",
             "properties": {
@@ -1988,6 +5754,10 @@ var doc = `{
                     "description": "令牌到期时间戳",
                     "type": "integer"
                 },
+                "refresh_token": {
+                    "description": "刷新令牌(仅能使用一次)",
+                    "type": "string"
+                },
                 "token_type": {
                     "description": "令牌类型",
                     "type": "string"
@@ -2057,9 +5827,17 @@ var doc = `{
                     "description": "状态(1:启用 2:禁用)",
                     "type": "integer"
                 },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
                 "updated_at": {
                     "description": "更新时间",
                     "type": "string"
+                },
+                "version": {
+                    "description": "版本号",
+                    "type": "integer"
                 }
             }
         },
@@ -2138,37 +5916,238 @@ var doc = `{
                         "$ref": "#/definitions/schema.MenuTree"
                     }
                 },
-                "icon": {
-                    "description": "菜单图标",
+                "icon": {
+                    "description": "菜单图标",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "菜单名称",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "父级ID",
+                    "type": "string"
+                },
+                "parent_path": {
+                    "description": "父级路径",
+                    "type": "string"
+                },
+                "router": {
+                    "description": "访问路由",
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "排序值",
+                    "type": "integer"
+                },
+                "show_status": {
+                    "description": "显示状态(1:显示 2:隐藏)",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:禁用)",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.OIDCAuthURL": {
+            "type": "object",
+            "properties": {
+                "auth_url": {
+                    "description": "授权地址(前端跳转到该地址进行登录)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.OIDCCallbackParam": {
+            "type": "object",
+            "required": [
+                "code",
+                "state"
+            ],
+            "properties": {
+                "code": {
+                    "description": "授权码",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "状态",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.PaginationResult": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.PasswordResetConfirmParam": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "新密码(需符合密码策略)",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "重置令牌",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.PasswordResetRequestParam": {
+            "type": "object",
+            "required": [
+                "user_name"
+            ],
+            "properties": {
+                "tenant_code": {
+                    "description": "租户编号(为空时为默认租户)",
+                    "type": "string"
+                },
+                "user_name": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.PermissionAPI": {
+            "type": "object",
+            "properties": {
+                "method": {
+                    "description": "请求方式(支持正则)",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "请求路径(支持/:id匹配)",
+                    "type": "string"
+                },
+                "subjects": {
+                    "description": "授予该接口的主体(角色ID或用户ID)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.PermissionExplain": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "description": "是否允许访问",
+                    "type": "boolean"
+                },
+                "grants": {
+                    "description": "授予权限的菜单动作资源",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionGrant"
+                    }
+                },
+                "method": {
+                    "description": "请求方式",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "请求路径",
+                    "type": "string"
+                },
+                "policies": {
+                    "description": "匹配的策略规则",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "description": "判定说明",
+                    "type": "string"
+                },
+                "roles": {
+                    "description": "用户经过的角色(包括继承的角色)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.PermissionRole"
+                    }
+                },
+                "tenant_id": {
+                    "description": "租户ID(casbin的域)",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.PermissionGrant": {
+            "type": "object",
+            "properties": {
+                "action_code": {
+                    "description": "动作编号",
+                    "type": "string"
+                },
+                "action_id": {
+                    "description": "动作ID",
+                    "type": "string"
+                },
+                "action_name": {
+                    "description": "动作名称",
                     "type": "string"
                 },
-                "id": {
-                    "description": "唯一标识",
+                "menu_id": {
+                    "description": "菜单ID",
                     "type": "string"
                 },
-                "name": {
+                "menu_name": {
                     "description": "菜单名称",
                     "type": "string"
                 },
-                "parent_id": {
-                    "description": "父级ID",
+                "method": {
+                    "description": "资源请求方式",
                     "type": "string"
                 },
-                "parent_path": {
-                    "description": "父级路径",
+                "path": {
+                    "description": "资源请求路径",
                     "type": "string"
                 },
-                "router": {
-                    "description": "访问路由",
+                "role_id": {
+                    "description": "角色ID",
                     "type": "string"
                 },
-                "sequence": {
-                    "description": "排序值",
-                    "type": "integer"
+                "role_name": {
+                    "description": "角色名称",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.PermissionRole": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "角色ID",
+                    "type": "string"
                 },
-                "show_status": {
-                    "description": "显示状态(1:显示 2:隐藏)",
-                    "type": "integer"
+                "name": {
+                    "description": "角色名称",
+                    "type": "string"
                 },
                 "status": {
                     "description": "状态(1:启用 2:禁用)",
@@ -2176,17 +6155,32 @@ var doc = `{
                 }
             }
         },
-        "schema.PaginationResult": {
+        "schema.RecycleItem": {
             "type": "object",
             "properties": {
-                "current": {
-                    "type": "integer"
+                "deleted_at": {
+                    "description": "删除时间",
+                    "type": "string"
                 },
-                "pageSize": {
-                    "type": "integer"
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.RefreshTokenParam": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "刷新令牌",
+                    "type": "string"
                 }
             }
         },
@@ -2206,6 +6200,17 @@ var doc = `{
                     "description": "创建者",
                     "type": "string"
                 },
+                "data_scope": {
+                    "description": "数据权限范围(1:全部 2:自定义部门 3:本部门 4:本部门及以下 5:仅本人)",
+                    "type": "integer"
+                },
+                "dept_ids": {
+                    "description": "自定义数据权限的部门ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "description": "唯一标识",
                     "type": "string"
@@ -2218,6 +6223,13 @@ var doc = `{
                     "description": "角色名称",
                     "type": "string"
                 },
+                "parent_ids": {
+                    "description": "继承的父级角色ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "role_menus": {
                     "description": "角色菜单列表",
                     "type": "array",
@@ -2233,9 +6245,17 @@ var doc = `{
                     "description": "状态(1:启用 2:禁用)",
                     "type": "integer"
                 },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
                 "updated_at": {
                     "description": "更新时间",
                     "type": "string"
+                },
+                "version": {
+                    "description": "版本号",
+                    "type": "integer"
                 }
             }
         },
@@ -2265,6 +6285,35 @@ var doc = `{
                 }
             }
         },
+        "schema.Session": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "description": "模拟登录的实际操作者ID(被模拟用户的会话)",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "会话ID",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "登录IP",
+                    "type": "string"
+                },
+                "issued_at": {
+                    "description": "登录时间戳",
+                    "type": "integer"
+                },
+                "target_id": {
+                    "description": "模拟登录的目标用户ID(实际操作者的会话)",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "description": "客户端标识",
+                    "type": "string"
+                }
+            }
+        },
         "schema.StatusResult": {
             "type": "object",
             "properties": {
@@ -2274,6 +6323,104 @@ var doc = `{
                 }
             }
         },
+        "schema.Tenant": {
+            "type": "object",
+            "required": [
+                "code",
+                "name",
+                "status"
+            ],
+            "properties": {
+                "code": {
+                    "description": "租户编号(登录时用于区分租户)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "memo": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "租户名称",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:停用)",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.TenantCreateParam": {
+            "type": "object",
+            "required": [
+                "admin_password",
+                "admin_user_name",
+                "code",
+                "name",
+                "status"
+            ],
+            "properties": {
+                "admin_password": {
+                    "description": "管理员密码",
+                    "type": "string"
+                },
+                "admin_real_name": {
+                    "description": "管理员真实姓名",
+                    "type": "string"
+                },
+                "admin_user_name": {
+                    "description": "管理员用户名",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "租户编号(登录时用于区分租户)",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "memo": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "租户名称",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:停用)",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
         "schema.UpdatePasswordParam": {
             "type": "object",
             "required": [
@@ -2282,11 +6429,11 @@ var doc = `{
             ],
             "properties": {
                 "new_password": {
-                    "description": "新密码(md5加密)",
+                    "description": "新密码(需符合密码策略)",
                     "type": "string"
                 },
                 "old_password": {
-                    "description": "旧密码(md5加密)",
+                    "description": "旧密码",
                     "type": "string"
                 }
             }
@@ -2308,6 +6455,10 @@ var doc = `{
                     "description": "创建者",
                     "type": "string"
                 },
+                "dept_id": {
+                    "description": "所属部门ID",
+                    "type": "string"
+                },
                 "email": {
                     "description": "邮箱",
                     "type": "string"
@@ -2316,10 +6467,18 @@ var doc = `{
                     "description": "唯一标识",
                     "type": "string"
                 },
+                "is_super": {
+                    "description": "是否超级管理员(仅能通过初始化创建)",
+                    "type": "boolean"
+                },
                 "password": {
                     "description": "密码",
                     "type": "string"
                 },
+                "password_changed_at": {
+                    "description": "密码修改时间",
+                    "type": "string"
+                },
                 "phone": {
                     "description": "手机号",
                     "type": "string"
@@ -2332,6 +6491,10 @@ var doc = `{
                     "description": "用户状态(1:启用 2:停用)",
                     "type": "integer"
                 },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
                 "user_name": {
                     "description": "用户名",
                     "type": "string"
@@ -2342,12 +6505,24 @@ var doc = `{
                     "items": {
                         "$ref": "#/definitions/schema.UserRole"
                     }
+                },
+                "version": {
+                    "description": "版本号",
+                    "type": "integer"
                 }
             }
         },
         "schema.UserLoginInfo": {
             "type": "object",
             "properties": {
+                "actor_id": {
+                    "description": "实际操作者ID(模拟登录时为管理员ID)",
+                    "type": "string"
+                },
+                "is_super": {
+                    "description": "是否超级管理员",
+                    "type": "boolean"
+                },
                 "real_name": {
                     "description": "真实姓名",
                     "type": "string"
@@ -2369,6 +6544,52 @@ var doc = `{
                 }
             }
         },
+        "schema.UserMFACodeParam": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "TOTP验证码或恢复码",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.UserMFAEnrollResult": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "description": "TOTP密钥(base32编码，用于手动输入)",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "otpauth地址(用于生成二维码)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.UserMFARecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "恢复码列表(每个仅能使用一次)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.UserMFAStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "是否已启用",
+                    "type": "boolean"
+                }
+            }
+        },
         "schema.UserRole": {
             "type": "object",
             "properties": {
@@ -2383,6 +6604,51 @@ var doc = `{
                 "user_id": {
                     "description": "用户ID",
                     "type": "string"
+                },
+                "valid_from": {
+                    "description": "生效时间(为空表示立即生效)",
+                    "type": "string"
+                },
+                "valid_until": {
+                    "description": "失效时间(为空表示永久有效)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.UserRoleExpiring": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "唯一标识",
+                    "type": "string"
+                },
+                "real_name": {
+                    "description": "真实姓名",
+                    "type": "string"
+                },
+                "role_id": {
+                    "description": "角色ID",
+                    "type": "string"
+                },
+                "role_name": {
+                    "description": "角色名称",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "user_name": {
+                    "description": "用户名",
+                    "type": "string"
+                },
+                "valid_from": {
+                    "description": "生效时间",
+                    "type": "string"
+                },
+                "valid_until": {
+                    "description": "失效时间",
+                    "type": "string"
                 }
             }
         },
@@ -2423,6 +6689,10 @@ var doc = `{
                 "user_name": {
                     "description": "用户名",
                     "type": "string"
+                },
+                "version": {
+                    "description": "版本号",
+                    "type": "integer"
                 }
             }
         }
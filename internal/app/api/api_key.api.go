@@ -0,0 +1,150 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/internal/app/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// APIKeySet 注入APIKey
+var APIKeySet = wire.NewSet(wire.Struct(new(APIKey), "*"))
+
+// APIKey API密钥管理
+type APIKey struct {
+	APIKeySrv *service.APIKey
+}
+
+// QueryCurrent 查询当前用户的API密钥
+// @Tags 登录管理
+// @Summary 查询当前用户的API密钥
+// @Security ApiKeyAuth
+// @Success 200 {object} schema.ListResult{list=[]schema.APIKey} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/api-keys [get]
+func (a *APIKey) QueryCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := a.APIKeySrv.Query(ctx, ginx.GetUserID(c))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResList(c, list)
+}
+
+// CreateCurrent 为当前用户创建API密钥
+// @Tags 登录管理
+// @Summary 为当前用户创建API密钥(完整密钥只在创建时返回一次)
+// @Security ApiKeyAuth
+// @Param body body schema.APIKeyCreateParam true "请求参数"
+// @Success 200 {object} schema.APIKeyToken
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/api-keys [post]
+func (a *APIKey) CreateCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.APIKeyCreateParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	userID := ginx.GetUserID(c)
+	result, err := a.APIKeySrv.Create(ctx, userID, item, userID)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, result)
+}
+
+// DeleteCurrent 撤销当前用户的API密钥
+// @Tags 登录管理
+// @Summary 撤销当前用户的API密钥
+// @Security ApiKeyAuth
+// @Param id path string true "密钥ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/api-keys/{id} [delete]
+func (a *APIKey) DeleteCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.APIKeySrv.Delete(ctx, ginx.GetUserID(c), c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// Query 查询指定用户的API密钥
+// @Tags 用户管理
+// @Summary 查询指定用户的API密钥
+// @Security ApiKeyAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} schema.ListResult{list=[]schema.APIKey} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/api-keys [get]
+func (a *APIKey) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := a.APIKeySrv.Query(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResList(c, list)
+}
+
+// Create 为指定用户创建API密钥(例如服务账号)
+// @Tags 用户管理
+// @Summary 为指定用户创建API密钥(完整密钥只在创建时返回一次)
+// @Security ApiKeyAuth
+// @Param id path string true "用户ID"
+// @Param body body schema.APIKeyCreateParam true "请求参数"
+// @Success 200 {object} schema.APIKeyToken
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/api-keys [post]
+func (a *APIKey) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.APIKeyCreateParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	result, err := a.APIKeySrv.Create(ctx, c.Param("id"), item, ginx.GetUserID(c))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, result)
+}
+
+// Delete 撤销指定用户的API密钥
+// @Tags 用户管理
+// @Summary 撤销指定用户的API密钥
+// @Security ApiKeyAuth
+// @Param id path string true "用户ID"
+// @Param key_id path string true "密钥ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/api-keys/{key_id} [delete]
+func (a *APIKey) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.APIKeySrv.Delete(ctx, c.Param("id"), c.Param("key_id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/internal/app/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// AuditLogSet 注入AuditLog
+var AuditLogSet = wire.NewSet(wire.Struct(new(AuditLog), "*"))
+
+// AuditLog 数据变更审计
+type AuditLog struct {
+	AuditLogSrv *service.AuditLog
+}
+
+// Query 查询数据
+// @Tags 审计日志
+// @Security ApiKeyAuth
+// @Summary 查询数据变更记录(按变更时间倒序)
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param table query string false "数据表(不含表名前缀，例如：user)"
+// @Param recordID query string false "数据主键"
+// @Param actorID query string false "实际操作者ID"
+// @Param action query string false "变更类型(create:新增 update:修改 delete:删除)"
+// @Param startTime query string false "开始时间(格式：2006-01-02 15:04:05)"
+// @Param endTime query string false "结束时间(格式：2006-01-02 15:04:05)"
+// @Success 200 {object} schema.ListResult{list=[]schema.AuditLog} "查询结果"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/audit-logs [get]
+func (a *AuditLog) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.AuditLogQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.AuditLogSrv.Query(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
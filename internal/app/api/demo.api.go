@@ -51,6 +51,7 @@ func (a *Demo) Query(c *gin.Context) {
 // @Summary 查询指定数据
 // @Param id path string true "唯一标识"
 // @Success 200 {object} schema.Demo
+// @Header 200 {string} ETag "资源版本号"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
 // @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
@@ -62,6 +63,7 @@ func (a *Demo) Get(c *gin.Context) {
 		ginx.ResError(c, err)
 		return
 	}
+	ginx.SetETag(c, item.Version)
 	ginx.ResSuccess(c, item)
 }
 
@@ -96,10 +98,13 @@ func (a *Demo) Create(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Summary 更新数据
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Param body body schema.Demo true "更新数据"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/demos/{id} [put]
 func (a *Demo) Update(c *gin.Context) {
@@ -110,12 +115,20 @@ func (a *Demo) Update(c *gin.Context) {
 		return
 	}
 
-	err := a.DemoSrv.Update(ctx, c.Param("id"), item)
+	version, err := ginx.ParseIfMatch(c)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
+	item.Version = version
 
+	err = a.DemoSrv.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	ginx.SetETag(c, version+1)
 	ginx.ResOK(c)
 }
 
@@ -143,17 +156,27 @@ func (a *Demo) Delete(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Summary 启用数据
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/demos/{id}/enable [patch]
 func (a *Demo) Enable(c *gin.Context) {
 	ctx := c.Request.Context()
-	err := a.DemoSrv.UpdateStatus(ctx, c.Param("id"), 1)
+	version, err := ginx.ParseIfMatch(c)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
+
+	err = a.DemoSrv.UpdateStatus(ctx, c.Param("id"), 1, version)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
 	ginx.ResOK(c)
 }
 
@@ -162,13 +185,72 @@ func (a *Demo) Enable(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Summary 禁用数据
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/demos/{id}/disable [patch]
 func (a *Demo) Disable(c *gin.Context) {
 	ctx := c.Request.Context()
-	err := a.DemoSrv.UpdateStatus(ctx, c.Param("id"), 2)
+	version, err := ginx.ParseIfMatch(c)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	err = a.DemoSrv.UpdateStatus(ctx, c.Param("id"), 2, version)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
+	ginx.ResOK(c)
+}
+
+// QueryDeleted 查询回收站中的数据
+// @Tags Demo
+// @Summary 查询回收站中的数据(已删除的数据)
+// @Security ApiKeyAuth
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值(名称)"
+// @Success 200 {object} schema.ListResult{list=[]schema.RecycleItem} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/demos.deleted [get]
+func (a *Demo) QueryDeleted(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.RecycleQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.DemoSrv.QueryDeleted(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
+
+// Restore 从回收站恢复数据
+// @Tags Demo
+// @Summary 从回收站恢复数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/demos/{id}/restore [patch]
+func (a *Demo) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.DemoSrv.Restore(ctx, c.Param("id"))
 	if err != nil {
 		ginx.ResError(c, err)
 		return
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/internal/app/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// DeptSet 注入Dept
+var DeptSet = wire.NewSet(wire.Struct(new(Dept), "*"))
+
+// Dept 部门管理
+type Dept struct {
+	DeptSrv *service.Dept
+}
+
+// Query 查询数据
+// @Tags 部门管理
+// @Summary 查询数据
+// @Security ApiKeyAuth
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值"
+// @Param status query int false "状态(1:启用 2:禁用)"
+// @Param parentID query string false "父级ID"
+// @Success 200 {object} schema.ListResult{list=[]schema.Dept} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts [get]
+func (a *Dept) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.DeptQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.DeptSrv.Query(ctx, params, schema.DeptQueryOptions{
+		OrderFields: schema.NewOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
+	})
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
+
+// QueryTree 查询部门树
+// @Tags 部门管理
+// @Summary 查询部门树
+// @Security ApiKeyAuth
+// @Param status query int false "状态(1:启用 2:禁用)"
+// @Param parentID query string false "父级ID"
+// @Success 200 {object} schema.ListResult{list=[]schema.DeptTree} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts.tree [get]
+func (a *Dept) QueryTree(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.DeptQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	result, err := a.DeptSrv.Query(ctx, params, schema.DeptQueryOptions{
+		OrderFields: schema.NewOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
+	})
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResList(c, result.Data.ToTree())
+}
+
+// Get 查询指定数据
+// @Tags 部门管理
+// @Summary 查询指定数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.Dept
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts/{id} [get]
+func (a *Dept) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.DeptSrv.Get(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, item)
+}
+
+// Create 创建数据
+// @Tags 部门管理
+// @Summary 创建数据
+// @Security ApiKeyAuth
+// @Param body body schema.Dept true "创建数据"
+// @Success 200 {object} schema.IDResult
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts [post]
+func (a *Dept) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Dept
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	item.Creator = ginx.GetUserID(c)
+	result, err := a.DeptSrv.Create(ctx, item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, result)
+}
+
+// Update 更新数据
+// @Tags 部门管理
+// @Summary 更新数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Param body body schema.Dept true "更新数据"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts/{id} [put]
+func (a *Dept) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Dept
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	err := a.DeptSrv.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// Delete 删除数据
+// @Tags 部门管理
+// @Summary 删除数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts/{id} [delete]
+func (a *Dept) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.DeptSrv.Delete(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// Enable 启用数据
+// @Tags 部门管理
+// @Summary 启用数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts/{id}/enable [patch]
+func (a *Dept) Enable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.DeptSrv.UpdateStatus(ctx, c.Param("id"), 1)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// Disable 禁用数据
+// @Tags 部门管理
+// @Summary 禁用数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts/{id}/disable [patch]
+func (a *Dept) Disable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.DeptSrv.UpdateStatus(ctx, c.Param("id"), 2)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// QueryDeleted 查询回收站中的数据
+// @Tags 部门管理
+// @Summary 查询回收站中的数据(已删除的数据)
+// @Security ApiKeyAuth
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值(名称)"
+// @Success 200 {object} schema.ListResult{list=[]schema.RecycleItem} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts.deleted [get]
+func (a *Dept) QueryDeleted(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.RecycleQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.DeptSrv.QueryDeleted(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
+
+// Restore 从回收站恢复数据
+// @Tags 部门管理
+// @Summary 从回收站恢复数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/depts/{id}/restore [patch]
+func (a *Dept) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.DeptSrv.Restore(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
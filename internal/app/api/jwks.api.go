@@ -0,0 +1,25 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/pkg/auth/jwtauth"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// JWKSSet 注入JWKS
+var JWKSSet = wire.NewSet(wire.Struct(new(JWKS), "*"))
+
+// JWKS 令牌验签公钥
+type JWKS struct {
+	KeySet *jwtauth.KeySet
+}
+
+// Get 获取令牌验签公钥集合
+// @Tags 登录管理
+// @Summary 获取令牌验签公钥集合(JWKS)
+// @Success 200 {object} jwtauth.JSONWebKeySet
+// @Router /.well-known/jwks.json [get]
+func (a *JWKS) Get(c *gin.Context) {
+	ginx.ResSuccess(c, a.KeySet.JWKS())
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// LockoutSet 注入Lockout
+var LockoutSet = wire.NewSet(wire.Struct(new(Lockout), "*"))
+
+// Lockout 登录锁定管理
+type Lockout struct {
+	LockoutSrv *service.Lockout
+}
+
+// GetUser 查询指定用户的登录锁定状态
+// @Tags 用户管理
+// @Summary 查询指定用户的登录锁定状态
+// @Security ApiKeyAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} schema.LoginLockout
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/lockout [get]
+func (a *Lockout) GetUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.LockoutSrv.GetByUser(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, item)
+}
+
+// UnlockUser 解除指定用户的登录锁定
+// @Tags 用户管理
+// @Summary 解除指定用户的登录锁定
+// @Security ApiKeyAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/lockout [delete]
+func (a *Lockout) UnlockUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.LockoutSrv.UnlockUser(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// GetIP 查询指定IP的登录锁定状态
+// @Tags 用户管理
+// @Summary 查询指定IP的登录锁定状态
+// @Security ApiKeyAuth
+// @Param ip path string true "IP地址"
+// @Success 200 {object} schema.LoginLockout
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/lockouts/ips/{ip} [get]
+func (a *Lockout) GetIP(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.LockoutSrv.GetByIP(ctx, c.Param("ip"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, item)
+}
+
+// UnlockIP 解除指定IP的登录锁定
+// @Tags 用户管理
+// @Summary 解除指定IP的登录锁定
+// @Security ApiKeyAuth
+// @Param ip path string true "IP地址"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/lockouts/ips/{ip} [delete]
+func (a *Lockout) UnlockIP(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.LockoutSrv.UnlockIP(ctx, c.Param("ip"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"context"
 	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
 	"ginAdmin/internal/app/ginx"
 	"ginAdmin/internal/app/schema"
 	"ginAdmin/internal/app/service"
@@ -17,7 +19,9 @@ var LoginSet = wire.NewSet(wire.Struct(new(Login), "*"))
 
 // Login 登录管理
 type Login struct {
-	LoginSrv *service.Login
+	LoginSrv         *service.Login
+	MFASrv           *service.MFA
+	ExternalLoginSrv *service.ExternalLogin
 }
 
 // GetCaptcha 获取验证码信息
@@ -67,12 +71,14 @@ func (a *Login) ResCaptcha(c *gin.Context) {
 	}
 }
 
-// Login 用户登录
+// Login 用户登录(用户启用两步验证时返回schema.LoginMFAChallenge，需通过/api/v1/pub/login/mfa换取令牌；
+// 密码过期时返回schema.LoginPasswordChallenge，需通过/api/v1/pub/login/password修改密码后换取令牌)
 // @Tags 登录管理
 // @Summary 用户登录
 // @Param body body schema.LoginParam true "请求参数"
 // @Success 200 {object} schema.LoginTokenInfo
 // @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 429 {object} schema.ErrorResult "{error:{code:0,message:登录失败次数过多}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/pub/login [post]
 func (a *Login) Login(c *gin.Context) {
@@ -83,22 +89,190 @@ func (a *Login) Login(c *gin.Context) {
 		return
 	}
 
-	if !captcha.VerifyString(item.CaptchaID, item.CaptchaCode) {
-		ginx.ResError(c, errors.New400Response("无效的验证码"))
+	//	用户名只在租户内唯一，在所属租户内验证用户
+	tenantID, err := a.LoginSrv.GetTenantID(ctx, item.TenantCode)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ctx = a.wrapTenantContext(c, tenantID)
+
+	//	失败次数达到阈值后才需要验证码
+	captchaRequired, err := a.LoginSrv.CheckLockout(ctx, item.UserName, c.ClientIP())
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	} else if captchaRequired {
+		if item.CaptchaID == "" || item.CaptchaCode == "" {
+			ginx.ResError(c, errors.ErrCaptchaRequired)
+			return
+		} else if !captcha.VerifyString(item.CaptchaID, item.CaptchaCode) {
+			ginx.ResError(c, errors.New400Response("无效的验证码"))
+			return
+		}
+	}
+
+	user, err := a.LoginSrv.Verify(ctx, item.UserName, item.Password, c.ClientIP())
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	a.startLogin(c, user.ID)
+}
+
+// OIDCAuthURL 获取第三方登录授权地址
+// @Tags 登录管理
+// @Summary 获取第三方登录授权地址(前端跳转到授权地址，登录后携带code及state调用回调接口)
+// @Param provider path string true "身份提供者名称"
+// @Success 200 {object} schema.OIDCAuthURL
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/login/oidc/{provider} [get]
+func (a *Login) OIDCAuthURL(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.ExternalLoginSrv.AuthURL(ctx, c.Param("provider"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, item)
+}
+
+// OIDCCallback 第三方登录回调(与用户登录相同，可能返回两步验证或修改密码挑战)
+// @Tags 登录管理
+// @Summary 第三方登录回调
+// @Param provider path string true "身份提供者名称"
+// @Param body body schema.OIDCCallbackParam true "请求参数"
+// @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/login/oidc/{provider}/callback [post]
+func (a *Login) OIDCCallback(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.OIDCCallbackParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
 		return
 	}
 
-	user, err := a.LoginSrv.Verify(ctx, item.UserName, item.Password)
+	user, err := a.ExternalLoginSrv.Callback(ctx, c.Param("provider"), item)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
 
-	userID := user.ID
+	a.wrapTenantContext(c, user.TenantID)
+	a.startLogin(c, user.ID)
+}
+
+// 将用户所属租户放入请求上下文(登录过程中的查询均限定在该租户内)
+func (a *Login) wrapTenantContext(c *gin.Context, tenantID string) context.Context {
+	ginx.SetTenantID(c, tenantID)
+	ctx := contextx.NewTenantID(c.Request.Context(), tenantID)
+	c.Request = c.Request.WithContext(ctx)
+	return ctx
+}
+
+// 身份校验通过后开始登录(用户启用两步验证时返回两步验证挑战)
+func (a *Login) startLogin(c *gin.Context, userID string) {
+	ctx := c.Request.Context()
 	// 将用户ID放入上下文
 	ginx.SetUserID(c, userID)
 
-	tokenInfo, err := a.LoginSrv.GenerateToken(ctx, userID)
+	enabled, err := a.MFASrv.IsEnabled(ctx, userID)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	} else if enabled {
+		challenge, err := a.LoginSrv.GenerateMFAChallenge(ctx, userID)
+		if err != nil {
+			ginx.ResError(c, err)
+			return
+		}
+		ginx.ResSuccess(c, challenge)
+		return
+	}
+
+	a.completeLogin(c, userID)
+}
+
+// VerifyMFA 登录两步验证
+// @Tags 登录管理
+// @Summary 登录两步验证(使用挑战令牌及TOTP验证码或恢复码换取令牌)
+// @Param body body schema.LoginMFAParam true "请求参数"
+// @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/login/mfa [post]
+func (a *Login) VerifyMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.LoginMFAParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	user, err := a.LoginSrv.VerifyMFA(ctx, item, c.ClientIP())
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	a.wrapTenantContext(c, user.TenantID)
+	ginx.SetUserID(c, user.ID)
+	a.completeLogin(c, user.ID)
+}
+
+// ChangeExpiredPassword 登录修改过期密码
+// @Tags 登录管理
+// @Summary 登录修改过期密码(使用挑战令牌设置新密码后换取令牌)
+// @Param body body schema.LoginPasswordParam true "请求参数"
+// @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/login/password [post]
+func (a *Login) ChangeExpiredPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.LoginPasswordParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	user, err := a.LoginSrv.ChangeExpiredPassword(ctx, item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	a.wrapTenantContext(c, user.TenantID)
+	ginx.SetUserID(c, user.ID)
+	a.generateToken(c, user.ID)
+}
+
+// 完成登录(密码过期时返回修改密码挑战，否则生成令牌)
+func (a *Login) completeLogin(c *gin.Context, userID string) {
+	ctx := c.Request.Context()
+	challenge, err := a.LoginSrv.CheckPasswordExpired(ctx, userID)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	} else if challenge != nil {
+		ginx.ResSuccess(c, challenge)
+		return
+	}
+
+	a.generateToken(c, userID)
+}
+
+// 生成令牌并响应
+func (a *Login) generateToken(c *gin.Context, userID string) {
+	ctx := c.Request.Context()
+	tokenInfo, err := a.LoginSrv.GenerateToken(ctx, userID, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		ginx.ResError(c, err)
 		return
@@ -134,14 +308,21 @@ func (a *Login) Logout(c *gin.Context) {
 // RefreshToken 刷新令牌
 // @Tags 登录管理
 // @Summary 刷新令牌
-// @Security ApiKeyAuth
+// @Param body body schema.RefreshTokenParam true "请求参数"
 // @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/pub/refresh-token [post]
 func (a *Login) RefreshToken(c *gin.Context) {
 	ctx := c.Request.Context()
-	tokenInfo, err := a.LoginSrv.GenerateToken(ctx, ginx.GetUserID(c))
+	var item schema.RefreshTokenParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	tokenInfo, err := a.LoginSrv.RefreshToken(ctx, item.RefreshToken)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
@@ -164,9 +345,32 @@ func (a *Login) GetUserInfo(c *gin.Context) {
 		ginx.ResError(c, err)
 		return
 	}
+	info.ActorID = ginx.GetActorID(c)
 	ginx.ResSuccess(c, info)
 }
 
+// Impersonate 模拟指定用户登录
+// @Tags 用户管理
+// @Summary 模拟指定用户登录(返回短期访问令牌，令牌同时记录被模拟用户及实际操作者，不提供刷新令牌)
+// @Security ApiKeyAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 403 {object} schema.ErrorResult "{error:{code:0,message:模拟登录时不允许此操作}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/impersonate [post]
+func (a *Login) Impersonate(c *gin.Context) {
+	ctx := c.Request.Context()
+	tokenInfo, err := a.LoginSrv.Impersonate(ctx, c.Param("id"), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, tokenInfo)
+}
+
 // QueryUserMenuTree 查询当前用户菜单树
 // @Tags 登录管理
 // @Summary 查询当前用户菜单树
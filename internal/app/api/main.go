@@ -4,9 +4,20 @@ import "github.com/google/wire"
 
 // APISet 注入API
 var APISet = wire.NewSet(
+	APIKeySet,
+	AuditLogSet,
 	DemoSet,
+	DeptSet,
+	JWKSSet,
+	LockoutSet,
 	LoginSet,
+	MFASet,
 	MenuSet,
+	PasswordResetSet,
+	PermissionSet,
 	RoleSet,
+	SessionSet,
+	TenantSet,
 	UserSet,
+	UserRoleSet,
 )
\ No newline at end of file
@@ -83,6 +83,7 @@ func (a *Menu) QueryTree(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
 // @Success 200 {object} schema.Menu
+// @Header 200 {string} ETag "资源版本号"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
 // @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
@@ -94,6 +95,7 @@ func (a *Menu) Get(c *gin.Context) {
 		ginx.ResError(c, err)
 		return
 	}
+	ginx.SetETag(c, item.Version)
 	ginx.ResSuccess(c, item)
 }
 
@@ -129,10 +131,13 @@ func (a *Menu) Create(c *gin.Context) {
 // @Summary 更新数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Param body body schema.Menu true "更新数据"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/menus/{id} [put]
 func (a *Menu) Update(c *gin.Context) {
@@ -143,11 +148,19 @@ func (a *Menu) Update(c *gin.Context) {
 		return
 	}
 
-	err := a.MenuSrv.Update(ctx, c.Param("id"), item)
+	version, err := ginx.ParseIfMatch(c)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
+	item.Version = version
+
+	err = a.MenuSrv.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
 	ginx.ResOK(c)
 }
 
@@ -175,17 +188,27 @@ func (a *Menu) Delete(c *gin.Context) {
 // @Summary 启用数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/menus/{id}/enable [patch]
 func (a *Menu) Enable(c *gin.Context) {
 	ctx := c.Request.Context()
-	err := a.MenuSrv.UpdateStatus(ctx, c.Param("id"), 1)
+	version, err := ginx.ParseIfMatch(c)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
+
+	err = a.MenuSrv.UpdateStatus(ctx, c.Param("id"), 1, version)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
 	ginx.ResOK(c)
 }
 
@@ -194,13 +217,72 @@ func (a *Menu) Enable(c *gin.Context) {
 // @Summary 禁用数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/menus/{id}/disable [patch]
 func (a *Menu) Disable(c *gin.Context) {
 	ctx := c.Request.Context()
-	err := a.MenuSrv.UpdateStatus(ctx, c.Param("id"), 2)
+	version, err := ginx.ParseIfMatch(c)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	err = a.MenuSrv.UpdateStatus(ctx, c.Param("id"), 2, version)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
+	ginx.ResOK(c)
+}
+
+// QueryDeleted 查询回收站中的数据
+// @Tags 菜单管理
+// @Summary 查询回收站中的数据(已删除的数据)
+// @Security ApiKeyAuth
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值(名称)"
+// @Success 200 {object} schema.ListResult{list=[]schema.RecycleItem} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/menus.deleted [get]
+func (a *Menu) QueryDeleted(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.RecycleQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.MenuSrv.QueryDeleted(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
+
+// Restore 从回收站恢复数据
+// @Tags 菜单管理
+// @Summary 从回收站恢复数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/menus/{id}/restore [patch]
+func (a *Menu) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.MenuSrv.Restore(ctx, c.Param("id"))
 	if err != nil {
 		ginx.ResError(c, err)
 		return
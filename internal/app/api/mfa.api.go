@@ -0,0 +1,106 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/internal/app/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// MFASet 注入MFA
+var MFASet = wire.NewSet(wire.Struct(new(MFA), "*"))
+
+// MFA 两步验证管理
+type MFA struct {
+	MFASrv *service.MFA
+}
+
+// Get 查询当前用户的两步验证状态
+// @Tags 登录管理
+// @Summary 查询当前用户的两步验证状态
+// @Security ApiKeyAuth
+// @Success 200 {object} schema.UserMFAStatus
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/mfa [get]
+func (a *MFA) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.MFASrv.GetStatus(ctx, ginx.GetUserID(c))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, item)
+}
+
+// Enroll 登记TOTP密钥
+// @Tags 登录管理
+// @Summary 登记TOTP密钥(确认后才会启用两步验证)
+// @Security ApiKeyAuth
+// @Success 200 {object} schema.UserMFAEnrollResult
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/mfa/totp [post]
+func (a *MFA) Enroll(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.MFASrv.Enroll(ctx, ginx.GetUserID(c))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, item)
+}
+
+// Confirm 确认并启用两步验证
+// @Tags 登录管理
+// @Summary 确认并启用两步验证(返回一次性恢复码)
+// @Security ApiKeyAuth
+// @Param body body schema.UserMFACodeParam true "请求参数"
+// @Success 200 {object} schema.UserMFARecoveryCodes
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/mfa/totp/confirm [post]
+func (a *MFA) Confirm(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.UserMFACodeParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	result, err := a.MFASrv.Confirm(ctx, ginx.GetUserID(c), item.Code)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, result)
+}
+
+// Disable 关闭两步验证
+// @Tags 登录管理
+// @Summary 关闭两步验证
+// @Security ApiKeyAuth
+// @Param body body schema.UserMFACodeParam true "请求参数"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/mfa/totp [delete]
+func (a *MFA) Disable(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.UserMFACodeParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	err := a.MFASrv.Disable(ctx, ginx.GetUserID(c), item.Code)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/internal/app/service"
+	"ginAdmin/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// PasswordResetSet 注入PasswordReset
+var PasswordResetSet = wire.NewSet(wire.Struct(new(PasswordReset), "*"))
+
+// PasswordReset 密码重置
+type PasswordReset struct {
+	PasswordResetSrv *service.PasswordReset
+}
+
+// Request 申请密码重置
+// @Tags 登录管理
+// @Summary 申请密码重置(重置令牌通过通知发送给用户)
+// @Param body body schema.PasswordResetRequestParam true "请求参数"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/password/reset [post]
+func (a *PasswordReset) Request(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.PasswordResetRequestParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	ctx = logger.NewTagContext(ctx, "__password_reset__")
+	err := a.PasswordResetSrv.Request(ctx, item, c.ClientIP())
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// Confirm 确认密码重置
+// @Tags 登录管理
+// @Summary 使用重置令牌设置新密码(成功后撤销用户的所有会话)
+// @Param body body schema.PasswordResetConfirmParam true "请求参数"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/password/reset/confirm [post]
+func (a *PasswordReset) Confirm(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.PasswordResetConfirmParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	ctx = logger.NewTagContext(ctx, "__password_reset__")
+	err := a.PasswordResetSrv.Confirm(ctx, item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/internal/app/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// PermissionSet 注入Permission
+var PermissionSet = wire.NewSet(wire.Struct(new(Permission), "*"))
+
+// Permission 权限诊断
+type Permission struct {
+	PermissionSrv *service.Permission
+}
+
+// Explain 诊断用户访问指定接口的判定结果
+// @Tags 权限诊断
+// @Security ApiKeyAuth
+// @Summary 诊断用户访问指定接口的判定结果(包括经过的角色、匹配的策略规则及授予权限的菜单动作资源)
+// @Param userID query string true "用户ID"
+// @Param path query string true "请求路径"
+// @Param method query string true "请求方式"
+// @Success 200 {object} schema.PermissionExplain
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/permissions/explain [get]
+func (a *Permission) Explain(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.PermissionExplainParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	item, err := a.PermissionSrv.Explain(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, item)
+}
+
+// QueryAPIs 查询用户或角色可调用的接口列表
+// @Tags 权限诊断
+// @Security ApiKeyAuth
+// @Summary 查询用户或角色可调用的接口列表
+// @Param userID query string false "用户ID"
+// @Param roleID query string false "角色ID"
+// @Success 200 {object} schema.ListResult{list=[]schema.PermissionAPI} "查询结果"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/permissions/apis [get]
+func (a *Permission) QueryAPIs(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.PermissionAPIQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	list, err := a.PermissionSrv.QueryAPIs(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResList(c, list)
+}
@@ -82,6 +82,7 @@ func (a *Role) QuerySelect(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
 // @Success 200 {object} schema.Role
+// @Header 200 {string} ETag "资源版本号"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
 // @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
@@ -93,6 +94,7 @@ func (a *Role) Get(c *gin.Context) {
 		ginx.ResError(c, err)
 		return
 	}
+	ginx.SetETag(c, item.Version)
 	ginx.ResSuccess(c, item)
 }
 
@@ -128,10 +130,13 @@ func (a *Role) Create(c *gin.Context) {
 // @Summary 更新数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Param body body schema.Role true "更新数据"
 // @Success 200 {object} schema.Role
 // @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/roles/{id} [put]
 func (a *Role) Update(c *gin.Context) {
@@ -142,11 +147,19 @@ func (a *Role) Update(c *gin.Context) {
 		return
 	}
 
-	err := a.RoleSrv.Update(ctx, c.Param("id"), item)
+	version, err := ginx.ParseIfMatch(c)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
+	item.Version = version
+
+	err = a.RoleSrv.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
 	ginx.ResOK(c)
 }
 
@@ -174,17 +187,27 @@ func (a *Role) Delete(c *gin.Context) {
 // @Summary 启用数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/roles/{id}/enable [patch]
 func (a *Role) Enable(c *gin.Context) {
 	ctx := c.Request.Context()
-	err := a.RoleSrv.UpdateStatus(ctx, c.Param("id"), 1)
+	version, err := ginx.ParseIfMatch(c)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
+
+	err = a.RoleSrv.UpdateStatus(ctx, c.Param("id"), 1, version)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
 	ginx.ResOK(c)
 }
 
@@ -193,13 +216,72 @@ func (a *Role) Enable(c *gin.Context) {
 // @Summary 禁用数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/roles/{id}/disable [patch]
 func (a *Role) Disable(c *gin.Context) {
 	ctx := c.Request.Context()
-	err := a.RoleSrv.UpdateStatus(ctx, c.Param("id"), 2)
+	version, err := ginx.ParseIfMatch(c)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	err = a.RoleSrv.UpdateStatus(ctx, c.Param("id"), 2, version)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
+	ginx.ResOK(c)
+}
+
+// QueryDeleted 查询回收站中的数据
+// @Tags 角色管理
+// @Summary 查询回收站中的数据(已删除的数据)
+// @Security ApiKeyAuth
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值(名称)"
+// @Success 200 {object} schema.ListResult{list=[]schema.RecycleItem} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/roles.deleted [get]
+func (a *Role) QueryDeleted(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.RecycleQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.RoleSrv.QueryDeleted(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
+
+// Restore 从回收站恢复数据
+// @Tags 角色管理
+// @Summary 从回收站恢复数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/roles/{id}/restore [patch]
+func (a *Role) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.RoleSrv.Restore(ctx, c.Param("id"))
 	if err != nil {
 		ginx.ResError(c, err)
 		return
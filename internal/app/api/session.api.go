@@ -0,0 +1,117 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/service"
+	"ginAdmin/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// SessionSet 注入Session
+var SessionSet = wire.NewSet(wire.Struct(new(Session), "*"))
+
+// Session 会话管理
+type Session struct {
+	SessionSrv *service.Session
+}
+
+// QueryCurrent 查询当前用户的登录会话
+// @Tags 登录管理
+// @Summary 查询当前用户的登录会话
+// @Security ApiKeyAuth
+// @Success 200 {object} schema.ListResult{list=[]schema.Session} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/sessions [get]
+func (a *Session) QueryCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := a.SessionSrv.Query(ctx, ginx.GetUserID(c))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResList(c, list)
+}
+
+// RevokeCurrent 撤销当前用户的指定会话
+// @Tags 登录管理
+// @Summary 撤销当前用户的指定会话
+// @Security ApiKeyAuth
+// @Param id path string true "会话ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/sessions/{id} [delete]
+func (a *Session) RevokeCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.SessionSrv.Revoke(ctx, ginx.GetUserID(c), c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// RevokeCurrentAll 撤销当前用户的所有会话(包括当前会话)
+// @Tags 登录管理
+// @Summary 撤销当前用户的所有会话
+// @Security ApiKeyAuth
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/sessions [delete]
+func (a *Session) RevokeCurrentAll(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.SessionSrv.RevokeAll(ctx, ginx.GetUserID(c))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// Query 查询指定用户的登录会话
+// @Tags 用户管理
+// @Summary 查询指定用户的登录会话
+// @Security ApiKeyAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} schema.ListResult{list=[]schema.Session} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/sessions [get]
+func (a *Session) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	list, err := a.SessionSrv.QueryByUser(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResList(c, list)
+}
+
+// Revoke 强制指定用户下线
+// @Tags 用户管理
+// @Summary 强制指定用户下线
+// @Security ApiKeyAuth
+// @Param id path string true "用户ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/sessions [delete]
+func (a *Session) Revoke(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := c.Param("id")
+	err := a.SessionSrv.RevokeByUser(ctx, userID)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	ctx = logger.NewTagContext(ctx, "__logout__")
+	logger.WithContext(ctx).Infof("强制用户[%s]下线", userID)
+	ginx.ResOK(c)
+}
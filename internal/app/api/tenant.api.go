@@ -0,0 +1,227 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/internal/app/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"))
+
+// Tenant 租户管理
+type Tenant struct {
+	TenantSrv *service.Tenant
+}
+
+// Query 查询数据
+// @Tags 租户管理
+// @Security ApiKeyAuth
+// @Summary 查询数据
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值"
+// @Param status query int false "状态(1:启用 2:停用)"
+// @Success 200 {object} schema.ListResult{list=[]schema.Tenant} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants [get]
+func (a *Tenant) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.TenantQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.TenantSrv.Query(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
+
+// Get 查询指定数据
+// @Tags 租户管理
+// @Security ApiKeyAuth
+// @Summary 查询指定数据
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.Tenant
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id} [get]
+func (a *Tenant) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.TenantSrv.Get(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, item)
+}
+
+// Create 创建数据
+// @Tags 租户管理
+// @Security ApiKeyAuth
+// @Summary 创建数据
+// @Param body body schema.TenantCreateParam true "创建数据"
+// @Success 200 {object} schema.IDResult
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants [post]
+func (a *Tenant) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.TenantCreateParam
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	item.Creator = ginx.GetUserID(c)
+	result, err := a.TenantSrv.Create(ctx, item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResSuccess(c, result)
+}
+
+// Update 更新数据
+// @Tags 租户管理
+// @Security ApiKeyAuth
+// @Summary 更新数据
+// @Param id path string true "唯一标识"
+// @Param body body schema.Tenant true "更新数据"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id} [put]
+func (a *Tenant) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Tenant
+	if err := ginx.ParseJSON(c, &item); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	err := a.TenantSrv.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	ginx.ResOK(c)
+}
+
+// Delete 删除数据
+// @Tags 租户管理
+// @Security ApiKeyAuth
+// @Summary 删除数据
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id} [delete]
+func (a *Tenant) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantSrv.Delete(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// Enable 启用数据
+// @Tags 租户管理
+// @Security ApiKeyAuth
+// @Summary 启用数据
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id}/enable [patch]
+func (a *Tenant) Enable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantSrv.UpdateStatus(ctx, c.Param("id"), 1)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// Disable 禁用数据
+// @Tags 租户管理
+// @Security ApiKeyAuth
+// @Summary 禁用数据
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id}/disable [patch]
+func (a *Tenant) Disable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantSrv.UpdateStatus(ctx, c.Param("id"), 2)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
+
+// QueryDeleted 查询回收站中的数据
+// @Tags 租户管理
+// @Summary 查询回收站中的数据(已删除的数据)
+// @Security ApiKeyAuth
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值(名称)"
+// @Success 200 {object} schema.ListResult{list=[]schema.RecycleItem} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants.deleted [get]
+func (a *Tenant) QueryDeleted(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.RecycleQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.TenantSrv.QueryDeleted(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
+
+// Restore 从回收站恢复数据
+// @Tags 租户管理
+// @Summary 从回收站恢复数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id}/restore [patch]
+func (a *Tenant) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantSrv.Restore(ctx, c.Param("id"))
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResOK(c)
+}
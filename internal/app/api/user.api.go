@@ -26,6 +26,7 @@ type User struct {
 // @Param pageSize query int true "分页大小" default(10)
 // @Param queryValue query string false "查询值"
 // @Param roleIDs query string false "角色ID(多个以英文逗号分隔)"
+// @Param deptID query string false "所属部门ID"
 // @Param status query int false "状态(1:启用 2:停用)"
 // @Success 200 {object} schema.ListResult{list=[]schema.UserShow} "查询结果"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
@@ -58,6 +59,7 @@ func (a *User) Query(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
 // @Success 200 {object} schema.User
+// @Header 200 {string} ETag "资源版本号"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
 // @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
@@ -69,6 +71,7 @@ func (a *User) Get(c *gin.Context) {
 		ginx.ResError(c, err)
 		return
 	}
+	ginx.SetETag(c, item.Version)
 	ginx.ResSuccess(c, item.CleanSecure())
 }
 
@@ -107,10 +110,13 @@ func (a *User) Create(c *gin.Context) {
 // @Summary 更新数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Param body body schema.User true "更新数据"
 // @Success 200 {object} schema.User
 // @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/users/{id} [put]
 func (a *User) Update(c *gin.Context) {
@@ -121,11 +127,19 @@ func (a *User) Update(c *gin.Context) {
 		return
 	}
 
-	err := a.UserSrv.Update(ctx, c.Param("id"), item)
+	version, err := ginx.ParseIfMatch(c)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
+	item.Version = version
+
+	err = a.UserSrv.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
 	ginx.ResOK(c)
 }
 
@@ -153,17 +167,27 @@ func (a *User) Delete(c *gin.Context) {
 // @Summary 启用数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/users/{id}/enable [patch]
 func (a *User) Enable(c *gin.Context) {
 	ctx := c.Request.Context()
-	err := a.UserSrv.UpdateStatus(ctx, c.Param("id"), 1)
+	version, err := ginx.ParseIfMatch(c)
 	if err != nil {
 		ginx.ResError(c, err)
 		return
 	}
+
+	err = a.UserSrv.UpdateStatus(ctx, c.Param("id"), 1, version)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
 	ginx.ResOK(c)
 }
 
@@ -172,13 +196,72 @@ func (a *User) Enable(c *gin.Context) {
 // @Summary 禁用数据
 // @Security ApiKeyAuth
 // @Param id path string true "唯一标识"
+// @Param If-Match header string true "资源版本号(查询时返回的ETag)"
 // @Success 200 {object} schema.StatusResult "{status:OK}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 412 {object} schema.ErrorResult "{error:{code:0,message:资源已被修改，请刷新后重试}}"
+// @Failure 428 {object} schema.ErrorResult "{error:{code:0,message:缺少资源版本(If-Match)}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/users/{id}/disable [patch]
 func (a *User) Disable(c *gin.Context) {
 	ctx := c.Request.Context()
-	err := a.UserSrv.UpdateStatus(ctx, c.Param("id"), 2)
+	version, err := ginx.ParseIfMatch(c)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	err = a.UserSrv.UpdateStatus(ctx, c.Param("id"), 2, version)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.SetETag(c, version+1)
+	ginx.ResOK(c)
+}
+
+// QueryDeleted 查询回收站中的数据
+// @Tags 用户管理
+// @Summary 查询回收站中的数据(已删除的数据)
+// @Security ApiKeyAuth
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值(名称)"
+// @Success 200 {object} schema.ListResult{list=[]schema.RecycleItem} "查询结果"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users.deleted [get]
+func (a *User) QueryDeleted(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.RecycleQueryParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.UserSrv.QueryDeleted(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
+
+// Restore 从回收站恢复数据
+// @Tags 用户管理
+// @Summary 从回收站恢复数据
+// @Security ApiKeyAuth
+// @Param id path string true "唯一标识"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/restore [patch]
+func (a *User) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.UserSrv.Restore(ctx, c.Param("id"))
 	if err != nil {
 		ginx.ResError(c, err)
 		return
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/internal/app/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// UserRoleSet 注入UserRole
+var UserRoleSet = wire.NewSet(wire.Struct(new(UserRole), "*"))
+
+// UserRole 用户角色授权
+type UserRole struct {
+	UserRoleSrv *service.UserRole
+}
+
+// QueryExpiring 查询即将到期的角色授权
+// @Tags 用户管理
+// @Summary 查询指定天数内即将到期的角色授权(按失效时间升序)
+// @Security ApiKeyAuth
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param days query int false "到期天数(1-365)" default(7)
+// @Success 200 {object} schema.ListResult{list=[]schema.UserRoleExpiring} "查询结果"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/user-roles/expiring [get]
+func (a *UserRole) QueryExpiring(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.UserRoleExpiringParam
+	if err := ginx.ParseQuery(c, &params); err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.UserRoleSrv.QueryExpiring(ctx, params)
+	if err != nil {
+		ginx.ResError(c, err)
+		return
+	}
+	ginx.ResPage(c, result.Data, result.PageResult)
+}
@@ -5,6 +5,8 @@ import (
 	"crypto/tls"
 	"fmt"
 	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/schema"
 	"ginAdmin/pkg/logger"
 	"github.com/LyricTian/captcha"
 	"github.com/LyricTian/captcha/store"
@@ -102,19 +104,42 @@ func Init(ctx context.Context, opts ...Option) (func(), error) {
 		return nil, err
 	}
 
+	// 初始化默认租户(菜单及超级管理员均初始化到默认租户)
+	err = injector.TenantBll.InitData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tctx := contextx.NewTenantID(ctx, schema.DefaultTenantID)
+
 	// 初始化菜单数据
 	if config.C.Menu.Enable && config.C.Menu.Data != "" {
-		err = injector.MenuBll.InitData(ctx, config.C.Menu.Data)
+		err = injector.MenuBll.InitData(tctx, config.C.Menu.Data)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// 初始化超级管理员
+	if config.C.Root.Bootstrap {
+		err = injector.UserBll.InitSuperUser(tctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 初始化角色授权有效期检查
+	sweeperCleanFunc := InitUserRoleSweeper(ctx, injector.UserRoleBll)
+
+	// 初始化回收站清理
+	purgerCleanFunc := InitRecycleBinPurger(ctx, injector.RecycleBinBll)
+
 	// 初始化HTTP服务
 	httpServerCleanFunc := InitHTTPServer(ctx, injector.Engine)
 
 	return func() {
 		httpServerCleanFunc()
+		purgerCleanFunc()
+		sweeperCleanFunc()
 		injectorCleanFunc()
 		monitorCleanFunc()
 		loggerCleanFunc()
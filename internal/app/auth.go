@@ -1,40 +1,84 @@
 package app
 
 import (
+	"errors"
 	"ginAdmin/internal/app/config"
 	"ginAdmin/pkg/auth"
 	"ginAdmin/pkg/auth/jwtauth"
+	"ginAdmin/pkg/auth/jwtauth/store/buntdb"
+	"ginAdmin/pkg/auth/jwtauth/store/memory"
 	"ginAdmin/pkg/auth/jwtauth/store/redis"
 	jwt "github.com/dgrijalva/jwt-go"
+	"os"
+	"path/filepath"
 )
 
+// InitJWTKeySet 初始化JWT签名密钥集合
+func InitJWTKeySet() (*jwtauth.KeySet, error) {
+	cfg := config.C.JWTAuth
+
+	if len(cfg.Keys) > 0 && cfg.SigningKeyID == "" {
+		return nil, errors.New("jwt auth signing key id is required when keys are configured")
+	}
+
+	var keys []*jwtauth.Key
+	//	配置了非对称密钥后，HMAC共享密钥(默认配置是公开的)只在明确保留时用于验签
+	if cfg.SigningKey != "" && (len(cfg.Keys) == 0 || cfg.KeepHMAC) {
+		var method jwt.SigningMethod
+		switch cfg.SigningMethod {
+		case "HS256":
+			method = jwt.SigningMethodHS256
+		case "HS384":
+			method = jwt.SigningMethodHS384
+		default:
+			method = jwt.SigningMethodHS512
+		}
+		keys = append(keys, jwtauth.NewHMACKey("", method, []byte(cfg.SigningKey)))
+	}
+
+	for _, item := range cfg.Keys {
+		if item.KeyID == "" {
+			return nil, errors.New("jwt auth key id can not be empty")
+		}
+
+		key, err := jwtauth.LoadKey(item.KeyID, item.SigningMethod, item.PrivateKeyFile, item.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return jwtauth.NewKeySet(cfg.SigningKeyID, keys...)
+}
+
 // InitAuth 初始化用户认证
-func InitAuth() (auth.Auther, func(), error) {
+func InitAuth(keySet *jwtauth.KeySet) (auth.Auther, func(), error) {
 	cfg := config.C.JWTAuth
 
 	var opts []jwtauth.Option
 	opts = append(opts, jwtauth.SetExpired(cfg.Expired))
-	opts = append(opts, jwtauth.SetSigningKey([]byte(cfg.SigningKey)))
-	opts = append(opts, jwtauth.SetKeyFunc(func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, auth.ErrInvalidToken
-		}
-		return []byte(cfg.SigningKey), nil
-	}))
-
-	var method jwt.SigningMethod
-	switch cfg.SigningMethod {
-	case "HS256":
-		method = jwt.SigningMethodHS256
-	case "HS384":
-		method = jwt.SigningMethodHS384
-	default:
-		method = jwt.SigningMethodHS512
+	if cfg.RefreshExpired > 0 {
+		opts = append(opts, jwtauth.SetRefreshExpired(cfg.RefreshExpired))
+	}
+	if cfg.ChallengeExpired > 0 {
+		opts = append(opts, jwtauth.SetChallengeExpired(cfg.ChallengeExpired))
 	}
-	opts = append(opts, jwtauth.SetSigningMethod(method))
+	if v := config.C.Impersonation.Expired; v > 0 {
+		opts = append(opts, jwtauth.SetImpersonationExpired(v))
+	}
+	opts = append(opts, jwtauth.SetKeySet(keySet))
 
 	var store jwtauth.Storer
 	switch cfg.Store {
+	case "file":
+		_ = os.MkdirAll(filepath.Dir(cfg.FilePath), 0777)
+		s, err := buntdb.NewStore(cfg.FilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		store = s
+	case "memory":
+		store = memory.NewStore(0)
 	default:
 		rcfg := config.C.Redis
 		store = redis.NewStore(&redis.Config{
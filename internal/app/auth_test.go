@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/jwtauth"
+	jwt "github.com/dgrijalva/jwt-go"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setTestJWTConfig(t *testing.T, cfg config.JWTAuth) {
+	old := config.C.JWTAuth
+	config.C.JWTAuth = cfg
+	t.Cleanup(func() { config.C.JWTAuth = old })
+}
+
+func writeTestECKey(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "k1.pem")
+	err = os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: buf}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// 使用共享密钥伪造的令牌(没有kid)
+func forgeHMACToken(t *testing.T, method jwt.SigningMethod, secret string) string {
+	token := jwt.NewWithClaims(method, jwt.StandardClaims{
+		Subject:   "root",
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+	s, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestInitJWTKeySetDropsHMACKey(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.JWTAuth{
+		SigningMethod: "HS256",
+		SigningKey:    "gin-admin",
+		SigningKeyID:  "k1",
+		Keys: []config.JWTAuthKey{
+			{KeyID: "k1", SigningMethod: "ES256", PrivateKeyFile: writeTestECKey(t)},
+		},
+	}
+	setTestJWTConfig(t, cfg)
+
+	ks, err := InitJWTKeySet()
+	if err != nil {
+		t.Fatal(err)
+	} else if ks.SigningKey().ID != "k1" {
+		t.Fatalf("unexpected signing key: %q", ks.SigningKey().ID)
+	}
+
+	a := jwtauth.New(nil, jwtauth.SetKeySet(ks))
+	if _, err := a.ParseUserID(ctx, forgeHMACToken(t, jwt.SigningMethodHS256, cfg.SigningKey)); err == nil {
+		t.Fatalf("token signed with the old secret must be rejected")
+	}
+
+	tokenInfo, err := a.GenerateToken(ctx, "user1", auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	} else if userID, err := a.ParseUserID(ctx, tokenInfo.GetAccessToken()); err != nil || userID != "user1" {
+		t.Fatalf("unexpected user id: %s, %v", userID, err)
+	}
+
+	// 明确保留时旧令牌仍可验签，但签名使用非对称密钥
+	cfg.KeepHMAC = true
+	setTestJWTConfig(t, cfg)
+	ks, err = InitJWTKeySet()
+	if err != nil {
+		t.Fatal(err)
+	} else if ks.SigningKey().ID != "k1" {
+		t.Fatalf("unexpected signing key: %q", ks.SigningKey().ID)
+	}
+	a = jwtauth.New(nil, jwtauth.SetKeySet(ks))
+	if _, err := a.ParseUserID(ctx, forgeHMACToken(t, jwt.SigningMethodHS256, cfg.SigningKey)); err != nil {
+		t.Fatalf("kept hmac key should verify old tokens: %v", err)
+	}
+}
+
+func TestInitJWTKeySetRequiresSigningKeyID(t *testing.T) {
+	setTestJWTConfig(t, config.JWTAuth{
+		SigningKey: "gin-admin",
+		Keys: []config.JWTAuthKey{
+			{KeyID: "k1", SigningMethod: "ES256", PrivateKeyFile: writeTestECKey(t)},
+		},
+	})
+
+	if _, err := InitJWTKeySet(); err == nil {
+		t.Fatal("expected error without signing key id")
+	}
+}
@@ -1,14 +1,47 @@
 package app
 
 import (
+	"context"
 	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/service"
+	"ginAdmin/pkg/logger"
+	"ginAdmin/pkg/watcher/local"
+	"ginAdmin/pkg/watcher/redis"
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/persist"
 	"time"
 )
 
+// InitCasbinWatcher 初始化casbin策略变更通知(多实例部署时使用redis发布订阅，否则使用进程内通知)
+func InitCasbinWatcher() (persist.Watcher, func(), error) {
+	cfg := config.C.Casbin
+
+	var w persist.Watcher
+	switch cfg.Watcher {
+	case "redis":
+		rcfg := config.C.Redis
+		rw, err := redis.NewWatcher(&redis.Config{
+			Addr:     rcfg.Addr,
+			Password: rcfg.Password,
+			Channel:  cfg.RedisChannel,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		w = rw
+	default:
+		w = local.NewWatcher()
+	}
+
+	cleanFunc := func() {
+		w.Close()
+	}
+	return w, cleanFunc, nil
+}
+
 // InitCasbin 初始化casbin
-func InitCasbin(adapter persist.Adapter) (*casbin.SyncedEnforcer, func(), error) {
+func InitCasbin(adapter persist.Adapter, watcher persist.Watcher) (*casbin.SyncedEnforcer, func(), error) {
 	cfg := config.C.Casbin
 	if cfg.Model == "" {
 		return new(casbin.SyncedEnforcer), nil, nil
@@ -26,6 +59,12 @@ func InitCasbin(adapter persist.Adapter) (*casbin.SyncedEnforcer, func(), error)
 	}
 	e.EnableEnforce(cfg.Enable)
 
+	//	策略变更(增量或全量)后通知其他实例，收到其他实例的通知时重新加载策略
+	err = e.SetWatcher(watcher)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	cleanFunc := func() {}
 	if cfg.AutoLoad {
 		e.StartAutoLoadPolicy(time.Duration(cfg.AutoLoadInternal) * time.Second)
@@ -36,3 +75,40 @@ func InitCasbin(adapter persist.Adapter) (*casbin.SyncedEnforcer, func(), error)
 
 	return e, cleanFunc, nil
 }
+
+// InitUserRoleSweeper 定期将到达生效或失效时间的角色授权同步到casbin权限策略(各实例独立执行，变更通过watcher通知)
+func InitUserRoleSweeper(ctx context.Context, srv *service.UserRole) func() {
+	cfg := config.C.Casbin
+	if !cfg.Enable || cfg.SweepInterval <= 0 {
+		return func() {}
+	}
+
+	// 定期任务不限定租户
+	ctx = contextx.NewSystem(ctx)
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Duration(cfg.SweepInterval) * time.Second)
+		defer ticker.Stop()
+
+		// 启动时已全量加载策略，首次检查覆盖加载期间到达时间的授权
+		since := time.Now().Add(-time.Duration(cfg.SweepInterval) * time.Second)
+		for {
+			select {
+			case <-ticker.C:
+				now := time.Now()
+				err := srv.Sweep(ctx, since, now)
+				if err != nil {
+					logger.WithContext(ctx).Errorf("Sweep user role error: %s", err.Error())
+					continue
+				}
+				since = now
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
@@ -58,28 +58,37 @@ func PrintWithJSON() {
 
 // Config 配置参数
 type Config struct {
-	RunMode      string
-	WWW          string
-	Swagger      bool
-	PrintConfig  bool
-	HTTP         HTTP
-	Menu         Menu
-	Casbin       Casbin
-	Log          Log
-	LogGormHook  LogGormHook
-	LogMongoHook LogMongoHook
-	Root         Root
-	JWTAuth      JWTAuth
-	Monitor      Monitor
-	Captcha      Captcha
-	RateLimiter  RateLimiter
-	CORS         CORS
-	GZIP         GZIP
-	Redis        Redis
-	Gorm         Gorm
-	MySQL        MySQL
-	Postgres     Postgres
-	Sqlite3      Sqlite3
+	RunMode        string
+	WWW            string
+	Swagger        bool
+	PrintConfig    bool
+	HTTP           HTTP
+	Menu           Menu
+	Casbin         Casbin
+	Log            Log
+	LogGormHook    LogGormHook
+	LogMongoHook   LogMongoHook
+	Root           Root
+	JWTAuth        JWTAuth
+	Password       Password
+	PasswordPolicy PasswordPolicy
+	MFA            MFA
+	PasswordReset  PasswordReset
+	OIDC           OIDC
+	Impersonation  Impersonation
+	RecycleBin     RecycleBin
+	Audit          Audit
+	Monitor        Monitor
+	LoginLockout   LoginLockout
+	Captcha        Captcha
+	RateLimiter    RateLimiter
+	CORS           CORS
+	GZIP           GZIP
+	Redis          Redis
+	Gorm           Gorm
+	MySQL          MySQL
+	Postgres       Postgres
+	Sqlite3        Sqlite3
 }
 
 // IsDebugMode 是否是debug模式
@@ -100,6 +109,9 @@ type Casbin struct {
 	Model            string
 	AutoLoad         bool
 	AutoLoadInternal int
+	Watcher          string
+	RedisChannel     string
+	SweepInterval    int
 }
 
 // LogHook 日志钩子
@@ -142,23 +154,119 @@ type LogMongoHook struct {
 	Collection string
 }
 
-// Root root用户
+// Root 超级管理员初始化配置
 type Root struct {
-	UserName string
-	Password string
-	RealName string
+	Bootstrap bool
+	UserName  string
+	Password  string
+	RealName  string
 }
 
 // JWTAuth 用户认证
 type JWTAuth struct {
-	Enable        bool
-	SigningMethod string
-	SigningKey    string
-	Expired       int
-	Store         string
-	FilePath      string
-	RedisDB       int
-	RedisPrefix   string
+	Enable           bool
+	SigningMethod    string
+	SigningKey       string
+	SigningKeyID     string
+	Keys             []JWTAuthKey
+	KeepHMAC         bool
+	Expired          int
+	RefreshExpired   int
+	ChallengeExpired int
+	Store            string
+	FilePath         string
+	RedisDB          int
+	RedisPrefix      string
+}
+
+// JWTAuthKey JWT非对称签名密钥
+type JWTAuthKey struct {
+	KeyID          string
+	SigningMethod  string
+	PrivateKeyFile string
+	PublicKeyFile  string
+}
+
+// Password 密码哈希配置参数
+type Password struct {
+	Algorithm     string
+	Argon2Time    uint32
+	Argon2Memory  uint32
+	Argon2Threads uint8
+	BcryptCost    int
+}
+
+// PasswordPolicy 密码策略配置参数
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUpper     bool
+	RequireLower     bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	DisallowUserName bool
+	BannedPasswords  []string
+	HistorySize      int
+	MaxAge           int
+}
+
+// MFA 两步验证配置参数
+type MFA struct {
+	Issuer        string
+	RecoveryCodes int
+}
+
+// PasswordReset 密码重置配置参数
+type PasswordReset struct {
+	Expired         int
+	Notifier        string
+	FilePath        string
+	URL             string
+	Window          int
+	UserMaxRequests int64
+	IPMaxRequests   int64
+}
+
+// OIDC 第三方身份提供者登录配置参数
+type OIDC struct {
+	Enable       bool
+	Store        string
+	RedisDB      int
+	RedisPrefix  string
+	StateExpired int
+	Providers    []OIDCProvider
+}
+
+// OIDCProvider OIDC身份提供者
+type OIDCProvider struct {
+	Name          string
+	Issuer        string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scopes        []string
+	TenantCode    string
+	AutoProvision bool
+	DefaultRoles  []string
+}
+
+// Impersonation 模拟登录配置参数
+type Impersonation struct {
+	Enable       bool
+	Expired      int
+	BlockedPaths []string
+}
+
+// RecycleBin 回收站配置参数
+type RecycleBin struct {
+	RetentionDays int
+	PurgeInterval int
+}
+
+// Audit 数据变更审计配置参数
+type Audit struct {
+	Enable       bool
+	IgnoreTables []string
+	MaskColumns  []string
 }
 
 // HTTP http配置参数
@@ -189,6 +297,20 @@ type Captcha struct {
 	RedisPrefix string
 }
 
+// LoginLockout 登录失败锁定配置参数
+type LoginLockout struct {
+	Enable          bool
+	Store           string
+	RedisDB         int
+	RedisPrefix     string
+	Window          int
+	CaptchaFailures int64
+	UserMaxFailures int64
+	IPMaxFailures   int64
+	LockDuration    int
+	MaxLockDuration int
+}
+
 // RateLimiter 请求频率限制配置参数
 type RateLimiter struct {
 	Enable  bool
@@ -1,14 +1,23 @@
 package contextx
 
-import "context"
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"sync"
+)
 
 // 定义全局上下文中的数据
 type (
-	transCtx     struct{} // 事务上下文
-	noTransCtx   struct{} // 不使用事务上下文
-	transLockCtx struct{} // 事务锁上下文
-	userIDCtx    struct{} // 用户ID上下文
-	traceIDCtx   struct{} // 跟踪ID上下文
+	transCtx       struct{} // 事务上下文
+	noTransCtx     struct{} // 不使用事务上下文
+	transLockCtx   struct{} // 事务锁上下文
+	userIDCtx      struct{} // 用户ID上下文
+	actorIDCtx     struct{} // 实际操作者ID上下文
+	traceIDCtx     struct{} // 跟踪ID上下文
+	tenantIDCtx    struct{} // 租户ID上下文
+	dataScopeCtx   struct{} // 数据权限范围上下文
+	noDataScopeCtx struct{} // 不使用数据权限范围上下文
+	systemCtx      struct{} // 系统任务上下文
 )
 
 // NewTrans 创建事务的上下文
@@ -60,6 +69,38 @@ func FromUserID(ctx context.Context) (string, bool) {
 	return "", false
 }
 
+// NewActorID 创建实际操作者ID的上下文(模拟登录时为管理员ID)
+func NewActorID(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorIDCtx{}, actorID)
+}
+
+// FromActorID 从上下文中获取实际操作者ID
+func FromActorID(ctx context.Context) (string, bool) {
+	v := ctx.Value(actorIDCtx{})
+	if v != nil {
+		if s, ok := v.(string); ok {
+			return s, s != ""
+		}
+	}
+	return "", false
+}
+
+// NewTenantID 创建租户ID的上下文(存储查询将限定为该租户的数据)
+func NewTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDCtx{}, tenantID)
+}
+
+// FromTenantID 从上下文中获取租户ID
+func FromTenantID(ctx context.Context) (string, bool) {
+	v := ctx.Value(tenantIDCtx{})
+	if v != nil {
+		if s, ok := v.(string); ok {
+			return s, s != ""
+		}
+	}
+	return "", false
+}
+
 // NewTraceID 创建跟踪ID的上下文
 func NewTraceID(ctx context.Context, traceID string) context.Context {
 	return context.WithValue(ctx, traceIDCtx{}, traceID)
@@ -75,3 +116,57 @@ func FromTraceID(ctx context.Context) (string, bool) {
 	}
 	return "", false
 }
+
+// DataScopeLoader 数据权限范围加载函数
+type DataScopeLoader func() (*schema.DataScope, error)
+
+type dataScopeHolder struct {
+	once  sync.Once
+	load  DataScopeLoader
+	scope *schema.DataScope
+	err   error
+}
+
+// NewDataScope 创建数据权限范围的上下文(首次获取时才执行加载，同一请求内只加载一次)
+func NewDataScope(ctx context.Context, load DataScopeLoader) context.Context {
+	return context.WithValue(ctx, dataScopeCtx{}, &dataScopeHolder{load: load})
+}
+
+// FromDataScope 从上下文中获取数据权限范围(未设置或不使用数据权限范围时返回nil)
+func FromDataScope(ctx context.Context) (*schema.DataScope, error) {
+	if FromNoDataScope(ctx) {
+		return nil, nil
+	}
+
+	v, ok := ctx.Value(dataScopeCtx{}).(*dataScopeHolder)
+	if !ok {
+		return nil, nil
+	}
+
+	v.once.Do(func() {
+		v.scope, v.err = v.load()
+	})
+	return v.scope, v.err
+}
+
+// NewNoDataScope 创建不使用数据权限范围的上下文(用于系统内部的全局查询)
+func NewNoDataScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noDataScopeCtx{}, true)
+}
+
+// FromNoDataScope 从上下文中获取不使用数据权限范围标识
+func FromNoDataScope(ctx context.Context) bool {
+	v := ctx.Value(noDataScopeCtx{})
+	return v != nil && v.(bool)
+}
+
+// NewSystem 创建系统任务的上下文(用于定时清理等系统内部的全局任务，上下文中没有租户时不限定租户)
+func NewSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemCtx{}, true)
+}
+
+// FromSystem 从上下文中获取系统任务标识
+func FromSystem(ctx context.Context) bool {
+	v := ctx.Value(systemCtx{})
+	return v != nil && v.(bool)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -16,6 +17,9 @@ import (
 const (
 	prefix           = "gin-admin"
 	UserIDKey        = prefix + "/user-id"
+	ActorIDKey       = prefix + "/actor-id"
+	TenantIDKey      = prefix + "/tenant-id"
+	APIKeyKey        = prefix + "/api-key"
 	ReqBodyKey       = prefix + "/req-body"
 	ResBodyKey       = prefix + "/res-body"
 	LoggerReqBodyKey = prefix + "/logger-req-body"
@@ -42,6 +46,39 @@ func SetUserID(c *gin.Context, userID string) {
 	c.Set(UserIDKey, userID)
 }
 
+// GetActorID 获取实际操作者ID(模拟登录时为管理员ID，否则为空)
+func GetActorID(c *gin.Context) string {
+	return c.GetString(ActorIDKey)
+}
+
+// SetActorID 设定实际操作者ID
+func SetActorID(c *gin.Context, actorID string) {
+	c.Set(ActorIDKey, actorID)
+}
+
+// GetTenantID 获取租户ID
+func GetTenantID(c *gin.Context) string {
+	return c.GetString(TenantIDKey)
+}
+
+// SetTenantID 设定租户ID
+func SetTenantID(c *gin.Context, tenantID string) {
+	c.Set(TenantIDKey, tenantID)
+}
+
+// GetAPIKey 获取API密钥认证信息(未使用API密钥认证时返回nil)
+func GetAPIKey(c *gin.Context) *schema.APIKeyAuth {
+	if v, ok := c.Get(APIKeyKey); ok {
+		return v.(*schema.APIKeyAuth)
+	}
+	return nil
+}
+
+// SetAPIKey 设定API密钥认证信息
+func SetAPIKey(c *gin.Context, item *schema.APIKeyAuth) {
+	c.Set(APIKeyKey, item)
+}
+
 // GetBody 获取请求的正文
 func GetBody(c *gin.Context) []byte {
 	if v, ok := c.Get(ReqBodyKey); ok {
@@ -76,6 +113,25 @@ func ParseForm(c *gin.Context, obj interface{}) error {
 	return nil
 }
 
+// SetETag 将资源版本号设置为响应的ETag
+func SetETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ParseIfMatch 解析If-Match请求头中的资源版本号(与ETag对应)
+func ParseIfMatch(c *gin.Context) (int64, error) {
+	v := strings.TrimSpace(c.GetHeader("If-Match"))
+	if v == "" {
+		return 0, errors.ErrPreconditionRequired
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(v, "W/"), `"`), 10, 64)
+	if err != nil {
+		return 0, errors.New400Response("无效的资源版本(If-Match)")
+	}
+	return version, nil
+}
+
 // ResOK 响应OK
 func ResOK(c *gin.Context) {
 	ResSuccess(c, schema.StatusResult{Status: schema.OKStatus})
@@ -146,6 +202,7 @@ func ResError(c *gin.Context, err error, status ...int) {
 	eitem := schema.ErrorItem{
 		Code:    res.Code,
 		Message: res.Message,
+		Details: res.Details,
 	}
 	ResJSON(c, res.StatusCode, schema.ErrorResult{
 		Error: eitem,
@@ -1,9 +1,12 @@
 package app
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"ginAdmin/internal/app/config"
 	"ginAdmin/internal/app/model/gormx"
+	"ginAdmin/pkg/logger"
 	"gorm.io/gorm"
 	"os"
 	"path/filepath"
@@ -17,8 +20,35 @@ func InitGormDB() (*gorm.DB, func(), error) {
 		return nil, cleanFunc, err
 	}
 
+	m, err := gormx.NewMigrator(db, cfg.DBType, cfg.TablePrefix)
+	if err != nil {
+		return nil, cleanFunc, err
+	}
+
+	ctx := context.Background()
 	if cfg.EnableAutoMigrate {
-		err = gormx.AutoMigrate(db)
+		migrations, err := m.Up(ctx, 0)
+		for _, item := range migrations {
+			logger.WithContext(ctx).Infof("执行数据库迁移: %d_%s", item.Version, item.Name)
+		}
+		if err != nil {
+			return nil, cleanFunc, err
+		}
+	}
+
+	// 数据库结构与当前版本不一致时拒绝启动
+	err = m.Check(ctx)
+	if err != nil {
+		return nil, cleanFunc, fmt.Errorf("数据库结构与当前版本不一致: %w", err)
+	}
+
+	err = gormx.CheckSchema(db)
+	if err != nil {
+		return nil, cleanFunc, err
+	}
+
+	if c := config.C.Audit; c.Enable {
+		err = gormx.RegisterAudit(db, cfg.TablePrefix, c.IgnoreTables, c.MaskColumns)
 		if err != nil {
 			return nil, cleanFunc, err
 		}
@@ -17,4 +17,8 @@ type Injector struct {
 	Auth           auth.Auther
 	CasbinEnforcer *casbin.SyncedEnforcer
 	MenuBll        *service.Menu
+	RecycleBinBll  *service.RecycleBin
+	TenantBll      *service.Tenant
+	UserBll        *service.User
+	UserRoleBll    *service.UserRole
 }
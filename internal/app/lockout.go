@@ -0,0 +1,82 @@
+package app
+
+import (
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/service"
+	"ginAdmin/pkg/lockout"
+	"ginAdmin/pkg/lockout/store/memory"
+	"ginAdmin/pkg/lockout/store/redis"
+	"time"
+)
+
+// InitLoginLockout 初始化登录失败锁定(未启用时返回nil)
+func InitLoginLockout() (*lockout.Limiter, func(), error) {
+	cfg := config.C.LoginLockout
+	if !cfg.Enable {
+		return nil, func() {}, nil
+	}
+
+	store := newLockoutStore(cfg.RedisPrefix)
+	rule := lockout.Rule{
+		CaptchaFailures: cfg.CaptchaFailures,
+		Window:          time.Duration(cfg.Window) * time.Second,
+		LockDuration:    time.Duration(cfg.LockDuration) * time.Second,
+		MaxLockDuration: time.Duration(cfg.MaxLockDuration) * time.Second,
+	}
+	userRule, ipRule := rule, rule
+	userRule.MaxFailures = cfg.UserMaxFailures
+	ipRule.MaxFailures = cfg.IPMaxFailures
+
+	limiter := lockout.New(store, map[string]lockout.Rule{
+		service.LockoutUser: userRule,
+		service.LockoutIP:   ipRule,
+	})
+	cleanFunc := func() {
+		_ = limiter.Release()
+	}
+	return limiter, cleanFunc, nil
+}
+
+// InitPasswordResetLimiter 初始化密码重置申请频率限制(存储方式与登录失败锁定相同，未限制申请次数时返回nil)
+func InitPasswordResetLimiter() (*service.PasswordResetLimiter, func(), error) {
+	cfg := config.C.PasswordReset
+	if cfg.UserMaxRequests <= 0 && cfg.IPMaxRequests <= 0 {
+		return nil, func() {}, nil
+	}
+
+	//	统计窗口内的申请次数达到上限后，锁定到窗口结束
+	window := time.Duration(cfg.Window) * time.Second
+	rule := lockout.Rule{
+		Window:          window,
+		LockDuration:    window,
+		MaxLockDuration: window,
+	}
+	userRule, ipRule := rule, rule
+	userRule.MaxFailures = cfg.UserMaxRequests
+	ipRule.MaxFailures = cfg.IPMaxRequests
+
+	limiter := lockout.New(newLockoutStore(config.C.LoginLockout.RedisPrefix+"password_reset_"), map[string]lockout.Rule{
+		service.LockoutUser: userRule,
+		service.LockoutIP:   ipRule,
+	})
+	cleanFunc := func() {
+		_ = limiter.Release()
+	}
+	return &service.PasswordResetLimiter{Limiter: limiter}, cleanFunc, nil
+}
+
+func newLockoutStore(keyPrefix string) lockout.Storer {
+	cfg := config.C.LoginLockout
+	switch cfg.Store {
+	case "memory":
+		return memory.NewStore(0)
+	default:
+		rcfg := config.C.Redis
+		return redis.NewStore(&redis.Config{
+			Addr:      rcfg.Addr,
+			Password:  rcfg.Password,
+			DB:        cfg.RedisDB,
+			KeyPrefix: keyPrefix,
+		})
+	}
+}
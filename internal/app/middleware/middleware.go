@@ -55,6 +55,24 @@ func AllowPathPrefixNoSkipper(prefixes ...string) SkipperFunc {
 	}
 }
 
+// AllowRoutePrefixNoSkipper 检查请求路径或匹配的路由(例如/api/v1/users/:id)是否包含指定的前缀，如果包含则不跳过
+func AllowRoutePrefixNoSkipper(prefixes ...string) SkipperFunc {
+	pathSkipper := AllowPathPrefixNoSkipper(prefixes...)
+	return func(c *gin.Context) bool {
+		if !pathSkipper(c) {
+			return false
+		}
+
+		route := c.FullPath()
+		for _, p := range prefixes {
+			if strings.HasPrefix(route, p) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 // AllowMethodAndPathPrefixSkipper 检查请求方法和路径是否包含指定的前缀，如果不包含则跳过
 func AllowMethodAndPathPrefixSkipper(prefixes ...string) SkipperFunc {
 	return func(c *gin.Context) bool {
@@ -1,29 +1,63 @@
 package middleware
 
 import (
+	"context"
 	"ginAdmin/internal/app/config"
 	"ginAdmin/internal/app/contextx"
 	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
 	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/apikey"
 	"ginAdmin/pkg/errors"
 	"ginAdmin/pkg/logger"
 	"github.com/gin-gonic/gin"
 )
 
-// 包装用户身份验证上下文
-func wrapUserAuthContext(c *gin.Context, userID string) {
+// 包装用户身份验证上下文(未指定租户的令牌归属默认租户)
+func wrapUserAuthContext(c *gin.Context, userID, tenantID string) {
+	if tenantID == "" {
+		tenantID = schema.DefaultTenantID
+	}
 	ginx.SetUserID(c, userID)
+	ginx.SetTenantID(c, tenantID)
 	ctx := contextx.NewUserID(c.Request.Context(), userID)
+	ctx = contextx.NewTenantID(ctx, tenantID)
 	ctx = logger.NewUserIDContext(ctx, userID)
 	c.Request = c.Request.WithContext(ctx)
 }
 
-// UserAuthMiddleware 用户授权中间件
-func UserAuthMiddleware(a auth.Auther, skippers ...SkipperFunc) gin.HandlerFunc {
+// 包装模拟登录的实际操作者上下文
+func wrapActorContext(c *gin.Context, actorID string) {
+	ginx.SetActorID(c, actorID)
+	ctx := contextx.NewActorID(c.Request.Context(), actorID)
+	ctx = logger.NewActorIDContext(ctx, actorID)
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// 包装默认用户(超级管理员)的身份验证上下文
+func wrapDefaultUserAuthContext(c *gin.Context, defaultUserID func(context.Context) (string, error)) bool {
+	userID, err := defaultUserID(c.Request.Context())
+	if err != nil {
+		ginx.ResError(c, err)
+		return false
+	}
+	wrapUserAuthContext(c, userID, schema.DefaultTenantID)
+	return true
+}
+
+// APIKeyVerifier 校验API密钥，返回所属用户及权限范围
+type APIKeyVerifier func(ctx context.Context, token string) (*schema.APIKeyAuth, error)
+
+// TenantChecker 检查租户是否存在且已启用
+type TenantChecker func(ctx context.Context, tenantID string) (bool, error)
+
+// UserAuthMiddleware 用户授权中间件(同时支持JWT令牌及API密钥，令牌所属租户已停用时拒绝访问；未启用认证或调试模式下令牌无效时，使用defaultUserID返回的用户身份)
+func UserAuthMiddleware(a auth.Auther, verifyAPIKey APIKeyVerifier, checkTenant TenantChecker, defaultUserID func(context.Context) (string, error), skippers ...SkipperFunc) gin.HandlerFunc {
 	if !config.C.JWTAuth.Enable {
 		return func(c *gin.Context) {
-			wrapUserAuthContext(c, config.C.Root.UserName)
-			c.Next()
+			if wrapDefaultUserAuthContext(c, defaultUserID) {
+				c.Next()
+			}
 		}
 	}
 
@@ -33,19 +67,50 @@ func UserAuthMiddleware(a auth.Auther, skippers ...SkipperFunc) gin.HandlerFunc
 			return
 		}
 
-		userID, err := a.ParseUserID(c.Request.Context(), ginx.GetToken(c))
+		token := ginx.GetToken(c)
+		if apikey.IsAPIKey(token) {
+			item, err := verifyAPIKey(c.Request.Context(), token)
+			if err != nil {
+				ginx.ResError(c, err)
+				return
+			}
+			ginx.SetAPIKey(c, item)
+			wrapUserAuthContext(c, item.UserID, item.TenantID)
+			c.Next()
+			return
+		}
+
+		identity, err := a.ParseIdentity(c.Request.Context(), token)
 		if err != nil {
 			if err == auth.ErrInvalidToken {
 				if config.C.IsDebugMode() {
-					wrapUserAuthContext(c, config.C.Root.UserName)
-					c.Next()
+					if wrapDefaultUserAuthContext(c, defaultUserID) {
+						c.Next()
+					}
 					return
 				}
 			}
 			ginx.ResError(c, errors.ErrInvalidToken)
 			return
 		}
-		wrapUserAuthContext(c, userID)
+
+		//	租户停用时已撤销会话，同时检查租户状态以覆盖未撤销的令牌(API密钥在校验时检查)
+		tenantID := identity.TenantID
+		if tenantID == "" {
+			tenantID = schema.DefaultTenantID
+		}
+		if ok, err := checkTenant(c.Request.Context(), tenantID); err != nil {
+			ginx.ResError(c, err)
+			return
+		} else if !ok {
+			ginx.ResError(c, errors.ErrInvalidToken)
+			return
+		}
+
+		wrapUserAuthContext(c, identity.UserID, identity.TenantID)
+		if identity.IsImpersonated() {
+			wrapActorContext(c, identity.ActorID)
+		}
 		c.Next()
 	}
 }
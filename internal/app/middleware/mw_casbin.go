@@ -3,27 +3,47 @@ package middleware
 import (
 	"ginAdmin/internal/app/config"
 	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
 	"ginAdmin/pkg/errors"
 	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/util"
 	"github.com/gin-gonic/gin"
 )
 
-// CasbinMiddleware casbin中间件
+// 检查请求是否在API密钥的权限范围内(权限范围为空时不限制)
+func matchAPIKeyScopes(scopes schema.APIKeyScopes, path, method string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+
+	for _, item := range scopes {
+		if util.KeyMatch2(path, item.Path) && util.RegexMatch(method, item.Method) {
+			return true
+		}
+	}
+	return false
+}
+
+// CasbinMiddleware casbin中间件(使用API密钥认证时，请求还需在密钥的权限范围内)
 func CasbinMiddleware(enforcer *casbin.SyncedEnforcer, skippers ...SkipperFunc) gin.HandlerFunc {
 	cfg := config.C.Casbin
-	if !cfg.Enable {
-		return EmptyMiddleware()
-	}
 
 	return func(c *gin.Context) {
-		if SkipHandler(c, skippers...) {
+		p := c.Request.URL.Path
+		m := c.Request.Method
+
+		//	权限范围对所有接口生效(包括跳过权限校验的当前用户接口)
+		if item := ginx.GetAPIKey(c); item != nil && !matchAPIKeyScopes(item.Scopes, p, m) {
+			ginx.ResError(c, errors.ErrNoPerm)
+			return
+		}
+
+		if !cfg.Enable || SkipHandler(c, skippers...) {
 			c.Next()
 			return
 		}
 
-		p := c.Request.URL.Path
-		m := c.Request.Method
-		if b, err := enforcer.Enforce(ginx.GetUserID(c), p, m); err != nil {
+		if b, err := enforcer.Enforce(ginx.GetUserID(c), ginx.GetTenantID(c), p, m); err != nil {
 			ginx.ResError(c, errors.WithStack(err))
 			return
 		} else if !b {
@@ -13,6 +13,8 @@ func CORSMiddleware() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     cfg.AllowOrigins,
 		AllowMethods:     cfg.AllowMethods,
+		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    []string{"ETag"},
 		AllowCredentials: cfg.AllowCredentials,
 		MaxAge:           time.Second * time.Duration(cfg.MaxAge),
 	})
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/internal/app/schema"
+	"github.com/gin-gonic/gin"
+)
+
+// DataScopeMiddleware 数据权限范围中间件(仓储首次查询受限数据时才计算当前用户的数据权限范围)
+func DataScopeMiddleware(resolve func(ctx context.Context, userID string) (*schema.DataScope, error), skippers ...SkipperFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if SkipHandler(c, skippers...) {
+			c.Next()
+			return
+		}
+
+		userID := ginx.GetUserID(c)
+		ctx := c.Request.Context()
+		c.Request = c.Request.WithContext(contextx.NewDataScope(ctx, func() (*schema.DataScope, error) {
+			return resolve(ctx, userID)
+		}))
+		c.Next()
+	}
+}
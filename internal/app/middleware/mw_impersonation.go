@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"ginAdmin/internal/app/ginx"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonationMiddleware 模拟登录中间件(模拟登录时禁止访问敏感接口，未跳过的请求即为敏感接口)
+func ImpersonationMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID := ginx.GetActorID(c)
+		if actorID == "" || SkipHandler(c, skippers...) {
+			c.Next()
+			return
+		}
+
+		ctx := logger.NewTagContext(c.Request.Context(), "__impersonate__")
+		logger.WithContext(ctx).Warnf("模拟登录时禁止访问敏感接口: %s %s", c.Request.Method, c.Request.URL.Path)
+		ginx.ResError(c, errors.ErrImpersonationForbidden)
+	}
+}
@@ -61,6 +61,9 @@ func LoggerMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
 		}
 
 		fields[logger.UserIDKey] = ginx.GetUserID(c)
+		if v := ginx.GetActorID(c); v != "" {
+			fields[logger.ActorIDKey] = v
+		}
 		entry.WithFields(fields).Infof("[http] %s-%s-%s-%d(%dms)",
 			p, c.Request.Method, c.ClientIP(), c.Writer.Status(), timeConsuming)
 	}
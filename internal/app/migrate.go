@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/model/gormx"
+	"ginAdmin/pkg/logger"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Migrate 执行数据库迁移命令(up [n]/down [n]/status)
+func Migrate(ctx context.Context, args []string, opts ...Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	config.MustLoad(o.ConfigFile)
+
+	if len(args) == 0 {
+		return errors.New("usage: migrate up [n] | down [n] | status")
+	}
+
+	var n int
+	if len(args) > 1 {
+		v, err := strconv.Atoi(args[1])
+		if err != nil || v <= 0 {
+			return fmt.Errorf("invalid migration count: %s", args[1])
+		}
+		n = v
+	}
+
+	db, cleanFunc, err := NewGormDB()
+	if err != nil {
+		return err
+	}
+	defer cleanFunc()
+
+	cfg := config.C.Gorm
+	m, err := gormx.NewMigrator(db, cfg.DBType, cfg.TablePrefix)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		migrations, err := m.Up(ctx, n)
+		for _, item := range migrations {
+			logger.WithContext(ctx).Infof("执行数据库迁移: %d_%s", item.Version, item.Name)
+		}
+		return err
+	case "down":
+		if n == 0 {
+			n = 1
+		}
+		migrations, err := m.Down(ctx, n)
+		for _, item := range migrations {
+			logger.WithContext(ctx).Infof("回滚数据库迁移: %d_%s", item.Version, item.Name)
+		}
+		return err
+	case "status":
+		list, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, item := range list {
+			appliedAt := "pending"
+			if item.AppliedAt != nil {
+				appliedAt = item.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if item.Unknown {
+				appliedAt += " (unknown)"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", item.Version, item.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command: %s", args[0])
+	}
+}
@@ -0,0 +1,79 @@
+package gormx
+
+import (
+	"context"
+	"encoding/json"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/audit"
+	"ginAdmin/pkg/util/uuid"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// RegisterAudit 注册数据变更审计(变更记录与数据变更在同一事务中写入审计表，ignoreTables不含表名前缀)
+func RegisterAudit(db *gorm.DB, tablePrefix string, ignoreTables, maskColumns []string) error {
+	tables := []string{tablePrefix + "audit_log"}
+	for _, table := range ignoreTables {
+		tables = append(tables, tablePrefix+table)
+	}
+
+	return db.Use(audit.New(audit.Config{
+		IgnoreTables: tables,
+		MaskColumns:  maskColumns,
+		Write: func(db *gorm.DB, records []*audit.Record) error {
+			return writeAuditLogs(db, tablePrefix, records)
+		},
+	}))
+}
+
+// 写入变更记录(操作者及跟踪ID取自数据变更的上下文，上下文中没有租户时租户取自变更的数据)
+func writeAuditLogs(db *gorm.DB, tablePrefix string, records []*audit.Record) error {
+	ctx := db.Statement.Context
+	_, hasTenant := contextx.FromTenantID(ctx)
+	userID, _ := contextx.FromUserID(ctx)
+	actorID, ok := contextx.FromActorID(ctx)
+	if !ok {
+		actorID = userID
+	}
+	traceID, _ := contextx.FromTraceID(ctx)
+
+	list := make(entity.AuditLogs, len(records))
+	for i, item := range records {
+		changes, err := json.Marshal(item.Changes)
+		if err != nil {
+			return err
+		}
+
+		list[i] = &entity.AuditLog{
+			ID:       uuid.MustString(),
+			UserID:   userID,
+			ActorID:  actorID,
+			TraceID:  traceID,
+			Table:    strings.TrimPrefix(item.Table, tablePrefix),
+			RecordID: item.RecordID,
+			Action:   item.Action,
+			Changes:  string(changes),
+		}
+		if !hasTenant {
+			list[i].TenantID = auditTenantID(ctx, item)
+		}
+	}
+	return db.Create(&list).Error
+}
+
+// 获取变更数据的租户(系统任务变更没有租户的数据时使用系统标识，其他情况为空时写入默认租户)
+func auditTenantID(ctx context.Context, item *audit.Record) string {
+	switch v := item.Row["tenant_id"].(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+
+	if contextx.FromSystem(ctx) {
+		return schema.SystemTenantID
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package gormx_test
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/model/gormx/gormxtest"
+	"ginAdmin/internal/app/schema"
+	"testing"
+)
+
+func TestAuditTenant(t *testing.T) {
+	db := gormxtest.NewDB(t)
+	if err := gormx.RegisterAudit(db, "", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tenantOf := func(recordID, action string) string {
+		t.Helper()
+		var list entity.AuditLogs
+		if err := db.Where("record_id=? AND action=?", recordID, action).Find(&list).Error; err != nil {
+			t.Fatal(err)
+		} else if len(list) != 1 {
+			t.Fatalf("unexpected audit logs for %s %s: %d", recordID, action, len(list))
+		}
+		return list[0].TenantID
+	}
+
+	ctx := context.Background()
+	tctx := contextx.NewTenantID(ctx, "t1")
+	if err := db.WithContext(tctx).Create(&entity.Role{ID: "r1", Name: "r1", Status: 1}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.WithContext(tctx).Create(&entity.UserRole{ID: "ur1", UserID: "u1", RoleID: "r1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if v := tenantOf("ur1", "create"); v != "t1" {
+		t.Fatalf("unexpected tenant: %s", v)
+	}
+
+	// 系统任务变更的数据使用数据所属的租户
+	sctx := contextx.NewSystem(ctx)
+	if err := db.WithContext(sctx).Model(new(entity.Role)).Where("id=?", "r1").Update("status", 2).Error; err != nil {
+		t.Fatal(err)
+	}
+	if v := tenantOf("r1", "update"); v != "t1" {
+		t.Fatalf("unexpected tenant: %s", v)
+	}
+	if err := db.WithContext(sctx).Unscoped().Where("id=?", "r1").Delete(new(entity.Role)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if v := tenantOf("r1", "delete"); v != "t1" {
+		t.Fatalf("unexpected tenant: %s", v)
+	}
+
+	// 数据没有租户时使用系统标识
+	if err := db.WithContext(sctx).Where("id=?", "ur1").Delete(new(entity.UserRole)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if v := tenantOf("ur1", "delete"); v != schema.SystemTenantID {
+		t.Fatalf("unexpected tenant: %s", v)
+	}
+}
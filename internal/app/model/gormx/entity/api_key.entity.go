@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetAPIKeyDB 获取API密钥存储
+func GetAPIKeyDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(APIKey))
+}
+
+// ToAPIKey 转换为API密钥实体
+func ToAPIKey(a *schema.APIKey) *APIKey {
+	item := &APIKey{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Name:       a.Name,
+		Prefix:     a.Prefix,
+		TokenHash:  a.TokenHash,
+		ExpiresAt:  a.ExpiresAt,
+		LastUsedAt: a.LastUsedAt,
+		Creator:    a.Creator,
+		CreatedAt:  a.CreatedAt,
+	}
+	if len(a.Scopes) > 0 {
+		b, _ := json.Marshal(a.Scopes)
+		item.ScopeData = string(b)
+	}
+	return item
+}
+
+// APIKey API密钥实体
+type APIKey struct {
+	ID         string     `gorm:"column:id;primaryKey;size:36;"`
+	UserID     string     `gorm:"column:user_id;size:36;index;default:'';not null;"`      // 所属用户内码
+	Name       string     `gorm:"column:name;size:64;default:'';not null;"`               // 名称
+	Prefix     string     `gorm:"column:prefix;size:32;uniqueIndex;default:'';not null;"` // 可见前缀
+	TokenHash  string     `gorm:"column:token_hash;size:64;default:'';not null;"`         // 密钥哈希(sha256)
+	ScopeData  string     `gorm:"column:scopes;type:text;"`                               // 权限范围(JSON)
+	ExpiresAt  *time.Time `gorm:"column:expires_at;index;"`                               // 到期时间
+	LastUsedAt *time.Time `gorm:"column:last_used_at;"`                                   // 最后使用时间
+	Creator    string     `gorm:"column:creator;size:36;"`                                // 创建者
+	CreatedAt  time.Time  `gorm:"column:created_at;index;"`
+}
+
+// ToSchemaAPIKey 转换为API密钥对象
+func (a *APIKey) ToSchemaAPIKey() *schema.APIKey {
+	item := &schema.APIKey{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		Name:       a.Name,
+		Prefix:     a.Prefix,
+		TokenHash:  a.TokenHash,
+		ExpiresAt:  a.ExpiresAt,
+		LastUsedAt: a.LastUsedAt,
+		Creator:    a.Creator,
+		CreatedAt:  a.CreatedAt,
+	}
+	if a.ScopeData != "" {
+		_ = json.Unmarshal([]byte(a.ScopeData), &item.Scopes)
+	}
+	return item
+}
+
+// APIKeys API密钥实体列表
+type APIKeys []*APIKey
+
+// ToSchemaAPIKeys 转换为API密钥对象列表
+func (a APIKeys) ToSchemaAPIKeys() []*schema.APIKey {
+	list := make([]*schema.APIKey, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaAPIKey()
+	}
+	return list
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetAuditLogDB 获取数据变更审计存储
+func GetAuditLogDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(AuditLog))
+}
+
+// AuditLog 数据变更审计实体
+type AuditLog struct {
+	TenantModel
+	ID        string    `gorm:"column:id;primaryKey;size:36;"`
+	UserID    string    `gorm:"column:user_id;size:36;default:'';not null;"`                               // 操作用户内码
+	ActorID   string    `gorm:"column:actor_id;size:36;index;default:'';not null;"`                        // 实际操作者内码
+	TraceID   string    `gorm:"column:trace_id;size:64;default:'';not null;"`                              // 跟踪ID
+	Table     string    `gorm:"column:table_name;size:64;index:idx_audit_log_record;default:'';not null;"` // 数据表(不含表名前缀)
+	RecordID  string    `gorm:"column:record_id;size:128;index:idx_audit_log_record;default:'';not null;"` // 数据主键
+	Action    string    `gorm:"column:action;size:16;default:'';not null;"`                                // 变更类型
+	Changes   string    `gorm:"column:changes;type:text;"`                                                 // 字段变更(JSON)
+	CreatedAt time.Time `gorm:"column:created_at;index;"`
+}
+
+// ToSchemaAuditLog 转换为数据变更审计对象
+func (a *AuditLog) ToSchemaAuditLog() *schema.AuditLog {
+	item := &schema.AuditLog{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		ActorID:   a.ActorID,
+		TraceID:   a.TraceID,
+		Table:     a.Table,
+		RecordID:  a.RecordID,
+		Action:    a.Action,
+		CreatedAt: a.CreatedAt,
+	}
+	if a.Changes != "" {
+		_ = json.Unmarshal([]byte(a.Changes), &item.Changes)
+	}
+	return item
+}
+
+// AuditLogs 数据变更审计实体列表
+type AuditLogs []*AuditLog
+
+// ToSchemaAuditLogs 转换为数据变更审计对象列表
+func (a AuditLogs) ToSchemaAuditLogs() []*schema.AuditLog {
+	list := make([]*schema.AuditLog, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaAuditLog()
+	}
+	return list
+}
@@ -3,7 +3,6 @@ package entity
 import (
 	"context"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/util/structure"
 	"gorm.io/gorm"
 	"time"
 )
@@ -13,34 +12,51 @@ func GetDemoDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	return GetDBWithModel(ctx, defDB, new(Demo))
 }
 
-// SchemaDemo demo对象
-type SchemaDemo schema.Demo
-
 // ToDemo 转换demo实体
-func (a SchemaDemo) ToDemo() *Demo {
-	item := new(Demo)
-	structure.Copy(a, item)
-	return item
+func ToDemo(a *schema.Demo) *Demo {
+	return &Demo{
+		TenantModel: TenantModel{TenantID: a.TenantID},
+		ID:          a.ID,
+		Code:        a.Code,
+		Name:        a.Name,
+		Memo:        stringPtr(a.Memo),
+		Status:      a.Status,
+		Creator:     a.Creator,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+		Version:     a.Version,
+	}
 }
 
 // Demo demo实体
 type Demo struct {
-	ID        string     `gorm:"column:id;primary_key;size:36;"`
-	Code      string     `gorm:"column:code;size:50;index;default:'';not null;"`
-	Name      string     `gorm:"column:name;size:100;index;default:'';not null;"` // 名称
-	Memo      *string    `gorm:"column:memo;size:200;"`                           // 备注
-	Status    int        `gorm:"column:status;index;default:0;not null;"`         // 状态(1:启用 2:停用)
-	Creator   string     `gorm:"column:creator;size:36;"`                         // 创建者
-	CreatedAt time.Time  `gorm:"column:created_at;index;"`
-	UpdatedAt time.Time  `gorm:"column:updated_at;index;"`
-	DeletedAt *time.Time `gorm:"column:deleted_at;index;"`
+	TenantModel
+	ID        string         `gorm:"column:id;primary_key;size:36;"`
+	Code      string         `gorm:"column:code;size:50;index;default:'';not null;"`
+	Name      string         `gorm:"column:name;size:100;index;default:'';not null;"` // 名称
+	Memo      *string        `gorm:"column:memo;size:200;"`                           // 备注
+	Status    int            `gorm:"column:status;index;default:0;not null;"`         // 状态(1:启用 2:停用)
+	Creator   string         `gorm:"column:creator;size:36;"`                         // 创建者
+	CreatedAt time.Time      `gorm:"column:created_at;index;"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;index;"`
+	Version   int64          `gorm:"column:version;default:1;not null;"` // 版本号
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index;"`
 }
 
 // ToSchemaDemo 转换为demo对象
-func (a Demo) ToSchemaDemo() *schema.Demo {
-	item := new(schema.Demo)
-	structure.Copy(a, item)
-	return item
+func (a *Demo) ToSchemaDemo() *schema.Demo {
+	return &schema.Demo{
+		TenantID:  a.TenantID,
+		ID:        a.ID,
+		Code:      a.Code,
+		Name:      a.Name,
+		Memo:      stringValue(a.Memo),
+		Status:    a.Status,
+		Creator:   a.Creator,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+		Version:   a.Version,
+	}
 }
 
 // Demos demo列表
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetDeptDB 获取部门储存
+func GetDeptDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(Dept))
+}
+
+// ToDept 转换为部门实体
+func ToDept(a *schema.Dept) *Dept {
+	return &Dept{
+		TenantModel: TenantModel{TenantID: a.TenantID},
+		ID:          a.ID,
+		Name:        a.Name,
+		Sequence:    a.Sequence,
+		ParentID:    stringPtr(a.ParentID),
+		ParentPath:  stringPtr(a.ParentPath),
+		Status:      a.Status,
+		Memo:        stringPtr(a.Memo),
+		Creator:     a.Creator,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+}
+
+// Dept 部门实体
+type Dept struct {
+	TenantModel
+	ID         string         `gorm:"column:id;primaryKey;size:36;"`
+	Name       string         `gorm:"column:name;size:50;index;default:'';not null;"` // 部门名称
+	Sequence   int            `gorm:"column:sequence;index;default:0;not null;"`      // 排序值
+	ParentID   *string        `gorm:"column:parent_id;size:36;index;"`                // 父级内码
+	ParentPath *string        `gorm:"column:parent_path;size:518;index;"`             // 父级路径
+	Status     int            `gorm:"column:status;index;default:0;not null;"`        // 状态(1:启用 2:禁用)
+	Memo       *string        `gorm:"column:memo;size:1024;"`                         // 备注
+	Creator    string         `gorm:"column:creator;size:36;"`                        // 创建人
+	CreatedAt  time.Time      `gorm:"column:created_at;index;"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at;index;"`
+	DeletedAt  gorm.DeletedAt `gorm:"column:deleted_at;index;"`
+}
+
+// ToSchemaDept 转换为部门对象
+func (a *Dept) ToSchemaDept() *schema.Dept {
+	return &schema.Dept{
+		TenantID:   a.TenantID,
+		ID:         a.ID,
+		Name:       a.Name,
+		Sequence:   a.Sequence,
+		ParentID:   stringValue(a.ParentID),
+		ParentPath: stringValue(a.ParentPath),
+		Status:     a.Status,
+		Memo:       stringValue(a.Memo),
+		Creator:    a.Creator,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+	}
+}
+
+// Depts 部门实体列表
+type Depts []*Dept
+
+// ToSchemaDepts 转换为部门对象列表
+func (a Depts) ToSchemaDepts() []*schema.Dept {
+	list := make([]*schema.Dept, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaDept()
+	}
+	return list
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"ginAdmin/internal/app/config"
 	"ginAdmin/internal/app/contextx"
+	"ginAdmin/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GetDB ...
@@ -19,13 +21,66 @@ func GetDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 					db = db.Set("gorm:query_option", "FOR UPDATE")
 				}
 			}
-			return db
+			return db.WithContext(ctx)
 		}
 	}
-	return defDB
+	return defDB.WithContext(ctx)
 }
 
-// GetDBWithModel ...
+// ErrNoTenant 访问按租户隔离的模型时上下文中没有租户
+var ErrNoTenant = errors.New("no tenant in context")
+
+// GetDBWithModel 获取指定模型的DB(按租户隔离的模型限定为上下文中的租户，
+// 上下文中没有租户时除系统任务外均返回ErrNoTenant，且不匹配任何数据)
 func GetDBWithModel(ctx context.Context, defDB *gorm.DB, m interface{}) *gorm.DB {
-	return GetDB(ctx, defDB).Model(m)
+	db := GetDB(ctx, defDB).Model(m)
+	if _, ok := m.(tenantEntity); !ok {
+		return db
+	}
+
+	if tenantID, ok := contextx.FromTenantID(ctx); ok {
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "tenant_id"},
+			Value:  tenantID,
+		})
+	} else if contextx.FromSystem(ctx) {
+		return db
+	}
+
+	//	已有错误时gorm开启的默认事务不会提交或回滚，导致连接泄漏，因此不开启默认事务；
+	//	作为子查询使用时错误不会传递，同时加上恒假条件
+	db = db.Session(&gorm.Session{SkipDefaultTransaction: true})
+	db.AddError(ErrNoTenant)
+	return db.Where("1=0")
+}
+
+type tenantEntity interface {
+	tenantEntity()
+}
+
+// TenantModel 按租户隔离的实体字段(租户ID只在创建时写入，之后不可修改)
+type TenantModel struct {
+	TenantID string `gorm:"<-:create;column:tenant_id;size:36;index;default:'default';not null;"` // 租户ID
+}
+
+func (a *TenantModel) tenantEntity() {}
+
+// BeforeCreate 创建时写入上下文中的租户(上下文中没有租户时使用请求数据或默认租户)
+func (a *TenantModel) BeforeCreate(tx *gorm.DB) error {
+	if tenantID, ok := contextx.FromTenantID(tx.Statement.Context); ok {
+		a.TenantID = tenantID
+	}
+	return nil
+}
+
+// 对象中的可选字段在实体中以指针保存(空值同样写入，按结构体更新时可清空字段)
+func stringPtr(v string) *string {
+	return &v
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
 }
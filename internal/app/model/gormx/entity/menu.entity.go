@@ -3,7 +3,6 @@ package entity
 import (
 	"context"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/util/structure"
 	"gorm.io/gorm"
 	"time"
 )
@@ -13,39 +12,66 @@ func GetMenuDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	return GetDBWithModel(ctx, defDB, new(Menu))
 }
 
-// SchemaMenu 菜单对象
-type SchemaMenu schema.Menu
-
 // ToMenu 转换为菜单实体
-func (a SchemaMenu) ToMenu() *Menu {
-	item := new(Menu)
-	structure.Copy(a, item)
-	return item
+func ToMenu(a *schema.Menu) *Menu {
+	return &Menu{
+		TenantModel: TenantModel{TenantID: a.TenantID},
+		ID:          a.ID,
+		Name:        a.Name,
+		Sequence:    a.Sequence,
+		Icon:        stringPtr(a.Icon),
+		Router:      stringPtr(a.Router),
+		ParentID:    stringPtr(a.ParentID),
+		ParentPath:  stringPtr(a.ParentPath),
+		ShowStatus:  a.ShowStatus,
+		Status:      a.Status,
+		Memo:        stringPtr(a.Memo),
+		Creator:     a.Creator,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+		Version:     a.Version,
+	}
 }
 
 // Menu 菜单实体
 type Menu struct {
-	ID         string     `gorm:"column:id;primaryKey;size:36;"`
-	Name       string     `gorm:"column:name;size:50;index;default:'';not null;"` // 菜单名称
-	Sequence   int        `gorm:"column:sequence;index;default:0;not null;"`      // 排序值
-	Icon       *string    `gorm:"column:icon;size:255;"`                          // 菜单图标
-	Router     *string    `gorm:"column:router;size:255;"`                        // 访问路由
-	ParentID   *string    `gorm:"column:parent_id;size:36;index;"`                // 父级内码
-	ParentPath *string    `gorm:"column:parent_path;size:518;index;"`             // 父级路径
-	ShowStatus int        `gorm:"column:show_status;index;default:0;not null;"`   // 状态(1:显示 2:隐藏)
-	Status     int        `gorm:"column:status;index;default:0;not null;"`        // 状态(1:启用 2:禁用)
-	Memo       *string    `gorm:"column:memo;size:1024;"`                         // 备注
-	Creator    string     `gorm:"column:creator;size:36;"`                        // 创建人
-	CreatedAt  time.Time  `gorm:"column:created_at;index;"`
-	UpdatedAt  time.Time  `gorm:"column:updated_at;index;"`
-	DeletedAt  *time.Time `gorm:"column:deleted_at;index;"`
+	TenantModel
+	ID         string         `gorm:"column:id;primaryKey;size:36;"`
+	Name       string         `gorm:"column:name;size:50;index;default:'';not null;"` // 菜单名称
+	Sequence   int            `gorm:"column:sequence;index;default:0;not null;"`      // 排序值
+	Icon       *string        `gorm:"column:icon;size:255;"`                          // 菜单图标
+	Router     *string        `gorm:"column:router;size:255;"`                        // 访问路由
+	ParentID   *string        `gorm:"column:parent_id;size:36;index;"`                // 父级内码
+	ParentPath *string        `gorm:"column:parent_path;size:518;index;"`             // 父级路径
+	ShowStatus int            `gorm:"column:show_status;index;default:0;not null;"`   // 状态(1:显示 2:隐藏)
+	Status     int            `gorm:"column:status;index;default:0;not null;"`        // 状态(1:启用 2:禁用)
+	Memo       *string        `gorm:"column:memo;size:1024;"`                         // 备注
+	Creator    string         `gorm:"column:creator;size:36;"`                        // 创建人
+	CreatedAt  time.Time      `gorm:"column:created_at;index;"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at;index;"`
+	Version    int64          `gorm:"column:version;default:1;not null;"` // 版本号
+	DeletedAt  gorm.DeletedAt `gorm:"column:deleted_at;index;"`
 }
 
 // ToSchemaMenu 转换为菜单对象
-func (a Menu) ToSchemaMenu() *schema.Menu {
-	item := new(schema.Menu)
-	structure.Copy(a, item)
-	return item
+func (a *Menu) ToSchemaMenu() *schema.Menu {
+	return &schema.Menu{
+		TenantID:   a.TenantID,
+		ID:         a.ID,
+		Name:       a.Name,
+		Sequence:   a.Sequence,
+		Icon:       stringValue(a.Icon),
+		Router:     stringValue(a.Router),
+		ParentID:   stringValue(a.ParentID),
+		ParentPath: stringValue(a.ParentPath),
+		ShowStatus: a.ShowStatus,
+		Status:     a.Status,
+		Memo:       stringValue(a.Memo),
+		Creator:    a.Creator,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+		Version:    a.Version,
+	}
 }
 
 // Menus 菜单实体列表
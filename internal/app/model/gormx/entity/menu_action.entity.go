@@ -3,7 +3,6 @@ package entity
 import (
 	"context"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/util/structure"
 	"gorm.io/gorm"
 )
 
@@ -12,14 +11,14 @@ func GetMenuActionDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	return GetDBWithModel(ctx, defDB, new(MenuAction))
 }
 
-// SchemaMenuAction 菜单动作
-type SchemaMenuAction schema.MenuAction
-
 // ToMenuAction 转换菜单动作实体
-func (a SchemaMenuAction) ToMenuAction() *MenuAction {
-	item := new(MenuAction)
-	structure.Copy(a, item)
-	return item
+func ToMenuAction(a *schema.MenuAction) *MenuAction {
+	return &MenuAction{
+		ID:     a.ID,
+		MenuID: a.MenuID,
+		Code:   a.Code,
+		Name:   a.Name,
+	}
 }
 
 // MenuAction 菜单动作实体
@@ -31,10 +30,13 @@ type MenuAction struct {
 }
 
 // ToSchemaMenuAction 转换为菜单动作对象
-func (a MenuAction) ToSchemaMenuAction() *schema.MenuAction {
-	item := new(schema.MenuAction)
-	structure.Copy(a, item)
-	return item
+func (a *MenuAction) ToSchemaMenuAction() *schema.MenuAction {
+	return &schema.MenuAction{
+		ID:     a.ID,
+		MenuID: a.MenuID,
+		Code:   a.Code,
+		Name:   a.Name,
+	}
 }
 
 // MenuActions 菜单动作列表
@@ -3,7 +3,6 @@ package entity
 import (
 	"context"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/util/structure"
 	"gorm.io/gorm"
 )
 
@@ -12,14 +11,14 @@ func GetMenuActionResourceDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	return GetDBWithModel(ctx, defDB, new(MenuActionResource))
 }
 
-// SchemaMenuActionResource 菜单动作关联资源
-type SchemaMenuActionResource schema.MenuActionResource
-
 // ToMenuActionResource 转换为菜单动作关联资源实体
-func (a SchemaMenuActionResource) ToMenuActionResource() *MenuActionResource {
-	item := new(MenuActionResource)
-	structure.Copy(a, item)
-	return item
+func ToMenuActionResource(a *schema.MenuActionResource) *MenuActionResource {
+	return &MenuActionResource{
+		ID:       a.ID,
+		ActionID: a.ActionID,
+		Method:   a.Method,
+		Path:     a.Path,
+	}
 }
 
 // MenuActionResource 菜单动作关联资源实体
@@ -31,10 +30,13 @@ type MenuActionResource struct {
 }
 
 // ToSchemaMenuActionResource 转换为菜单动作关联资源对象
-func (a MenuActionResource) ToSchemaMenuActionResource() *schema.MenuActionResource {
-	item := new(schema.MenuActionResource)
-	structure.Copy(a, item)
-	return item
+func (a *MenuActionResource) ToSchemaMenuActionResource() *schema.MenuActionResource {
+	return &schema.MenuActionResource{
+		ID:       a.ID,
+		ActionID: a.ActionID,
+		Method:   a.Method,
+		Path:     a.Path,
+	}
 }
 
 // MenuActionResources 菜单动作关联资源列表
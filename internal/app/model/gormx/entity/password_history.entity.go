@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetPasswordHistoryDB 获取历史密码存储
+func GetPasswordHistoryDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(PasswordHistory))
+}
+
+// ToPasswordHistory 转换为历史密码实体
+func ToPasswordHistory(a *schema.PasswordHistory) *PasswordHistory {
+	return &PasswordHistory{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Password:  a.Password,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+// PasswordHistory 历史密码实体
+type PasswordHistory struct {
+	ID        string    `gorm:"column:id;primaryKey;size:36;"`
+	UserID    string    `gorm:"column:user_id;size:36;index;default:'';not null;"` // 用户内码
+	Password  string    `gorm:"column:password;size:255;default:'';not null;"`     // 密码哈希
+	CreatedAt time.Time `gorm:"column:created_at;index;"`
+}
+
+// ToSchemaPasswordHistory 转换为历史密码对象
+func (a *PasswordHistory) ToSchemaPasswordHistory() *schema.PasswordHistory {
+	return &schema.PasswordHistory{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Password:  a.Password,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+// PasswordHistories 历史密码实体列表
+type PasswordHistories []*PasswordHistory
+
+// ToSchemaPasswordHistories 转换为历史密码对象列表
+func (a PasswordHistories) ToSchemaPasswordHistories() []*schema.PasswordHistory {
+	list := make([]*schema.PasswordHistory, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaPasswordHistory()
+	}
+	return list
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetPasswordResetDB 获取密码重置存储
+func GetPasswordResetDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(PasswordReset))
+}
+
+// ToPasswordReset 转换为密码重置实体
+func ToPasswordReset(a *schema.PasswordReset) *PasswordReset {
+	return &PasswordReset{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		TokenHash: a.TokenHash,
+		ExpiresAt: a.ExpiresAt,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+// PasswordReset 密码重置实体
+type PasswordReset struct {
+	ID        string    `gorm:"column:id;primaryKey;size:36;"`
+	UserID    string    `gorm:"column:user_id;size:36;index;default:'';not null;"`          // 用户内码
+	TokenHash string    `gorm:"column:token_hash;size:64;uniqueIndex;default:'';not null;"` // 重置令牌哈希(sha256)
+	ExpiresAt time.Time `gorm:"column:expires_at;index;"`                                   // 到期时间
+	CreatedAt time.Time `gorm:"column:created_at;index;"`
+}
+
+// ToSchemaPasswordReset 转换为密码重置对象
+func (a *PasswordReset) ToSchemaPasswordReset() *schema.PasswordReset {
+	return &schema.PasswordReset{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		TokenHash: a.TokenHash,
+		ExpiresAt: a.ExpiresAt,
+		CreatedAt: a.CreatedAt,
+	}
+}
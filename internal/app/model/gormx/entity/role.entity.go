@@ -3,7 +3,6 @@ package entity
 import (
 	"context"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/util/structure"
 	"gorm.io/gorm"
 	"time"
 )
@@ -13,34 +12,54 @@ func GetRoleDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	return GetDBWithModel(ctx, defDB, new(Role))
 }
 
-// SchemaRole 角色对象
-type SchemaRole schema.Role
-
 // ToRole 转换为角色实体
-func (a SchemaRole) ToRole() *Role {
-	item := new(Role)
-	structure.Copy(a, item)
-	return item
+func ToRole(a *schema.Role) *Role {
+	return &Role{
+		TenantModel: TenantModel{TenantID: a.TenantID},
+		ID:          a.ID,
+		Name:        a.Name,
+		Sequence:    a.Sequence,
+		Memo:        stringPtr(a.Memo),
+		Status:      a.Status,
+		DataScope:   a.DataScope,
+		Creator:     a.Creator,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+		Version:     a.Version,
+	}
 }
 
 // Role 角色实体
 type Role struct {
-	ID        string    `gorm:"column:id;primaryKey;size:36;"`
-	Name      string    `gorm:"column:name;size:100;index;default:'';not nul;"` // 角色名称
-	Sequence  int       `gorm:"column:sequence;index;default:0;not null;"`      // 排序值
-	Memo      *string   `gorm:"column:memo;size:1024;"`                         // 备注
-	Status    int       `gorm:"column:status;index;default:0;not null;"`        // 状态(1:启用 2:禁用)
-	Creator   string    `gorm:"column:creator;size:36;"`                        // 创建者
-	CreatedAt time.Time `gorm:"column:created_at;index;"`
-	UpdatedAt time.Time `gorm:"column:updated_at;index;"`
-	DeletedAt time.Time `gorm:"column:deleted_at;index;"`
+	TenantModel
+	ID        string         `gorm:"column:id;primaryKey;size:36;"`
+	Name      string         `gorm:"column:name;size:100;index;default:'';not nul;"` // 角色名称
+	Sequence  int            `gorm:"column:sequence;index;default:0;not null;"`      // 排序值
+	Memo      *string        `gorm:"column:memo;size:1024;"`                         // 备注
+	Status    int            `gorm:"column:status;index;default:0;not null;"`        // 状态(1:启用 2:禁用)
+	DataScope int            `gorm:"column:data_scope;default:1;not null;"`          // 数据权限范围
+	Creator   string         `gorm:"column:creator;size:36;"`                        // 创建者
+	CreatedAt time.Time      `gorm:"column:created_at;index;"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;index;"`
+	Version   int64          `gorm:"column:version;default:1;not null;"` // 版本号
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index;"`
 }
 
 // ToSchemaRole 转换角色对象
-func (a Role) ToSchemaRole() *schema.Role {
-	item := new(schema.Role)
-	structure.Copy(a, item)
-	return item
+func (a *Role) ToSchemaRole() *schema.Role {
+	return &schema.Role{
+		TenantID:  a.TenantID,
+		ID:        a.ID,
+		Name:      a.Name,
+		Sequence:  a.Sequence,
+		Memo:      stringValue(a.Memo),
+		Status:    a.Status,
+		DataScope: a.DataScope,
+		Creator:   a.Creator,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+		Version:   a.Version,
+	}
 }
 
 // Roles 角色实体列表
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// GetRoleDeptDB 角色部门
+func GetRoleDeptDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(RoleDept))
+}
+
+// ToRoleDept 转换为角色部门实体
+func ToRoleDept(a *schema.RoleDept) *RoleDept {
+	return &RoleDept{
+		ID:     a.ID,
+		RoleID: a.RoleID,
+		DeptID: a.DeptID,
+	}
+}
+
+// RoleDept 角色部门实体
+type RoleDept struct {
+	ID     string `gorm:"column:id;primaryKey;size:36;"`
+	RoleID string `gorm:"column:role_id;size:36;index;default:'';not null;"` // 角色ID
+	DeptID string `gorm:"column:dept_id;size:36;index;default:'';not null;"` // 部门ID
+}
+
+// ToSchemaRoleDept 转换为角色部门对象
+func (a *RoleDept) ToSchemaRoleDept() *schema.RoleDept {
+	return &schema.RoleDept{
+		ID:     a.ID,
+		RoleID: a.RoleID,
+		DeptID: a.DeptID,
+	}
+}
+
+// RoleDepts 角色部门列表
+type RoleDepts []*RoleDept
+
+// ToSchemaRoleDepts 转换为角色部门对象列表
+func (a RoleDepts) ToSchemaRoleDepts() []*schema.RoleDept {
+	list := make([]*schema.RoleDept, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaRoleDept()
+	}
+	return list
+}
@@ -3,7 +3,6 @@ package entity
 import (
 	"context"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/util/structure"
 	"gorm.io/gorm"
 )
 
@@ -12,14 +11,14 @@ func GetRoleMenuDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	return GetDBWithModel(ctx, defDB, new(RoleMenu))
 }
 
-// SchemaRoleMenu 角色菜单
-type SchemaRoleMenu schema.RoleMenu
-
 // ToRoleMenu 转换为角色菜单实体
-func (a SchemaRoleMenu) ToRoleMenu() *RoleMenu {
-	item := new(RoleMenu)
-	structure.Copy(a, item)
-	return item
+func ToRoleMenu(a *schema.RoleMenu) *RoleMenu {
+	return &RoleMenu{
+		ID:       a.ID,
+		RoleID:   a.RoleID,
+		MenuID:   a.MenuID,
+		ActionID: a.ActionID,
+	}
 }
 
 // RoleMenu 角色菜单实体
@@ -31,10 +30,13 @@ type RoleMenu struct {
 }
 
 // ToSchemaRoleMenu 转换为角色菜单对象
-func (a RoleMenu) ToSchemaRoleMenu() *schema.RoleMenu {
-	item := new(schema.RoleMenu)
-	structure.Copy(a, item)
-	return item
+func (a *RoleMenu) ToSchemaRoleMenu() *schema.RoleMenu {
+	return &schema.RoleMenu{
+		ID:       a.ID,
+		RoleID:   a.RoleID,
+		MenuID:   a.MenuID,
+		ActionID: a.ActionID,
+	}
 }
 
 // RoleMenus 角色菜单列表
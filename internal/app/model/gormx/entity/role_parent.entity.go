@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+)
+
+// GetRoleParentDB 角色继承关系
+func GetRoleParentDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(RoleParent))
+}
+
+// ToRoleParent 转换为角色继承关系实体
+func ToRoleParent(a *schema.RoleParent) *RoleParent {
+	return &RoleParent{
+		ID:       a.ID,
+		RoleID:   a.RoleID,
+		ParentID: a.ParentID,
+	}
+}
+
+// RoleParent 角色继承关系实体
+type RoleParent struct {
+	ID       string `gorm:"column:id;primaryKey;size:36;"`
+	RoleID   string `gorm:"column:role_id;size:36;index;default:'';not null;"`   // 角色ID
+	ParentID string `gorm:"column:parent_id;size:36;index;default:'';not null;"` // 父级角色ID
+}
+
+// ToSchemaRoleParent 转换为角色继承关系对象
+func (a *RoleParent) ToSchemaRoleParent() *schema.RoleParent {
+	return &schema.RoleParent{
+		ID:       a.ID,
+		RoleID:   a.RoleID,
+		ParentID: a.ParentID,
+	}
+}
+
+// RoleParents 角色继承关系列表
+type RoleParents []*RoleParent
+
+// ToSchemaRoleParents 转换为角色继承关系对象列表
+func (a RoleParents) ToSchemaRoleParents() []*schema.RoleParent {
+	list := make([]*schema.RoleParent, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaRoleParent()
+	}
+	return list
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetTenantDB 获取租户存储
+func GetTenantDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(Tenant))
+}
+
+// ToTenant 转换为租户实体
+func ToTenant(a *schema.Tenant) *Tenant {
+	return &Tenant{
+		ID:        a.ID,
+		Code:      a.Code,
+		Name:      a.Name,
+		Memo:      stringPtr(a.Memo),
+		Status:    a.Status,
+		Creator:   a.Creator,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+// Tenant 租户实体
+type Tenant struct {
+	ID        string         `gorm:"column:id;primaryKey;size:36;"`
+	Code      string         `gorm:"column:code;size:50;uniqueIndex;default:'';not null;"` // 租户编号
+	Name      string         `gorm:"column:name;size:100;index;default:'';not null;"`      // 租户名称
+	Memo      *string        `gorm:"column:memo;size:1024;"`                               // 备注
+	Status    int            `gorm:"column:status;index;default:0;not null;"`              // 状态(1:启用 2:停用)
+	Creator   string         `gorm:"column:creator;size:36;"`                              // 创建者
+	CreatedAt time.Time      `gorm:"column:created_at;index;"`
+	UpdatedAt time.Time      `gorm:"column:updated_at;index;"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index;"`
+}
+
+// ToSchemaTenant 转换为租户对象
+func (a *Tenant) ToSchemaTenant() *schema.Tenant {
+	return &schema.Tenant{
+		ID:        a.ID,
+		Code:      a.Code,
+		Name:      a.Name,
+		Memo:      stringValue(a.Memo),
+		Status:    a.Status,
+		Creator:   a.Creator,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+// Tenants 租户实体列表
+type Tenants []*Tenant
+
+// ToSchemaTenants 转换为租户对象列表
+func (a Tenants) ToSchemaTenants() []*schema.Tenant {
+	list := make([]*schema.Tenant, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaTenant()
+	}
+	return list
+}
@@ -3,8 +3,8 @@ package entity
 import (
 	"context"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/util/structure"
 	"gorm.io/gorm"
+	"time"
 )
 
 // GetUserDB 获取用户数据
@@ -12,36 +12,62 @@ func GetUserDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	return GetDBWithModel(ctx, defDB, new(User))
 }
 
-// SchemaUser 用户对象
-type SchemaUser schema.User
-
 // ToUser 转换为用户实体
-func (a SchemaUser) ToUser() *User {
-	item := new(User)
-	structure.Copy(a, item)
-	return item
+func ToUser(a *schema.User) *User {
+	return &User{
+		TenantModel:       TenantModel{TenantID: a.TenantID},
+		ID:                a.ID,
+		UserName:          a.UserName,
+		RealName:          a.RealName,
+		Password:          a.Password,
+		Email:             stringPtr(a.Email),
+		Phone:             stringPtr(a.Phone),
+		Status:            a.Status,
+		IsSuper:           a.IsSuper,
+		DeptID:            a.DeptID,
+		PasswordChangedAt: a.PasswordChangedAt,
+		Creator:           a.Creator,
+		Version:           a.Version,
+	}
 }
 
 // User 用户实体
 type User struct {
-	ID        string  `gorm:"column:id;primaryKey;size:36;"`
-	UserName  string  `gorm:"column:user_name;size:64;index;default:'';not null;"` // 用户名
-	RealName  string  `gorm:"column:real_name;size:64;index;default:'';not null;"` // 真实姓名
-	Password  string  `gorm:"column:password;size:40;default:'';not null;"`        // 密码(sha1(md5(明文))加密)
-	Email     *string `gorm:"column:email;size:255;index;"`                        // 邮箱
-	Phone     *string `gorm:"column:phone;size:20;index;"`                         // 手机号
-	Status    int     `gorm:"column:status;index;default:0;not null"`              // 状态(1:启用 2:停用)
-	Creator   string  `gorm:"column:creator;size:36;"`                             // 创建者
-	CreatedAt string  `gorm:"column:created_at;index;"`
-	UpdatedAt string  `gorm:"column:updated_at;index;"`
-	DeletedAt string  `gorm:"column:deleted_at;index;"`
+	TenantModel
+	ID                string         `gorm:"column:id;primaryKey;size:36;"`
+	UserName          string         `gorm:"column:user_name;size:64;index;default:'';not null;"` // 用户名
+	RealName          string         `gorm:"column:real_name;size:64;index;default:'';not null;"` // 真实姓名
+	Password          string         `gorm:"column:password;size:255;default:'';not null;"`       // 密码(带算法标识的哈希，例如：$argon2id$...)
+	Email             *string        `gorm:"column:email;size:255;index;"`                        // 邮箱
+	Phone             *string        `gorm:"column:phone;size:20;index;"`                         // 手机号
+	Status            int            `gorm:"column:status;index;default:0;not null"`              // 状态(1:启用 2:停用)
+	IsSuper           bool           `gorm:"column:is_super;index;default:false;not null;"`       // 是否超级管理员
+	DeptID            string         `gorm:"column:dept_id;size:36;index;default:'';not null;"`   // 所属部门ID
+	PasswordChangedAt *time.Time     `gorm:"column:password_changed_at;"`                         // 密码修改时间
+	Creator           string         `gorm:"column:creator;size:36;"`                             // 创建者
+	CreatedAt         string         `gorm:"column:created_at;index;"`
+	UpdatedAt         string         `gorm:"column:updated_at;index;"`
+	Version           int64          `gorm:"column:version;default:1;not null;"` // 版本号
+	DeletedAt         gorm.DeletedAt `gorm:"column:deleted_at;index;"`
 }
 
 // ToSchemaUser 转换为用户对象
-func (a User) ToSchemaUser() *schema.User {
-	item := new(schema.User)
-	structure.Copy(a, item)
-	return item
+func (a *User) ToSchemaUser() *schema.User {
+	return &schema.User{
+		TenantID:          a.TenantID,
+		ID:                a.ID,
+		UserName:          a.UserName,
+		RealName:          a.RealName,
+		Password:          a.Password,
+		Email:             stringValue(a.Email),
+		Phone:             stringValue(a.Phone),
+		Status:            a.Status,
+		IsSuper:           a.IsSuper,
+		DeptID:            a.DeptID,
+		PasswordChangedAt: a.PasswordChangedAt,
+		Creator:           a.Creator,
+		Version:           a.Version,
+	}
 }
 
 // Users 用户实体列表
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetUserIdentityDB 获取用户外部身份存储
+func GetUserIdentityDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(UserIdentity))
+}
+
+// ToUserIdentity 转换为用户外部身份实体
+func ToUserIdentity(a *schema.UserIdentity) *UserIdentity {
+	return &UserIdentity{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Provider:  a.Provider,
+		Subject:   a.Subject,
+		Email:     a.Email,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+// UserIdentity 用户外部身份实体
+type UserIdentity struct {
+	ID        string    `gorm:"column:id;primaryKey;size:36;"`
+	UserID    string    `gorm:"column:user_id;size:36;index;default:'';not null;"`                             // 用户内码
+	Provider  string    `gorm:"column:provider;size:64;uniqueIndex:idx_provider_subject;default:'';not null;"` // 身份提供者
+	Subject   string    `gorm:"column:subject;size:255;uniqueIndex:idx_provider_subject;default:'';not null;"` // 身份提供者中的用户唯一标识
+	Email     string    `gorm:"column:email;size:255;"`                                                        // 邮箱
+	CreatedAt time.Time `gorm:"column:created_at;index;"`
+	UpdatedAt time.Time `gorm:"column:updated_at;index;"`
+}
+
+// ToSchemaUserIdentity 转换为用户外部身份对象
+func (a *UserIdentity) ToSchemaUserIdentity() *schema.UserIdentity {
+	return &schema.UserIdentity{
+		ID:        a.ID,
+		UserID:    a.UserID,
+		Provider:  a.Provider,
+		Subject:   a.Subject,
+		Email:     a.Email,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
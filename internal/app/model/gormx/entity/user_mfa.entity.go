@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"context"
+	"ginAdmin/internal/app/schema"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GetUserMFADB 获取用户两步验证存储
+func GetUserMFADB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return GetDBWithModel(ctx, defDB, new(UserMFA))
+}
+
+// ToUserMFA 转换为用户两步验证实体
+func ToUserMFA(a *schema.UserMFA) *UserMFA {
+	return &UserMFA{
+		ID:            a.ID,
+		UserID:        a.UserID,
+		Secret:        a.Secret,
+		RecoveryCodes: a.RecoveryCodes,
+		LastStep:      a.LastStep,
+		Status:        a.Status,
+		CreatedAt:     a.CreatedAt,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
+
+// UserMFA 用户两步验证实体
+type UserMFA struct {
+	ID            string    `gorm:"column:id;primaryKey;size:36;"`
+	UserID        string    `gorm:"column:user_id;size:36;uniqueIndex;default:'';not null;"` // 用户内码
+	Secret        string    `gorm:"column:secret;size:64;default:'';not null;"`              // TOTP密钥
+	RecoveryCodes string    `gorm:"column:recovery_codes;size:1024;default:'';not null;"`    // 恢复码哈希(逗号分隔)
+	LastStep      int64     `gorm:"column:last_step;default:0;not null;"`                    // 最近一次使用的TOTP时间步
+	Status        int       `gorm:"column:status;index;default:0;not null;"`                 // 状态(1:已启用 2:待确认)
+	CreatedAt     time.Time `gorm:"column:created_at;index;"`
+	UpdatedAt     time.Time `gorm:"column:updated_at;index;"`
+}
+
+// ToSchemaUserMFA 转换为用户两步验证对象
+func (a *UserMFA) ToSchemaUserMFA() *schema.UserMFA {
+	return &schema.UserMFA{
+		ID:            a.ID,
+		UserID:        a.UserID,
+		Secret:        a.Secret,
+		RecoveryCodes: a.RecoveryCodes,
+		LastStep:      a.LastStep,
+		Status:        a.Status,
+		CreatedAt:     a.CreatedAt,
+		UpdatedAt:     a.UpdatedAt,
+	}
+}
@@ -3,8 +3,8 @@ package entity
 import (
 	"context"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/util/structure"
 	"gorm.io/gorm"
+	"time"
 )
 
 // GetUserRoleDB 获取用户角色关联储存
@@ -12,28 +12,35 @@ func GetUserRoleDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
 	return GetDBWithModel(ctx, defDB, new(UserRole))
 }
 
-// SchemaUserRole 用户角色
-type SchemaUserRole schema.UserRole
-
 // ToUserRole 转换为角色菜单实体
-func (a SchemaUserRole) ToUserRole() *UserRole {
-	item := new(UserRole)
-	structure.Copy(a, item)
-	return item
+func ToUserRole(a *schema.UserRole) *UserRole {
+	return &UserRole{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		RoleID:     a.RoleID,
+		ValidFrom:  a.ValidFrom,
+		ValidUntil: a.ValidUntil,
+	}
 }
 
 // UserRole 用户角色关联实体
 type UserRole struct {
-	ID     string `gorm:"column:id;primaryKey;size:36;"`
-	UserID string `gorm:"column:user_id;size:36;index;default:'';not null;"` // 用户内码
-	RoleID string `gorm:"column:role_id;size:36;index;default:'';not null;"` // 角色内码
+	ID         string     `gorm:"column:id;primaryKey;size:36;"`
+	UserID     string     `gorm:"column:user_id;size:36;index;default:'';not null;"` // 用户内码
+	RoleID     string     `gorm:"column:role_id;size:36;index;default:'';not null;"` // 角色内码
+	ValidFrom  *time.Time `gorm:"column:valid_from;index;"`                          // 生效时间
+	ValidUntil *time.Time `gorm:"column:valid_until;index;"`                         // 失效时间
 }
 
 // ToSchemaUserRole 转换为用户角色对象
-func (a UserRole) ToSchemaUserRole() *schema.UserRole {
-	item := new(schema.UserRole)
-	structure.Copy(a, item)
-	return item
+func (a *UserRole) ToSchemaUserRole() *schema.UserRole {
+	return &schema.UserRole{
+		ID:         a.ID,
+		UserID:     a.UserID,
+		RoleID:     a.RoleID,
+		ValidFrom:  a.ValidFrom,
+		ValidUntil: a.ValidUntil,
+	}
 }
 
 // UserRoles 用户角色关联列表
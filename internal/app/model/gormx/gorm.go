@@ -1,15 +1,12 @@
 package gormx
 
 import (
-	"ginAdmin/internal/app/config"
-	"ginAdmin/internal/app/model/gormx/entity"
 	"ginAdmin/pkg/logger"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
-	"strings"
 	"time"
 )
 
@@ -82,21 +79,3 @@ func NewDB(c *Config) (*gorm.DB, func(), error) {
 	sqlDB.SetConnMaxLifetime(time.Duration(c.MaxLifetime) * time.Second)
 	return gormDB, cleanFunc, nil
 }
-
-// AutoMigrate 自动映射数据表
-func AutoMigrate(db *gorm.DB) error {
-	if dbType := config.C.Gorm.DBType; strings.ToLower(dbType) == "mysql" {
-		db = db.Set("gorm:table_options", "ENGINE=InnoDB")
-	}
-
-	return db.AutoMigrate(
-		new(entity.Demo),
-		new(entity.MenuAction),
-		new(entity.MenuActionResource),
-		new(entity.Menu),
-		new(entity.RoleMenu),
-		new(entity.Role),
-		new(entity.UserRole),
-		new(entity.User),
-	)
-}
@@ -0,0 +1,46 @@
+// Package gormxtest 提供基于sqlite的测试数据库(执行与正式环境相同的迁移文件)
+package gormxtest
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+	"path/filepath"
+	"testing"
+)
+
+// NewDB 在临时目录中创建sqlite数据库并执行全部迁移
+func NewDB(t testing.TB) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sqlite不支持并发写入，测试中只使用一个连接(未释放的连接会使测试阻塞)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	m, err := gormx.NewMigrator(db, "sqlite3", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := gormx.CheckSchema(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
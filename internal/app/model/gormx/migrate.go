@@ -0,0 +1,90 @@
+package gormx
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/pkg/migrate"
+	"gorm.io/gorm"
+	"path"
+	"time"
+)
+
+// 迁移文件按数据库类型存放(mysql/postgres/sqlite3)，表名前缀使用${prefix}变量
+//
+//go:embed migrations
+var migrationFS embed.FS
+
+// 与迁移文件对应的实体(用于启动时检查数据表结构)
+var models = []interface{}{
+	new(entity.Demo),
+	new(entity.Dept),
+	new(entity.MenuAction),
+	new(entity.MenuActionResource),
+	new(entity.Menu),
+	new(entity.RoleMenu),
+	new(entity.RoleDept),
+	new(entity.RoleParent),
+	new(entity.Role),
+	new(entity.UserRole),
+	new(entity.User),
+	new(entity.UserMFA),
+	new(entity.PasswordReset),
+	new(entity.PasswordHistory),
+	new(entity.UserIdentity),
+	new(entity.APIKey),
+	new(entity.Tenant),
+	new(entity.AuditLog),
+}
+
+// NewMigrator 创建数据库迁移实例
+func NewMigrator(db *gorm.DB, dbType, tablePrefix string) (*migrate.Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := migrate.Load(migrationFS, path.Join("migrations", dbType))
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.New(sqlDB, migrations, migrate.Config{
+		Dialect:     dbType,
+		Table:       tablePrefix + "schema_migration",
+		Vars:        map[string]string{"prefix": tablePrefix},
+		LockTimeout: time.Minute,
+		LockExpire:  time.Minute * 10,
+		// 旧版本通过AutoMigrate创建数据表，未执行过迁移但已存在用户表即为旧版本数据库
+		DetectLegacy: func(ctx context.Context) (bool, error) {
+			return db.WithContext(ctx).Migrator().HasTable(new(entity.User)), nil
+		},
+	}), nil
+}
+
+// CheckSchema 检查实体对应的数据表及字段是否存在
+func CheckSchema(db *gorm.DB) error {
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		err := stmt.Parse(model)
+		if err != nil {
+			return err
+		}
+
+		if !db.Migrator().HasTable(model) {
+			return fmt.Errorf("数据表%s不存在", stmt.Table)
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+
+			if !db.Migrator().HasColumn(model, field.DBName) {
+				return fmt.Errorf("数据表%s缺少字段%s", stmt.Table, field.DBName)
+			}
+		}
+	}
+	return nil
+}
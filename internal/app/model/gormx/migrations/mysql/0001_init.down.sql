@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS `${prefix}tenant`;
+DROP TABLE IF EXISTS `${prefix}api_key`;
+DROP TABLE IF EXISTS `${prefix}user_identity`;
+DROP TABLE IF EXISTS `${prefix}password_history`;
+DROP TABLE IF EXISTS `${prefix}password_reset`;
+DROP TABLE IF EXISTS `${prefix}user_mfa`;
+DROP TABLE IF EXISTS `${prefix}user`;
+DROP TABLE IF EXISTS `${prefix}user_role`;
+DROP TABLE IF EXISTS `${prefix}role`;
+DROP TABLE IF EXISTS `${prefix}role_parent`;
+DROP TABLE IF EXISTS `${prefix}role_dept`;
+DROP TABLE IF EXISTS `${prefix}role_menu`;
+DROP TABLE IF EXISTS `${prefix}menu`;
+DROP TABLE IF EXISTS `${prefix}menu_action_resource`;
+DROP TABLE IF EXISTS `${prefix}menu_action`;
+DROP TABLE IF EXISTS `${prefix}dept`;
+DROP TABLE IF EXISTS `${prefix}demo`;
@@ -0,0 +1,172 @@
+-- 旧版本(通过AutoMigrate创建数据表，未执行过迁移)数据库升级到0001_init
+-- 旧版本的数据均归属default租户；旧版本为物理删除，现存记录均未删除
+
+CREATE TABLE `${prefix}dept` (
+  `tenant_id` varchar(36) NOT NULL DEFAULT 'default',
+  `id` varchar(36),
+  `name` varchar(50) NOT NULL DEFAULT '',
+  `sequence` bigint NOT NULL DEFAULT 0,
+  `parent_id` varchar(36),
+  `parent_path` varchar(518),
+  `status` bigint NOT NULL DEFAULT 0,
+  `memo` varchar(1024),
+  `creator` varchar(36),
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}dept_created_at (`created_at`),
+  INDEX idx_${prefix}dept_deleted_at (`deleted_at`),
+  INDEX idx_${prefix}dept_name (`name`),
+  INDEX idx_${prefix}dept_parent_id (`parent_id`),
+  INDEX idx_${prefix}dept_parent_path (`parent_path`),
+  INDEX idx_${prefix}dept_sequence (`sequence`),
+  INDEX idx_${prefix}dept_status (`status`),
+  INDEX idx_${prefix}dept_tenant_id (`tenant_id`),
+  INDEX idx_${prefix}dept_updated_at (`updated_at`)
+) ENGINE=InnoDB;
+
+CREATE TABLE `${prefix}role_dept` (
+  `id` varchar(36),
+  `role_id` varchar(36) NOT NULL DEFAULT '',
+  `dept_id` varchar(36) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}role_dept_dept_id (`dept_id`),
+  INDEX idx_${prefix}role_dept_role_id (`role_id`)
+) ENGINE=InnoDB;
+
+CREATE TABLE `${prefix}role_parent` (
+  `id` varchar(36),
+  `role_id` varchar(36) NOT NULL DEFAULT '',
+  `parent_id` varchar(36) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}role_parent_parent_id (`parent_id`),
+  INDEX idx_${prefix}role_parent_role_id (`role_id`)
+) ENGINE=InnoDB;
+
+CREATE TABLE `${prefix}user_mfa` (
+  `id` varchar(36),
+  `user_id` varchar(36) NOT NULL DEFAULT '',
+  `secret` varchar(64) NOT NULL DEFAULT '',
+  `recovery_codes` varchar(1024) NOT NULL DEFAULT '',
+  `last_step` bigint NOT NULL DEFAULT 0,
+  `status` bigint NOT NULL DEFAULT 0,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}user_mfa_created_at (`created_at`),
+  INDEX idx_${prefix}user_mfa_status (`status`),
+  INDEX idx_${prefix}user_mfa_updated_at (`updated_at`),
+  UNIQUE INDEX idx_${prefix}user_mfa_user_id (`user_id`)
+) ENGINE=InnoDB;
+
+CREATE TABLE `${prefix}password_reset` (
+  `id` varchar(36),
+  `user_id` varchar(36) NOT NULL DEFAULT '',
+  `token_hash` varchar(64) NOT NULL DEFAULT '',
+  `expires_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}password_reset_created_at (`created_at`),
+  INDEX idx_${prefix}password_reset_expires_at (`expires_at`),
+  UNIQUE INDEX idx_${prefix}password_reset_token_hash (`token_hash`),
+  INDEX idx_${prefix}password_reset_user_id (`user_id`)
+) ENGINE=InnoDB;
+
+CREATE TABLE `${prefix}password_history` (
+  `id` varchar(36),
+  `user_id` varchar(36) NOT NULL DEFAULT '',
+  `password` varchar(255) NOT NULL DEFAULT '',
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}password_history_created_at (`created_at`),
+  INDEX idx_${prefix}password_history_user_id (`user_id`)
+) ENGINE=InnoDB;
+
+CREATE TABLE `${prefix}user_identity` (
+  `id` varchar(36),
+  `user_id` varchar(36) NOT NULL DEFAULT '',
+  `provider` varchar(64) NOT NULL DEFAULT '',
+  `subject` varchar(255) NOT NULL DEFAULT '',
+  `email` varchar(255),
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}user_identity_created_at (`created_at`),
+  INDEX idx_${prefix}user_identity_updated_at (`updated_at`),
+  INDEX idx_${prefix}user_identity_user_id (`user_id`),
+  UNIQUE INDEX idx_provider_subject (`provider`,`subject`)
+) ENGINE=InnoDB;
+
+CREATE TABLE `${prefix}api_key` (
+  `id` varchar(36),
+  `user_id` varchar(36) NOT NULL DEFAULT '',
+  `name` varchar(64) NOT NULL DEFAULT '',
+  `prefix` varchar(32) NOT NULL DEFAULT '',
+  `token_hash` varchar(64) NOT NULL DEFAULT '',
+  `scopes` text,
+  `expires_at` datetime(3) NULL,
+  `last_used_at` datetime(3) NULL,
+  `creator` varchar(36),
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}api_key_created_at (`created_at`),
+  INDEX idx_${prefix}api_key_expires_at (`expires_at`),
+  UNIQUE INDEX idx_${prefix}api_key_prefix (`prefix`),
+  INDEX idx_${prefix}api_key_user_id (`user_id`)
+) ENGINE=InnoDB;
+
+CREATE TABLE `${prefix}tenant` (
+  `id` varchar(36),
+  `code` varchar(50) NOT NULL DEFAULT '',
+  `name` varchar(100) NOT NULL DEFAULT '',
+  `memo` varchar(1024),
+  `status` bigint NOT NULL DEFAULT 0,
+  `creator` varchar(36),
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX idx_${prefix}tenant_code (`code`),
+  INDEX idx_${prefix}tenant_created_at (`created_at`),
+  INDEX idx_${prefix}tenant_deleted_at (`deleted_at`),
+  INDEX idx_${prefix}tenant_name (`name`),
+  INDEX idx_${prefix}tenant_status (`status`),
+  INDEX idx_${prefix}tenant_updated_at (`updated_at`)
+) ENGINE=InnoDB;
+
+ALTER TABLE `${prefix}demo`
+  ADD COLUMN `tenant_id` varchar(36) NOT NULL DEFAULT 'default' FIRST,
+  ADD INDEX idx_${prefix}demo_tenant_id (`tenant_id`);
+
+ALTER TABLE `${prefix}menu`
+  ADD COLUMN `tenant_id` varchar(36) NOT NULL DEFAULT 'default' FIRST,
+  ADD INDEX idx_${prefix}menu_tenant_id (`tenant_id`);
+
+ALTER TABLE `${prefix}role`
+  ADD COLUMN `tenant_id` varchar(36) NOT NULL DEFAULT 'default' FIRST,
+  ADD COLUMN `data_scope` bigint NOT NULL DEFAULT 1 AFTER `status`,
+  ADD INDEX idx_${prefix}role_tenant_id (`tenant_id`);
+-- 旧版本的删除时间写入的是零值
+UPDATE `${prefix}role` SET `deleted_at`=NULL;
+
+ALTER TABLE `${prefix}user_role`
+  ADD COLUMN `valid_from` datetime(3) NULL,
+  ADD COLUMN `valid_until` datetime(3) NULL,
+  ADD INDEX idx_${prefix}user_role_valid_from (`valid_from`),
+  ADD INDEX idx_${prefix}user_role_valid_until (`valid_until`);
+
+-- 旧版本用户表的删除时间为字符串类型
+UPDATE `${prefix}user` SET `deleted_at`=NULL;
+ALTER TABLE `${prefix}user`
+  ADD COLUMN `tenant_id` varchar(36) NOT NULL DEFAULT 'default' FIRST,
+  ADD COLUMN `is_super` boolean NOT NULL DEFAULT false AFTER `status`,
+  ADD COLUMN `dept_id` varchar(36) NOT NULL DEFAULT '' AFTER `is_super`,
+  ADD COLUMN `password_changed_at` datetime(3) NULL AFTER `dept_id`,
+  MODIFY COLUMN `password` varchar(255) NOT NULL DEFAULT '',
+  MODIFY COLUMN `created_at` varchar(256),
+  MODIFY COLUMN `updated_at` varchar(256),
+  MODIFY COLUMN `deleted_at` datetime(3) NULL,
+  ADD INDEX idx_${prefix}user_dept_id (`dept_id`),
+  ADD INDEX idx_${prefix}user_is_super (`is_super`),
+  ADD INDEX idx_${prefix}user_tenant_id (`tenant_id`);
@@ -0,0 +1,4 @@
+ALTER TABLE `${prefix}demo` DROP COLUMN `version`;
+ALTER TABLE `${prefix}menu` DROP COLUMN `version`;
+ALTER TABLE `${prefix}role` DROP COLUMN `version`;
+ALTER TABLE `${prefix}user` DROP COLUMN `version`;
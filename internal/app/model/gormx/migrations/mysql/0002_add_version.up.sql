@@ -0,0 +1,4 @@
+ALTER TABLE `${prefix}demo` ADD COLUMN `version` bigint NOT NULL DEFAULT 1;
+ALTER TABLE `${prefix}menu` ADD COLUMN `version` bigint NOT NULL DEFAULT 1;
+ALTER TABLE `${prefix}role` ADD COLUMN `version` bigint NOT NULL DEFAULT 1;
+ALTER TABLE `${prefix}user` ADD COLUMN `version` bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,17 @@
+CREATE TABLE `${prefix}audit_log` (
+  `tenant_id` varchar(36) NOT NULL DEFAULT 'default',
+  `id` varchar(36),
+  `user_id` varchar(36) NOT NULL DEFAULT '',
+  `actor_id` varchar(36) NOT NULL DEFAULT '',
+  `trace_id` varchar(64) NOT NULL DEFAULT '',
+  `table_name` varchar(64) NOT NULL DEFAULT '',
+  `record_id` varchar(128) NOT NULL DEFAULT '',
+  `action` varchar(16) NOT NULL DEFAULT '',
+  `changes` text,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX idx_${prefix}audit_log_tenant_id (`tenant_id`),
+  INDEX idx_${prefix}audit_log_actor_id (`actor_id`),
+  INDEX idx_${prefix}audit_log_created_at (`created_at`),
+  INDEX idx_audit_log_record (`table_name`,`record_id`)
+) ENGINE=InnoDB;
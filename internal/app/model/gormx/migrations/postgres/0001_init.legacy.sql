@@ -0,0 +1,169 @@
+-- 旧版本(通过AutoMigrate创建数据表，未执行过迁移)数据库升级到0001_init
+-- 旧版本的数据均归属default租户；旧版本为物理删除，现存记录均未删除
+
+CREATE TABLE "${prefix}dept" (
+  "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  "id" varchar(36),
+  "name" varchar(50) NOT NULL DEFAULT '',
+  "sequence" bigint NOT NULL DEFAULT 0,
+  "parent_id" varchar(36),
+  "parent_path" varchar(518),
+  "status" bigint NOT NULL DEFAULT 0,
+  "memo" varchar(1024),
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}dept_created_at" ON "${prefix}dept" ("created_at");
+CREATE INDEX "idx_${prefix}dept_deleted_at" ON "${prefix}dept" ("deleted_at");
+CREATE INDEX "idx_${prefix}dept_name" ON "${prefix}dept" ("name");
+CREATE INDEX "idx_${prefix}dept_parent_id" ON "${prefix}dept" ("parent_id");
+CREATE INDEX "idx_${prefix}dept_parent_path" ON "${prefix}dept" ("parent_path");
+CREATE INDEX "idx_${prefix}dept_sequence" ON "${prefix}dept" ("sequence");
+CREATE INDEX "idx_${prefix}dept_status" ON "${prefix}dept" ("status");
+CREATE INDEX "idx_${prefix}dept_tenant_id" ON "${prefix}dept" ("tenant_id");
+CREATE INDEX "idx_${prefix}dept_updated_at" ON "${prefix}dept" ("updated_at");
+
+CREATE TABLE "${prefix}role_dept" (
+  "id" varchar(36),
+  "role_id" varchar(36) NOT NULL DEFAULT '',
+  "dept_id" varchar(36) NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}role_dept_dept_id" ON "${prefix}role_dept" ("dept_id");
+CREATE INDEX "idx_${prefix}role_dept_role_id" ON "${prefix}role_dept" ("role_id");
+
+CREATE TABLE "${prefix}role_parent" (
+  "id" varchar(36),
+  "role_id" varchar(36) NOT NULL DEFAULT '',
+  "parent_id" varchar(36) NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}role_parent_parent_id" ON "${prefix}role_parent" ("parent_id");
+CREATE INDEX "idx_${prefix}role_parent_role_id" ON "${prefix}role_parent" ("role_id");
+
+CREATE TABLE "${prefix}user_mfa" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "secret" varchar(64) NOT NULL DEFAULT '',
+  "recovery_codes" varchar(1024) NOT NULL DEFAULT '',
+  "last_step" bigint NOT NULL DEFAULT 0,
+  "status" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}user_mfa_created_at" ON "${prefix}user_mfa" ("created_at");
+CREATE INDEX "idx_${prefix}user_mfa_status" ON "${prefix}user_mfa" ("status");
+CREATE INDEX "idx_${prefix}user_mfa_updated_at" ON "${prefix}user_mfa" ("updated_at");
+CREATE UNIQUE INDEX "idx_${prefix}user_mfa_user_id" ON "${prefix}user_mfa" ("user_id");
+
+CREATE TABLE "${prefix}password_reset" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "token_hash" varchar(64) NOT NULL DEFAULT '',
+  "expires_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}password_reset_created_at" ON "${prefix}password_reset" ("created_at");
+CREATE INDEX "idx_${prefix}password_reset_expires_at" ON "${prefix}password_reset" ("expires_at");
+CREATE UNIQUE INDEX "idx_${prefix}password_reset_token_hash" ON "${prefix}password_reset" ("token_hash");
+CREATE INDEX "idx_${prefix}password_reset_user_id" ON "${prefix}password_reset" ("user_id");
+
+CREATE TABLE "${prefix}password_history" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "password" varchar(255) NOT NULL DEFAULT '',
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}password_history_created_at" ON "${prefix}password_history" ("created_at");
+CREATE INDEX "idx_${prefix}password_history_user_id" ON "${prefix}password_history" ("user_id");
+
+CREATE TABLE "${prefix}user_identity" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "provider" varchar(64) NOT NULL DEFAULT '',
+  "subject" varchar(255) NOT NULL DEFAULT '',
+  "email" varchar(255),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}user_identity_created_at" ON "${prefix}user_identity" ("created_at");
+CREATE INDEX "idx_${prefix}user_identity_updated_at" ON "${prefix}user_identity" ("updated_at");
+CREATE INDEX "idx_${prefix}user_identity_user_id" ON "${prefix}user_identity" ("user_id");
+CREATE UNIQUE INDEX "idx_provider_subject" ON "${prefix}user_identity" ("provider","subject");
+
+CREATE TABLE "${prefix}api_key" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "name" varchar(64) NOT NULL DEFAULT '',
+  "prefix" varchar(32) NOT NULL DEFAULT '',
+  "token_hash" varchar(64) NOT NULL DEFAULT '',
+  "scopes" text,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}api_key_created_at" ON "${prefix}api_key" ("created_at");
+CREATE INDEX "idx_${prefix}api_key_expires_at" ON "${prefix}api_key" ("expires_at");
+CREATE UNIQUE INDEX "idx_${prefix}api_key_prefix" ON "${prefix}api_key" ("prefix");
+CREATE INDEX "idx_${prefix}api_key_user_id" ON "${prefix}api_key" ("user_id");
+
+CREATE TABLE "${prefix}tenant" (
+  "id" varchar(36),
+  "code" varchar(50) NOT NULL DEFAULT '',
+  "name" varchar(100) NOT NULL DEFAULT '',
+  "memo" varchar(1024),
+  "status" bigint NOT NULL DEFAULT 0,
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_${prefix}tenant_code" ON "${prefix}tenant" ("code");
+CREATE INDEX "idx_${prefix}tenant_created_at" ON "${prefix}tenant" ("created_at");
+CREATE INDEX "idx_${prefix}tenant_deleted_at" ON "${prefix}tenant" ("deleted_at");
+CREATE INDEX "idx_${prefix}tenant_name" ON "${prefix}tenant" ("name");
+CREATE INDEX "idx_${prefix}tenant_status" ON "${prefix}tenant" ("status");
+CREATE INDEX "idx_${prefix}tenant_updated_at" ON "${prefix}tenant" ("updated_at");
+
+ALTER TABLE "${prefix}demo" ADD COLUMN "tenant_id" varchar(36) NOT NULL DEFAULT 'default';
+CREATE INDEX "idx_${prefix}demo_tenant_id" ON "${prefix}demo" ("tenant_id");
+
+ALTER TABLE "${prefix}menu" ADD COLUMN "tenant_id" varchar(36) NOT NULL DEFAULT 'default';
+CREATE INDEX "idx_${prefix}menu_tenant_id" ON "${prefix}menu" ("tenant_id");
+
+ALTER TABLE "${prefix}role"
+  ADD COLUMN "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  ADD COLUMN "data_scope" bigint NOT NULL DEFAULT 1;
+CREATE INDEX "idx_${prefix}role_tenant_id" ON "${prefix}role" ("tenant_id");
+-- 旧版本的删除时间写入的是零值
+UPDATE "${prefix}role" SET "deleted_at"=NULL;
+
+ALTER TABLE "${prefix}user_role"
+  ADD COLUMN "valid_from" timestamptz,
+  ADD COLUMN "valid_until" timestamptz;
+CREATE INDEX "idx_${prefix}user_role_valid_from" ON "${prefix}user_role" ("valid_from");
+CREATE INDEX "idx_${prefix}user_role_valid_until" ON "${prefix}user_role" ("valid_until");
+
+-- 旧版本用户表的删除时间为字符串类型
+ALTER TABLE "${prefix}user"
+  ADD COLUMN "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  ADD COLUMN "is_super" boolean NOT NULL DEFAULT false,
+  ADD COLUMN "dept_id" varchar(36) NOT NULL DEFAULT '',
+  ADD COLUMN "password_changed_at" timestamptz,
+  ALTER COLUMN "password" TYPE varchar(255),
+  ALTER COLUMN "created_at" TYPE text,
+  ALTER COLUMN "updated_at" TYPE text,
+  ALTER COLUMN "deleted_at" TYPE timestamptz USING NULL;
+CREATE INDEX "idx_${prefix}user_dept_id" ON "${prefix}user" ("dept_id");
+CREATE INDEX "idx_${prefix}user_is_super" ON "${prefix}user" ("is_super");
+CREATE INDEX "idx_${prefix}user_tenant_id" ON "${prefix}user" ("tenant_id");
@@ -0,0 +1,276 @@
+CREATE TABLE "${prefix}demo" (
+  "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  "id" varchar(36),
+  "code" varchar(50) NOT NULL DEFAULT '',
+  "name" varchar(100) NOT NULL DEFAULT '',
+  "memo" varchar(200),
+  "status" bigint NOT NULL DEFAULT 0,
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}demo_code" ON "${prefix}demo" ("code");
+CREATE INDEX "idx_${prefix}demo_created_at" ON "${prefix}demo" ("created_at");
+CREATE INDEX "idx_${prefix}demo_deleted_at" ON "${prefix}demo" ("deleted_at");
+CREATE INDEX "idx_${prefix}demo_name" ON "${prefix}demo" ("name");
+CREATE INDEX "idx_${prefix}demo_status" ON "${prefix}demo" ("status");
+CREATE INDEX "idx_${prefix}demo_tenant_id" ON "${prefix}demo" ("tenant_id");
+CREATE INDEX "idx_${prefix}demo_updated_at" ON "${prefix}demo" ("updated_at");
+
+CREATE TABLE "${prefix}dept" (
+  "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  "id" varchar(36),
+  "name" varchar(50) NOT NULL DEFAULT '',
+  "sequence" bigint NOT NULL DEFAULT 0,
+  "parent_id" varchar(36),
+  "parent_path" varchar(518),
+  "status" bigint NOT NULL DEFAULT 0,
+  "memo" varchar(1024),
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}dept_created_at" ON "${prefix}dept" ("created_at");
+CREATE INDEX "idx_${prefix}dept_deleted_at" ON "${prefix}dept" ("deleted_at");
+CREATE INDEX "idx_${prefix}dept_name" ON "${prefix}dept" ("name");
+CREATE INDEX "idx_${prefix}dept_parent_id" ON "${prefix}dept" ("parent_id");
+CREATE INDEX "idx_${prefix}dept_parent_path" ON "${prefix}dept" ("parent_path");
+CREATE INDEX "idx_${prefix}dept_sequence" ON "${prefix}dept" ("sequence");
+CREATE INDEX "idx_${prefix}dept_status" ON "${prefix}dept" ("status");
+CREATE INDEX "idx_${prefix}dept_tenant_id" ON "${prefix}dept" ("tenant_id");
+CREATE INDEX "idx_${prefix}dept_updated_at" ON "${prefix}dept" ("updated_at");
+
+CREATE TABLE "${prefix}menu_action" (
+  "id" varchar(36),
+  "menu_id" varchar(36) NOT NULL DEFAULT '0',
+  "code" varchar(100) NOT NULL DEFAULT '',
+  "name" varchar(100) NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}menu_action_menu_id" ON "${prefix}menu_action" ("menu_id");
+
+CREATE TABLE "${prefix}menu_action_resource" (
+  "id" varchar(36),
+  "action_id" varchar(36) NOT NULL DEFAULT '',
+  "method" varchar(100) NOT NULL DEFAULT '',
+  "path" varchar(100) NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}menu_action_resource_action_id" ON "${prefix}menu_action_resource" ("action_id");
+
+CREATE TABLE "${prefix}menu" (
+  "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  "id" varchar(36),
+  "name" varchar(50) NOT NULL DEFAULT '',
+  "sequence" bigint NOT NULL DEFAULT 0,
+  "icon" varchar(255),
+  "router" varchar(255),
+  "parent_id" varchar(36),
+  "parent_path" varchar(518),
+  "show_status" bigint NOT NULL DEFAULT 0,
+  "status" bigint NOT NULL DEFAULT 0,
+  "memo" varchar(1024),
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}menu_created_at" ON "${prefix}menu" ("created_at");
+CREATE INDEX "idx_${prefix}menu_deleted_at" ON "${prefix}menu" ("deleted_at");
+CREATE INDEX "idx_${prefix}menu_name" ON "${prefix}menu" ("name");
+CREATE INDEX "idx_${prefix}menu_parent_id" ON "${prefix}menu" ("parent_id");
+CREATE INDEX "idx_${prefix}menu_parent_path" ON "${prefix}menu" ("parent_path");
+CREATE INDEX "idx_${prefix}menu_sequence" ON "${prefix}menu" ("sequence");
+CREATE INDEX "idx_${prefix}menu_show_status" ON "${prefix}menu" ("show_status");
+CREATE INDEX "idx_${prefix}menu_status" ON "${prefix}menu" ("status");
+CREATE INDEX "idx_${prefix}menu_tenant_id" ON "${prefix}menu" ("tenant_id");
+CREATE INDEX "idx_${prefix}menu_updated_at" ON "${prefix}menu" ("updated_at");
+
+CREATE TABLE "${prefix}role_menu" (
+  "id" varchar(36),
+  "role_id" varchar(36) NOT NULL DEFAULT '',
+  "menu_id" varchar(36) NOT NULL DEFAULT '',
+  "action_id" varchar(36) NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}role_menu_menu_id" ON "${prefix}role_menu" ("menu_id");
+CREATE INDEX "idx_${prefix}role_menu_role_id" ON "${prefix}role_menu" ("role_id");
+
+CREATE TABLE "${prefix}role_dept" (
+  "id" varchar(36),
+  "role_id" varchar(36) NOT NULL DEFAULT '',
+  "dept_id" varchar(36) NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}role_dept_dept_id" ON "${prefix}role_dept" ("dept_id");
+CREATE INDEX "idx_${prefix}role_dept_role_id" ON "${prefix}role_dept" ("role_id");
+
+CREATE TABLE "${prefix}role_parent" (
+  "id" varchar(36),
+  "role_id" varchar(36) NOT NULL DEFAULT '',
+  "parent_id" varchar(36) NOT NULL DEFAULT '',
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}role_parent_parent_id" ON "${prefix}role_parent" ("parent_id");
+CREATE INDEX "idx_${prefix}role_parent_role_id" ON "${prefix}role_parent" ("role_id");
+
+CREATE TABLE "${prefix}role" (
+  "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  "id" varchar(36),
+  "name" varchar(100) DEFAULT '',
+  "sequence" bigint NOT NULL DEFAULT 0,
+  "memo" varchar(1024),
+  "status" bigint NOT NULL DEFAULT 0,
+  "data_scope" bigint NOT NULL DEFAULT 1,
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}role_created_at" ON "${prefix}role" ("created_at");
+CREATE INDEX "idx_${prefix}role_deleted_at" ON "${prefix}role" ("deleted_at");
+CREATE INDEX "idx_${prefix}role_name" ON "${prefix}role" ("name");
+CREATE INDEX "idx_${prefix}role_sequence" ON "${prefix}role" ("sequence");
+CREATE INDEX "idx_${prefix}role_status" ON "${prefix}role" ("status");
+CREATE INDEX "idx_${prefix}role_tenant_id" ON "${prefix}role" ("tenant_id");
+CREATE INDEX "idx_${prefix}role_updated_at" ON "${prefix}role" ("updated_at");
+
+CREATE TABLE "${prefix}user_role" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "role_id" varchar(36) NOT NULL DEFAULT '',
+  "valid_from" timestamptz,
+  "valid_until" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}user_role_role_id" ON "${prefix}user_role" ("role_id");
+CREATE INDEX "idx_${prefix}user_role_user_id" ON "${prefix}user_role" ("user_id");
+CREATE INDEX "idx_${prefix}user_role_valid_from" ON "${prefix}user_role" ("valid_from");
+CREATE INDEX "idx_${prefix}user_role_valid_until" ON "${prefix}user_role" ("valid_until");
+
+CREATE TABLE "${prefix}user" (
+  "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  "id" varchar(36),
+  "user_name" varchar(64) NOT NULL DEFAULT '',
+  "real_name" varchar(64) NOT NULL DEFAULT '',
+  "password" varchar(255) NOT NULL DEFAULT '',
+  "email" varchar(255),
+  "phone" varchar(20),
+  "status" bigint NOT NULL DEFAULT 0,
+  "is_super" boolean NOT NULL DEFAULT false,
+  "dept_id" varchar(36) NOT NULL DEFAULT '',
+  "password_changed_at" timestamptz,
+  "creator" varchar(36),
+  "created_at" text,
+  "updated_at" text,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}user_created_at" ON "${prefix}user" ("created_at");
+CREATE INDEX "idx_${prefix}user_deleted_at" ON "${prefix}user" ("deleted_at");
+CREATE INDEX "idx_${prefix}user_dept_id" ON "${prefix}user" ("dept_id");
+CREATE INDEX "idx_${prefix}user_email" ON "${prefix}user" ("email");
+CREATE INDEX "idx_${prefix}user_is_super" ON "${prefix}user" ("is_super");
+CREATE INDEX "idx_${prefix}user_phone" ON "${prefix}user" ("phone");
+CREATE INDEX "idx_${prefix}user_real_name" ON "${prefix}user" ("real_name");
+CREATE INDEX "idx_${prefix}user_status" ON "${prefix}user" ("status");
+CREATE INDEX "idx_${prefix}user_tenant_id" ON "${prefix}user" ("tenant_id");
+CREATE INDEX "idx_${prefix}user_updated_at" ON "${prefix}user" ("updated_at");
+CREATE INDEX "idx_${prefix}user_user_name" ON "${prefix}user" ("user_name");
+
+CREATE TABLE "${prefix}user_mfa" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "secret" varchar(64) NOT NULL DEFAULT '',
+  "recovery_codes" varchar(1024) NOT NULL DEFAULT '',
+  "last_step" bigint NOT NULL DEFAULT 0,
+  "status" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}user_mfa_created_at" ON "${prefix}user_mfa" ("created_at");
+CREATE INDEX "idx_${prefix}user_mfa_status" ON "${prefix}user_mfa" ("status");
+CREATE INDEX "idx_${prefix}user_mfa_updated_at" ON "${prefix}user_mfa" ("updated_at");
+CREATE UNIQUE INDEX "idx_${prefix}user_mfa_user_id" ON "${prefix}user_mfa" ("user_id");
+
+CREATE TABLE "${prefix}password_reset" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "token_hash" varchar(64) NOT NULL DEFAULT '',
+  "expires_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}password_reset_created_at" ON "${prefix}password_reset" ("created_at");
+CREATE INDEX "idx_${prefix}password_reset_expires_at" ON "${prefix}password_reset" ("expires_at");
+CREATE UNIQUE INDEX "idx_${prefix}password_reset_token_hash" ON "${prefix}password_reset" ("token_hash");
+CREATE INDEX "idx_${prefix}password_reset_user_id" ON "${prefix}password_reset" ("user_id");
+
+CREATE TABLE "${prefix}password_history" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "password" varchar(255) NOT NULL DEFAULT '',
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}password_history_created_at" ON "${prefix}password_history" ("created_at");
+CREATE INDEX "idx_${prefix}password_history_user_id" ON "${prefix}password_history" ("user_id");
+
+CREATE TABLE "${prefix}user_identity" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "provider" varchar(64) NOT NULL DEFAULT '',
+  "subject" varchar(255) NOT NULL DEFAULT '',
+  "email" varchar(255),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}user_identity_created_at" ON "${prefix}user_identity" ("created_at");
+CREATE INDEX "idx_${prefix}user_identity_updated_at" ON "${prefix}user_identity" ("updated_at");
+CREATE INDEX "idx_${prefix}user_identity_user_id" ON "${prefix}user_identity" ("user_id");
+CREATE UNIQUE INDEX "idx_provider_subject" ON "${prefix}user_identity" ("provider","subject");
+
+CREATE TABLE "${prefix}api_key" (
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "name" varchar(64) NOT NULL DEFAULT '',
+  "prefix" varchar(32) NOT NULL DEFAULT '',
+  "token_hash" varchar(64) NOT NULL DEFAULT '',
+  "scopes" text,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}api_key_created_at" ON "${prefix}api_key" ("created_at");
+CREATE INDEX "idx_${prefix}api_key_expires_at" ON "${prefix}api_key" ("expires_at");
+CREATE UNIQUE INDEX "idx_${prefix}api_key_prefix" ON "${prefix}api_key" ("prefix");
+CREATE INDEX "idx_${prefix}api_key_user_id" ON "${prefix}api_key" ("user_id");
+
+CREATE TABLE "${prefix}tenant" (
+  "id" varchar(36),
+  "code" varchar(50) NOT NULL DEFAULT '',
+  "name" varchar(100) NOT NULL DEFAULT '',
+  "memo" varchar(1024),
+  "status" bigint NOT NULL DEFAULT 0,
+  "creator" varchar(36),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_${prefix}tenant_code" ON "${prefix}tenant" ("code");
+CREATE INDEX "idx_${prefix}tenant_created_at" ON "${prefix}tenant" ("created_at");
+CREATE INDEX "idx_${prefix}tenant_deleted_at" ON "${prefix}tenant" ("deleted_at");
+CREATE INDEX "idx_${prefix}tenant_name" ON "${prefix}tenant" ("name");
+CREATE INDEX "idx_${prefix}tenant_status" ON "${prefix}tenant" ("status");
+CREATE INDEX "idx_${prefix}tenant_updated_at" ON "${prefix}tenant" ("updated_at");
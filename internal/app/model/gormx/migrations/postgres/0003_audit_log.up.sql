@@ -0,0 +1,17 @@
+CREATE TABLE "${prefix}audit_log" (
+  "tenant_id" varchar(36) NOT NULL DEFAULT 'default',
+  "id" varchar(36),
+  "user_id" varchar(36) NOT NULL DEFAULT '',
+  "actor_id" varchar(36) NOT NULL DEFAULT '',
+  "trace_id" varchar(64) NOT NULL DEFAULT '',
+  "table_name" varchar(64) NOT NULL DEFAULT '',
+  "record_id" varchar(128) NOT NULL DEFAULT '',
+  "action" varchar(16) NOT NULL DEFAULT '',
+  "changes" text,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_${prefix}audit_log_tenant_id" ON "${prefix}audit_log" ("tenant_id");
+CREATE INDEX "idx_${prefix}audit_log_actor_id" ON "${prefix}audit_log" ("actor_id");
+CREATE INDEX "idx_${prefix}audit_log_created_at" ON "${prefix}audit_log" ("created_at");
+CREATE INDEX "idx_audit_log_record" ON "${prefix}audit_log" ("table_name","record_id");
@@ -0,0 +1,188 @@
+-- 旧版本(通过AutoMigrate创建数据表，未执行过迁移)数据库升级到0001_init
+-- 旧版本的数据均归属default租户；旧版本为物理删除，现存记录均未删除
+
+CREATE TABLE `${prefix}dept` (
+  `tenant_id` text NOT NULL DEFAULT 'default',
+  `id` text,
+  `name` text NOT NULL DEFAULT '',
+  `sequence` integer NOT NULL DEFAULT 0,
+  `parent_id` text,
+  `parent_path` text,
+  `status` integer NOT NULL DEFAULT 0,
+  `memo` text,
+  `creator` text,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}dept_created_at` ON `${prefix}dept` (`created_at`);
+CREATE INDEX `idx_${prefix}dept_deleted_at` ON `${prefix}dept` (`deleted_at`);
+CREATE INDEX `idx_${prefix}dept_name` ON `${prefix}dept` (`name`);
+CREATE INDEX `idx_${prefix}dept_parent_id` ON `${prefix}dept` (`parent_id`);
+CREATE INDEX `idx_${prefix}dept_parent_path` ON `${prefix}dept` (`parent_path`);
+CREATE INDEX `idx_${prefix}dept_sequence` ON `${prefix}dept` (`sequence`);
+CREATE INDEX `idx_${prefix}dept_status` ON `${prefix}dept` (`status`);
+CREATE INDEX `idx_${prefix}dept_tenant_id` ON `${prefix}dept` (`tenant_id`);
+CREATE INDEX `idx_${prefix}dept_updated_at` ON `${prefix}dept` (`updated_at`);
+
+CREATE TABLE `${prefix}role_dept` (
+  `id` text,
+  `role_id` text NOT NULL DEFAULT '',
+  `dept_id` text NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}role_dept_dept_id` ON `${prefix}role_dept` (`dept_id`);
+CREATE INDEX `idx_${prefix}role_dept_role_id` ON `${prefix}role_dept` (`role_id`);
+
+CREATE TABLE `${prefix}role_parent` (
+  `id` text,
+  `role_id` text NOT NULL DEFAULT '',
+  `parent_id` text NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}role_parent_parent_id` ON `${prefix}role_parent` (`parent_id`);
+CREATE INDEX `idx_${prefix}role_parent_role_id` ON `${prefix}role_parent` (`role_id`);
+
+CREATE TABLE `${prefix}user_mfa` (
+  `id` text,
+  `user_id` text NOT NULL DEFAULT '',
+  `secret` text NOT NULL DEFAULT '',
+  `recovery_codes` text NOT NULL DEFAULT '',
+  `last_step` integer NOT NULL DEFAULT 0,
+  `status` integer NOT NULL DEFAULT 0,
+  `created_at` datetime,
+  `updated_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}user_mfa_created_at` ON `${prefix}user_mfa` (`created_at`);
+CREATE INDEX `idx_${prefix}user_mfa_status` ON `${prefix}user_mfa` (`status`);
+CREATE INDEX `idx_${prefix}user_mfa_updated_at` ON `${prefix}user_mfa` (`updated_at`);
+CREATE UNIQUE INDEX `idx_${prefix}user_mfa_user_id` ON `${prefix}user_mfa` (`user_id`);
+
+CREATE TABLE `${prefix}password_reset` (
+  `id` text,
+  `user_id` text NOT NULL DEFAULT '',
+  `token_hash` text NOT NULL DEFAULT '',
+  `expires_at` datetime,
+  `created_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}password_reset_created_at` ON `${prefix}password_reset` (`created_at`);
+CREATE INDEX `idx_${prefix}password_reset_expires_at` ON `${prefix}password_reset` (`expires_at`);
+CREATE UNIQUE INDEX `idx_${prefix}password_reset_token_hash` ON `${prefix}password_reset` (`token_hash`);
+CREATE INDEX `idx_${prefix}password_reset_user_id` ON `${prefix}password_reset` (`user_id`);
+
+CREATE TABLE `${prefix}password_history` (
+  `id` text,
+  `user_id` text NOT NULL DEFAULT '',
+  `password` text NOT NULL DEFAULT '',
+  `created_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}password_history_created_at` ON `${prefix}password_history` (`created_at`);
+CREATE INDEX `idx_${prefix}password_history_user_id` ON `${prefix}password_history` (`user_id`);
+
+CREATE TABLE `${prefix}user_identity` (
+  `id` text,
+  `user_id` text NOT NULL DEFAULT '',
+  `provider` text NOT NULL DEFAULT '',
+  `subject` text NOT NULL DEFAULT '',
+  `email` text,
+  `created_at` datetime,
+  `updated_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}user_identity_created_at` ON `${prefix}user_identity` (`created_at`);
+CREATE INDEX `idx_${prefix}user_identity_updated_at` ON `${prefix}user_identity` (`updated_at`);
+CREATE INDEX `idx_${prefix}user_identity_user_id` ON `${prefix}user_identity` (`user_id`);
+CREATE UNIQUE INDEX `idx_provider_subject` ON `${prefix}user_identity` (`provider`,`subject`);
+
+CREATE TABLE `${prefix}api_key` (
+  `id` text,
+  `user_id` text NOT NULL DEFAULT '',
+  `name` text NOT NULL DEFAULT '',
+  `prefix` text NOT NULL DEFAULT '',
+  `token_hash` text NOT NULL DEFAULT '',
+  `scopes` text,
+  `expires_at` datetime,
+  `last_used_at` datetime,
+  `creator` text,
+  `created_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}api_key_created_at` ON `${prefix}api_key` (`created_at`);
+CREATE INDEX `idx_${prefix}api_key_expires_at` ON `${prefix}api_key` (`expires_at`);
+CREATE UNIQUE INDEX `idx_${prefix}api_key_prefix` ON `${prefix}api_key` (`prefix`);
+CREATE INDEX `idx_${prefix}api_key_user_id` ON `${prefix}api_key` (`user_id`);
+
+CREATE TABLE `${prefix}tenant` (
+  `id` text,
+  `code` text NOT NULL DEFAULT '',
+  `name` text NOT NULL DEFAULT '',
+  `memo` text,
+  `status` integer NOT NULL DEFAULT 0,
+  `creator` text,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE UNIQUE INDEX `idx_${prefix}tenant_code` ON `${prefix}tenant` (`code`);
+CREATE INDEX `idx_${prefix}tenant_created_at` ON `${prefix}tenant` (`created_at`);
+CREATE INDEX `idx_${prefix}tenant_deleted_at` ON `${prefix}tenant` (`deleted_at`);
+CREATE INDEX `idx_${prefix}tenant_name` ON `${prefix}tenant` (`name`);
+CREATE INDEX `idx_${prefix}tenant_status` ON `${prefix}tenant` (`status`);
+CREATE INDEX `idx_${prefix}tenant_updated_at` ON `${prefix}tenant` (`updated_at`);
+
+ALTER TABLE `${prefix}demo` ADD COLUMN `tenant_id` text NOT NULL DEFAULT 'default';
+CREATE INDEX `idx_${prefix}demo_tenant_id` ON `${prefix}demo` (`tenant_id`);
+
+ALTER TABLE `${prefix}menu` ADD COLUMN `tenant_id` text NOT NULL DEFAULT 'default';
+CREATE INDEX `idx_${prefix}menu_tenant_id` ON `${prefix}menu` (`tenant_id`);
+
+ALTER TABLE `${prefix}role` ADD COLUMN `tenant_id` text NOT NULL DEFAULT 'default';
+ALTER TABLE `${prefix}role` ADD COLUMN `data_scope` integer NOT NULL DEFAULT 1;
+CREATE INDEX `idx_${prefix}role_tenant_id` ON `${prefix}role` (`tenant_id`);
+-- 旧版本的删除时间写入的是零值
+UPDATE `${prefix}role` SET `deleted_at`=NULL;
+
+ALTER TABLE `${prefix}user_role` ADD COLUMN `valid_from` datetime;
+ALTER TABLE `${prefix}user_role` ADD COLUMN `valid_until` datetime;
+CREATE INDEX `idx_${prefix}user_role_valid_from` ON `${prefix}user_role` (`valid_from`);
+CREATE INDEX `idx_${prefix}user_role_valid_until` ON `${prefix}user_role` (`valid_until`);
+
+-- 旧版本用户表的删除时间为字符串类型(sqlite不支持修改字段类型，重建数据表)
+CREATE TABLE `${prefix}user_upgrade` (
+  `tenant_id` text NOT NULL DEFAULT 'default',
+  `id` text,
+  `user_name` text NOT NULL DEFAULT '',
+  `real_name` text NOT NULL DEFAULT '',
+  `password` text NOT NULL DEFAULT '',
+  `email` text,
+  `phone` text,
+  `status` integer NOT NULL DEFAULT 0,
+  `is_super` numeric NOT NULL DEFAULT false,
+  `dept_id` text NOT NULL DEFAULT '',
+  `password_changed_at` datetime,
+  `creator` text,
+  `created_at` text,
+  `updated_at` text,
+  `deleted_at` datetime,
+  PRIMARY KEY (`id`)
+);
+INSERT INTO `${prefix}user_upgrade` (id, user_name, real_name, password, email, phone, status, creator, created_at, updated_at)
+  SELECT id, user_name, real_name, password, email, phone, status, creator, created_at, updated_at FROM `${prefix}user`;
+DROP TABLE `${prefix}user`;
+ALTER TABLE `${prefix}user_upgrade` RENAME TO `${prefix}user`;
+CREATE INDEX `idx_${prefix}user_created_at` ON `${prefix}user` (`created_at`);
+CREATE INDEX `idx_${prefix}user_deleted_at` ON `${prefix}user` (`deleted_at`);
+CREATE INDEX `idx_${prefix}user_dept_id` ON `${prefix}user` (`dept_id`);
+CREATE INDEX `idx_${prefix}user_email` ON `${prefix}user` (`email`);
+CREATE INDEX `idx_${prefix}user_is_super` ON `${prefix}user` (`is_super`);
+CREATE INDEX `idx_${prefix}user_phone` ON `${prefix}user` (`phone`);
+CREATE INDEX `idx_${prefix}user_real_name` ON `${prefix}user` (`real_name`);
+CREATE INDEX `idx_${prefix}user_status` ON `${prefix}user` (`status`);
+CREATE INDEX `idx_${prefix}user_tenant_id` ON `${prefix}user` (`tenant_id`);
+CREATE INDEX `idx_${prefix}user_updated_at` ON `${prefix}user` (`updated_at`);
+CREATE INDEX `idx_${prefix}user_user_name` ON `${prefix}user` (`user_name`);
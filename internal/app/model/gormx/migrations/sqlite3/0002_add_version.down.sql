@@ -0,0 +1,118 @@
+-- sqlite不支持删除字段，通过重建数据表回滚
+CREATE TABLE `${prefix}demo_tmp` (
+  `tenant_id` text NOT NULL DEFAULT 'default',
+  `id` text,
+  `code` text NOT NULL DEFAULT '',
+  `name` text NOT NULL DEFAULT '',
+  `memo` text,
+  `status` integer NOT NULL DEFAULT 0,
+  `creator` text,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  PRIMARY KEY (`id`)
+);
+INSERT INTO `${prefix}demo_tmp` (`tenant_id`, `id`, `code`, `name`, `memo`, `status`, `creator`, `created_at`, `updated_at`, `deleted_at`)
+  SELECT `tenant_id`, `id`, `code`, `name`, `memo`, `status`, `creator`, `created_at`, `updated_at`, `deleted_at` FROM `${prefix}demo`;
+DROP TABLE `${prefix}demo`;
+ALTER TABLE `${prefix}demo_tmp` RENAME TO `${prefix}demo`;
+CREATE INDEX `idx_${prefix}demo_code` ON `${prefix}demo` (`code`);
+CREATE INDEX `idx_${prefix}demo_created_at` ON `${prefix}demo` (`created_at`);
+CREATE INDEX `idx_${prefix}demo_deleted_at` ON `${prefix}demo` (`deleted_at`);
+CREATE INDEX `idx_${prefix}demo_name` ON `${prefix}demo` (`name`);
+CREATE INDEX `idx_${prefix}demo_status` ON `${prefix}demo` (`status`);
+CREATE INDEX `idx_${prefix}demo_tenant_id` ON `${prefix}demo` (`tenant_id`);
+CREATE INDEX `idx_${prefix}demo_updated_at` ON `${prefix}demo` (`updated_at`);
+
+CREATE TABLE `${prefix}menu_tmp` (
+  `tenant_id` text NOT NULL DEFAULT 'default',
+  `id` text,
+  `name` text NOT NULL DEFAULT '',
+  `sequence` integer NOT NULL DEFAULT 0,
+  `icon` text,
+  `router` text,
+  `parent_id` text,
+  `parent_path` text,
+  `show_status` integer NOT NULL DEFAULT 0,
+  `status` integer NOT NULL DEFAULT 0,
+  `memo` text,
+  `creator` text,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  PRIMARY KEY (`id`)
+);
+INSERT INTO `${prefix}menu_tmp` (`tenant_id`, `id`, `name`, `sequence`, `icon`, `router`, `parent_id`, `parent_path`, `show_status`, `status`, `memo`, `creator`, `created_at`, `updated_at`, `deleted_at`)
+  SELECT `tenant_id`, `id`, `name`, `sequence`, `icon`, `router`, `parent_id`, `parent_path`, `show_status`, `status`, `memo`, `creator`, `created_at`, `updated_at`, `deleted_at` FROM `${prefix}menu`;
+DROP TABLE `${prefix}menu`;
+ALTER TABLE `${prefix}menu_tmp` RENAME TO `${prefix}menu`;
+CREATE INDEX `idx_${prefix}menu_created_at` ON `${prefix}menu` (`created_at`);
+CREATE INDEX `idx_${prefix}menu_deleted_at` ON `${prefix}menu` (`deleted_at`);
+CREATE INDEX `idx_${prefix}menu_name` ON `${prefix}menu` (`name`);
+CREATE INDEX `idx_${prefix}menu_parent_id` ON `${prefix}menu` (`parent_id`);
+CREATE INDEX `idx_${prefix}menu_parent_path` ON `${prefix}menu` (`parent_path`);
+CREATE INDEX `idx_${prefix}menu_sequence` ON `${prefix}menu` (`sequence`);
+CREATE INDEX `idx_${prefix}menu_show_status` ON `${prefix}menu` (`show_status`);
+CREATE INDEX `idx_${prefix}menu_status` ON `${prefix}menu` (`status`);
+CREATE INDEX `idx_${prefix}menu_tenant_id` ON `${prefix}menu` (`tenant_id`);
+CREATE INDEX `idx_${prefix}menu_updated_at` ON `${prefix}menu` (`updated_at`);
+
+CREATE TABLE `${prefix}role_tmp` (
+  `tenant_id` text NOT NULL DEFAULT 'default',
+  `id` text,
+  `name` text DEFAULT '',
+  `sequence` integer NOT NULL DEFAULT 0,
+  `memo` text,
+  `status` integer NOT NULL DEFAULT 0,
+  `data_scope` integer NOT NULL DEFAULT 1,
+  `creator` text,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime,
+  PRIMARY KEY (`id`)
+);
+INSERT INTO `${prefix}role_tmp` (`tenant_id`, `id`, `name`, `sequence`, `memo`, `status`, `data_scope`, `creator`, `created_at`, `updated_at`, `deleted_at`)
+  SELECT `tenant_id`, `id`, `name`, `sequence`, `memo`, `status`, `data_scope`, `creator`, `created_at`, `updated_at`, `deleted_at` FROM `${prefix}role`;
+DROP TABLE `${prefix}role`;
+ALTER TABLE `${prefix}role_tmp` RENAME TO `${prefix}role`;
+CREATE INDEX `idx_${prefix}role_created_at` ON `${prefix}role` (`created_at`);
+CREATE INDEX `idx_${prefix}role_deleted_at` ON `${prefix}role` (`deleted_at`);
+CREATE INDEX `idx_${prefix}role_name` ON `${prefix}role` (`name`);
+CREATE INDEX `idx_${prefix}role_sequence` ON `${prefix}role` (`sequence`);
+CREATE INDEX `idx_${prefix}role_status` ON `${prefix}role` (`status`);
+CREATE INDEX `idx_${prefix}role_tenant_id` ON `${prefix}role` (`tenant_id`);
+CREATE INDEX `idx_${prefix}role_updated_at` ON `${prefix}role` (`updated_at`);
+
+CREATE TABLE `${prefix}user_tmp` (
+  `tenant_id` text NOT NULL DEFAULT 'default',
+  `id` text,
+  `user_name` text NOT NULL DEFAULT '',
+  `real_name` text NOT NULL DEFAULT '',
+  `password` text NOT NULL DEFAULT '',
+  `email` text,
+  `phone` text,
+  `status` integer NOT NULL DEFAULT 0,
+  `is_super` numeric NOT NULL DEFAULT false,
+  `dept_id` text NOT NULL DEFAULT '',
+  `password_changed_at` datetime,
+  `creator` text,
+  `created_at` text,
+  `updated_at` text,
+  `deleted_at` datetime,
+  PRIMARY KEY (`id`)
+);
+INSERT INTO `${prefix}user_tmp` (`tenant_id`, `id`, `user_name`, `real_name`, `password`, `email`, `phone`, `status`, `is_super`, `dept_id`, `password_changed_at`, `creator`, `created_at`, `updated_at`, `deleted_at`)
+  SELECT `tenant_id`, `id`, `user_name`, `real_name`, `password`, `email`, `phone`, `status`, `is_super`, `dept_id`, `password_changed_at`, `creator`, `created_at`, `updated_at`, `deleted_at` FROM `${prefix}user`;
+DROP TABLE `${prefix}user`;
+ALTER TABLE `${prefix}user_tmp` RENAME TO `${prefix}user`;
+CREATE INDEX `idx_${prefix}user_created_at` ON `${prefix}user` (`created_at`);
+CREATE INDEX `idx_${prefix}user_deleted_at` ON `${prefix}user` (`deleted_at`);
+CREATE INDEX `idx_${prefix}user_dept_id` ON `${prefix}user` (`dept_id`);
+CREATE INDEX `idx_${prefix}user_email` ON `${prefix}user` (`email`);
+CREATE INDEX `idx_${prefix}user_is_super` ON `${prefix}user` (`is_super`);
+CREATE INDEX `idx_${prefix}user_phone` ON `${prefix}user` (`phone`);
+CREATE INDEX `idx_${prefix}user_real_name` ON `${prefix}user` (`real_name`);
+CREATE INDEX `idx_${prefix}user_status` ON `${prefix}user` (`status`);
+CREATE INDEX `idx_${prefix}user_tenant_id` ON `${prefix}user` (`tenant_id`);
+CREATE INDEX `idx_${prefix}user_updated_at` ON `${prefix}user` (`updated_at`);
+CREATE INDEX `idx_${prefix}user_user_name` ON `${prefix}user` (`user_name`);
@@ -0,0 +1,4 @@
+ALTER TABLE `${prefix}demo` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `${prefix}menu` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `${prefix}role` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
+ALTER TABLE `${prefix}user` ADD COLUMN `version` integer NOT NULL DEFAULT 1;
@@ -0,0 +1,17 @@
+CREATE TABLE `${prefix}audit_log` (
+  `tenant_id` text NOT NULL DEFAULT 'default',
+  `id` text,
+  `user_id` text NOT NULL DEFAULT '',
+  `actor_id` text NOT NULL DEFAULT '',
+  `trace_id` text NOT NULL DEFAULT '',
+  `table_name` text NOT NULL DEFAULT '',
+  `record_id` text NOT NULL DEFAULT '',
+  `action` text NOT NULL DEFAULT '',
+  `changes` text,
+  `created_at` datetime,
+  PRIMARY KEY (`id`)
+);
+CREATE INDEX `idx_${prefix}audit_log_tenant_id` ON `${prefix}audit_log` (`tenant_id`);
+CREATE INDEX `idx_${prefix}audit_log_actor_id` ON `${prefix}audit_log` (`actor_id`);
+CREATE INDEX `idx_${prefix}audit_log_created_at` ON `${prefix}audit_log` (`created_at`);
+CREATE INDEX `idx_audit_log_record` ON `${prefix}audit_log` (`table_name`,`record_id`);
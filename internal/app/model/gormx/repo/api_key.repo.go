@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+	"time"
+)
+
+// APIKeySet 注入APIKey
+var APIKeySet = wire.NewSet(wire.Struct(new(APIKey), "*"))
+
+// APIKey API密钥存储
+type APIKey struct {
+	DB *gorm.DB
+}
+
+// QueryByUserID 查询用户的API密钥(按创建时间倒序)
+func (a *APIKey) QueryByUserID(ctx context.Context, userID string) (schema.APIKeys, error) {
+	var list entity.APIKeys
+	result := entity.GetAPIKeyDB(ctx, a.DB).Where("user_id=?", userID).Order("created_at DESC").Find(&list)
+	if err := result.Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return list.ToSchemaAPIKeys(), nil
+}
+
+// Get 查询指定数据
+func (a *APIKey) Get(ctx context.Context, id string) (*schema.APIKey, error) {
+	var item entity.APIKey
+	ok, err := FindOne(ctx, entity.GetAPIKeyDB(ctx, a.DB).Where("id=?", id), &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaAPIKey(), nil
+}
+
+// GetByPrefix 根据可见前缀查询数据
+func (a *APIKey) GetByPrefix(ctx context.Context, prefix string) (*schema.APIKey, error) {
+	var item entity.APIKey
+	ok, err := FindOne(ctx, entity.GetAPIKeyDB(ctx, a.DB).Where("prefix=?", prefix), &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaAPIKey(), nil
+}
+
+// Create 创建数据
+func (a *APIKey) Create(ctx context.Context, item schema.APIKey) error {
+	eitem := entity.ToAPIKey(&item)
+	result := entity.GetAPIKeyDB(ctx, a.DB).Create(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// UpdateLastUsedAt 更新最后使用时间
+func (a *APIKey) UpdateLastUsedAt(ctx context.Context, id string, lastUsedAt time.Time) error {
+	result := entity.GetAPIKeyDB(ctx, a.DB).Where("id=?", id).Update("last_used_at", lastUsedAt)
+	return errors.WithStack(result.Error)
+}
+
+// Delete 删除数据
+func (a *APIKey) Delete(ctx context.Context, id string) error {
+	result := entity.GetAPIKeyDB(ctx, a.DB).Where("id=?", id).Delete(entity.APIKey{})
+	return errors.WithStack(result.Error)
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *APIKey) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetAPIKeyDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.APIKey{})
+	return errors.WithStack(result.Error)
+}
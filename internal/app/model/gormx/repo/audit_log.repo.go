@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// AuditLogSet 注入AuditLog
+var AuditLogSet = wire.NewSet(wire.Struct(new(AuditLog), "*"))
+
+// AuditLog 数据变更审计存储(变更记录由gorm回调写入)
+type AuditLog struct {
+	DB *gorm.DB
+}
+
+func (a *AuditLog) getQueryOption(opts ...schema.AuditLogQueryOptions) schema.AuditLogQueryOptions {
+	var opt schema.AuditLogQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *AuditLog) Query(ctx context.Context, params schema.AuditLogQueryParam, opts ...schema.AuditLogQueryOptions) (*schema.AuditLogQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetAuditLogDB(ctx, a.DB)
+	if v := params.Table; v != "" {
+		db = db.Where("table_name=?", v)
+	}
+	if v := params.RecordID; v != "" {
+		db = db.Where("record_id=?", v)
+	}
+	if v := params.ActorID; v != "" {
+		db = db.Where("actor_id=?", v)
+	}
+	if v := params.Action; v != "" {
+		db = db.Where("action=?", v)
+	}
+	if v := params.StartTime; !v.IsZero() {
+		db = db.Where("created_at>=?", v)
+	}
+	if v := params.EndTime; !v.IsZero() {
+		db = db.Where("created_at<=?", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("created_at", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.AuditLogs
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	qr := &schema.AuditLogQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaAuditLogs(),
+	}
+	return qr, nil
+}
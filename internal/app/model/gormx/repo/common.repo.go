@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
 	"ginAdmin/internal/app/schema"
 	"gorm.io/gorm"
 	"strings"
+	"time"
 )
 
 // TransFunc 定义事务执行函数
@@ -52,6 +54,83 @@ func WrapPageQuery(ctx context.Context, db *gorm.DB, pp schema.PaginationParam,
 	}, nil
 }
 
+// WrapDataScope 根据上下文中的数据权限范围过滤数据
+// deptColumn为数据所属部门字段，为空时按创建者所在的部门过滤；creatorColumn及ownColumns为本人数据的判断字段
+func WrapDataScope(ctx context.Context, defDB, db *gorm.DB, deptColumn, creatorColumn string, ownColumns ...string) (*gorm.DB, error) {
+	scope, err := contextx.FromDataScope(ctx)
+	if err != nil {
+		return nil, err
+	} else if scope == nil || scope.All {
+		return db, nil
+	}
+
+	var conds []string
+	var args []interface{}
+	for _, column := range append([]string{creatorColumn}, ownColumns...) {
+		conds = append(conds, column+"=?")
+		args = append(args, scope.UserID)
+	}
+
+	if len(scope.DeptIDs) > 0 {
+		if deptColumn != "" {
+			conds = append(conds, deptColumn+" IN (?)")
+			args = append(args, scope.DeptIDs)
+		} else {
+			subQuery := entity.GetUserDB(ctx, defDB).
+				Select("id").
+				Where("dept_id IN (?)", scope.DeptIDs)
+			conds = append(conds, creatorColumn+" IN (?)")
+			args = append(args, subQuery)
+		}
+	}
+
+	return db.Where("("+strings.Join(conds, " OR ")+")", args...), nil
+}
+
+// WrapRecycleQuery 查询已删除(软删除)的数据，nameColumn为作为名称显示的字段
+func WrapRecycleQuery(ctx context.Context, db *gorm.DB, nameColumn string, params schema.RecycleQueryParam) (*schema.RecycleQueryResult, error) {
+	db = db.Unscoped().Where("deleted_at IS NOT NULL")
+	if v := params.QueryValue; v != "" {
+		db = db.Where(nameColumn+" LIKE ?", "%"+v+"%")
+	}
+	db = db.Select("id", nameColumn+" AS name", "deleted_at").Order("deleted_at DESC")
+
+	var list []*schema.RecycleItem
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.RecycleQueryResult{
+		PageResult: pr,
+		Data:       list,
+	}, nil
+}
+
+// FindDeleted 查询单条已删除的数据
+func FindDeleted(ctx context.Context, db *gorm.DB, id string, out interface{}) (bool, error) {
+	return FindOne(ctx, db.Unscoped().Where("id=? AND deleted_at IS NOT NULL", id), out)
+}
+
+// RestoreDeleted 恢复已删除的数据
+func RestoreDeleted(ctx context.Context, db *gorm.DB, id string) error {
+	return db.Unscoped().Where("id=? AND deleted_at IS NOT NULL", id).UpdateColumn("deleted_at", nil).Error
+}
+
+// FindDeletedIDs 查询删除时间早于指定时间的数据ID
+func FindDeletedIDs(ctx context.Context, db *gorm.DB, before time.Time) ([]string, error) {
+	var ids []string
+	err := db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at<?", before).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// PurgeDeleted 彻底删除已删除的数据(未删除的数据不受影响)
+func PurgeDeleted(ctx context.Context, db *gorm.DB, ids []string, model interface{}) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Unscoped().Where("id IN (?) AND deleted_at IS NOT NULL", ids).Delete(model).Error
+}
+
 // FindPage 查询分页数据
 func FindPage(ctx context.Context, db *gorm.DB, pp schema.PaginationParam, out interface{}) (int64, error) {
 	var count int64
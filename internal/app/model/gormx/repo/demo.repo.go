@@ -4,17 +4,28 @@ import (
 	"context"
 	"ginAdmin/internal/app/model/gormx/entity"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/errors"
 	"github.com/google/wire"
 	"gorm.io/gorm"
 )
 
 // DemoSet 注入Demo
-var DemoSet = wire.NewSet(wire.Struct(new(Demo), "*"))
+var DemoSet = wire.NewSet(NewDemo)
+
+// NewDemo 创建示例储存
+func NewDemo(db *gorm.DB) *Demo {
+	return &Demo{
+		Repository: Repository[entity.Demo, schema.Demo]{
+			DB:         db,
+			Mapper:     Mapper[entity.Demo, schema.Demo]{ToEntity: entity.ToDemo, ToSchema: (*entity.Demo).ToSchemaDemo},
+			NameColumn: "name",
+			DataScope:  &DataScopeColumns{Creator: "creator"},
+		},
+	}
+}
 
 // Demo 示例储存
 type Demo struct {
-	DB *gorm.DB
+	Repository[entity.Demo, schema.Demo]
 }
 
 func (a *Demo) getQueryOption(opts ...schema.DemoQueryOptions) schema.DemoQueryOptions {
@@ -29,7 +40,7 @@ func (a *Demo) getQueryOption(opts ...schema.DemoQueryOptions) schema.DemoQueryO
 func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...schema.DemoQueryOptions) (*schema.DemoQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	db := entity.GetDemoDB(ctx, a.DB)
+	db := a.GetDB(ctx)
 	if v := params.Code; v != "" {
 		db = db.Where("code=?", v)
 	}
@@ -37,58 +48,21 @@ func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...
 		v = "%" + v + "%"
 	}
 
-	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
-	db = db.Order(ParseOrder(opt.OrderFields))
-
-	var list entity.Demos
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	list, pr, err := a.QueryPage(ctx, db, params.PaginationParam, opt.OrderFields)
 	if err != nil {
 		return nil, err
 	}
 	qr := &schema.DemoQueryResult{
 		PageResult: pr,
-		Data:       list.ToSchemaDemos(),
+		Data:       list,
 	}
 
 	return qr, nil
 }
 
-// Get 查询指定数据
-func (a *Demo) Get(ctx context.Context, id string, opts ...schema.DemoQueryOptions) (*schema.Demo, error) {
-	db := entity.GetDemoDB(ctx, a.DB).Where("id=?", id)
-	var item entity.Demo
-	ok, err := FindOne(ctx, db, &item)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	} else if !ok {
-		return nil, nil
-	}
-
-	return item.ToSchemaDemo(), nil
-}
-
-// Create 创建数据
-func (a *Demo) Create(ctx context.Context, item schema.Demo) error {
-	eitem := entity.SchemaDemo(item).ToDemo()
-	result := entity.GetDemoDB(ctx, a.DB).Create(eitem)
-	return errors.WithStack(result.Error)
-}
-
-// Update 更新数据
+// Update 更新数据(版本号与item.Version一致时才更新，同时递增版本号，否则返回ErrPreconditionFailed)
 func (a *Demo) Update(ctx context.Context, id string, item schema.Demo) error {
-	eitem := entity.SchemaDemo(item).ToDemo()
-	result := entity.GetDemoDB(ctx, a.DB).Where("id=?", id).Updates(eitem)
-	return errors.WithStack(result.Error)
-}
-
-// Delete 删除数据
-func (a *Demo) Delete(ctx context.Context, id string) error {
-	result := entity.GetDemoDB(ctx, a.DB).Where("id", id).Delete(entity.Demo{})
-	return errors.WithStack(result.Error)
-}
-
-// UpdateStatus 更新状态
-func (a *Demo) UpdateStatus(ctx context.Context, id string, status int) error {
-	result := entity.GetDemoDB(ctx, a.DB).Where("id=?", id).Update("status", status)
-	return errors.WithStack(result.Error)
+	eitem := entity.ToDemo(&item)
+	eitem.Version = item.Version + 1
+	return a.UpdateVersion(ctx, id, item.Version, eitem)
 }
@@ -0,0 +1,93 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// DeptSet 注入Dept
+var DeptSet = wire.NewSet(NewDept)
+
+// NewDept 创建部门储存
+func NewDept(db *gorm.DB) *Dept {
+	return &Dept{
+		Repository: Repository[entity.Dept, schema.Dept]{
+			DB:         db,
+			Mapper:     Mapper[entity.Dept, schema.Dept]{ToEntity: entity.ToDept, ToSchema: (*entity.Dept).ToSchemaDept},
+			NameColumn: "name",
+		},
+	}
+}
+
+// Dept 部门储存
+type Dept struct {
+	Repository[entity.Dept, schema.Dept]
+}
+
+func (a *Dept) getQueryOption(opts ...schema.DeptQueryOptions) schema.DeptQueryOptions {
+	var opt schema.DeptQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Dept) Query(ctx context.Context, params schema.DeptQueryParam, opts ...schema.DeptQueryOptions) (*schema.DeptQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := a.GetDB(ctx)
+	if v := params.IDs; len(v) > 0 {
+		db = db.Where("id IN (?)", v)
+	}
+	if v := params.Name; v != "" {
+		db = db.Where("name=?", v)
+	}
+	if v := params.ParentID; v != nil {
+		db = db.Where("parent_id=?", *v)
+	}
+	if v := params.PrefixParentPath; v != "" {
+		db = db.Where("parent_path LIKE ?", v+"%")
+	}
+	if v := params.Status; v != 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.QueryValue; v != "" {
+		v = "%" + v + "%"
+		db = db.Where("name LIKE ? OR memo LIKE ?", v, v)
+	}
+
+	list, pr, err := a.QueryPage(ctx, db, params.PaginationParam, opt.OrderFields)
+	if err != nil {
+		return nil, err
+	}
+
+	qr := &schema.DeptQueryResult{
+		PageResult: pr,
+		Data:       list,
+	}
+	return qr, nil
+}
+
+// Update 更新数据
+func (a *Dept) Update(ctx context.Context, id string, item schema.Dept) error {
+	eitem := entity.ToDept(&item)
+	result := a.GetDB(ctx).Where("id=?", id).Updates(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// UpdateParentPath 更新父级路径
+func (a *Dept) UpdateParentPath(ctx context.Context, id, parentPath string) error {
+	result := a.GetDB(ctx).Where("id=?", id).Update("parent_path", parentPath)
+	return errors.WithStack(result.Error)
+}
+
+// UpdateStatus 更新状态
+func (a *Dept) UpdateStatus(ctx context.Context, id string, status int) error {
+	result := a.GetDB(ctx).Where("id=?", id).Update("status", status)
+	return errors.WithStack(result.Error)
+}
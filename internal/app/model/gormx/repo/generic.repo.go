@@ -0,0 +1,193 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Mapper 实体与对象之间的类型化转换
+type Mapper[E any, S any] struct {
+	ToEntity func(*S) *E
+	ToSchema func(*E) *S
+}
+
+// ToSchemas 转换为对象列表
+func (m Mapper[E, S]) ToSchemas(list []*E) []*S {
+	items := make([]*S, len(list))
+	for i, item := range list {
+		items[i] = m.ToSchema(item)
+	}
+	return items
+}
+
+// DataScopeColumns 数据权限范围过滤字段(参考WrapDataScope)
+type DataScopeColumns struct {
+	Dept    string   // 数据所属部门字段
+	Creator string   // 创建者字段
+	Own     []string // 其它本人数据的判断字段
+}
+
+// Repository 通用存储(E为实体，S为对象)
+// 实体需包含id及deleted_at字段(UpdateStatus及UpdateVersion还需包含status及version字段)，事务及租户取自上下文
+type Repository[E any, S any] struct {
+	DB         *gorm.DB
+	Mapper     Mapper[E, S]
+	NameColumn string            // 回收站中作为名称显示的字段
+	DataScope  *DataScopeColumns // 数据权限范围过滤字段(为空时不过滤)
+}
+
+// GetDB 获取存储
+func (a *Repository[E, S]) GetDB(ctx context.Context) *gorm.DB {
+	return entity.GetDBWithModel(ctx, a.DB, new(E))
+}
+
+// WrapScope 根据上下文中的数据权限范围过滤数据
+func (a *Repository[E, S]) WrapScope(ctx context.Context, db *gorm.DB) (*gorm.DB, error) {
+	if a.DataScope == nil {
+		return db, nil
+	}
+	return WrapDataScope(ctx, a.DB, db, a.DataScope.Dept, a.DataScope.Creator, a.DataScope.Own...)
+}
+
+// QueryPage 查询数据(按数据权限范围过滤，按排序字段及id倒序排序)
+func (a *Repository[E, S]) QueryPage(ctx context.Context, db *gorm.DB, pp schema.PaginationParam, orderFields []*schema.OrderField) ([]*S, *schema.PaginationResult, error) {
+	db, err := a.WrapScope(ctx, db)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	orderFields = append(orderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(orderFields))
+
+	var list []*E
+	pr, err := WrapPageQuery(ctx, db, pp, &list)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return a.Mapper.ToSchemas(list), pr, nil
+}
+
+// FindOne 查询单条数据(不存在时返回nil)
+func (a *Repository[E, S]) FindOne(ctx context.Context, db *gorm.DB) (*S, error) {
+	item := new(E)
+	ok, err := FindOne(ctx, db, item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+	return a.Mapper.ToSchema(item), nil
+}
+
+// Get 查询指定数据
+func (a *Repository[E, S]) Get(ctx context.Context, id string) (*S, error) {
+	db, err := a.WrapScope(ctx, a.GetDB(ctx).Where("id=?", id))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return a.FindOne(ctx, db)
+}
+
+// Create 创建数据
+func (a *Repository[E, S]) Create(ctx context.Context, item S) error {
+	result := a.GetDB(ctx).Create(a.Mapper.ToEntity(&item))
+	return errors.WithStack(result.Error)
+}
+
+// UpdateVersion 更新数据(版本号与version一致时才更新，否则返回ErrPreconditionFailed)，eitem中需包含递增后的版本号
+// 按结构体更新时会忽略零值，指定columns时只更新这些字段(包括零值)
+func (a *Repository[E, S]) UpdateVersion(ctx context.Context, id string, version int64, eitem interface{}, columns ...string) error {
+	db := a.GetDB(ctx).Where("id=? AND version=?", id, version)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	result := db.Updates(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	} else if result.RowsAffected == 0 {
+		return errors.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Repository[E, S]) Delete(ctx context.Context, id string) error {
+	result := a.GetDB(ctx).Where("id=?", id).Delete(new(E))
+	return errors.WithStack(result.Error)
+}
+
+// UpdateStatus 更新状态(版本号一致时才更新，同时递增版本号，否则返回ErrPreconditionFailed)
+func (a *Repository[E, S]) UpdateStatus(ctx context.Context, id string, status int, version int64) error {
+	return a.UpdateVersion(ctx, id, version, map[string]interface{}{
+		"status":  status,
+		"version": version + 1,
+	})
+}
+
+// QueryDeleted 查询已删除的数据(回收站)
+func (a *Repository[E, S]) QueryDeleted(ctx context.Context, params schema.RecycleQueryParam) (*schema.RecycleQueryResult, error) {
+	db, err := a.WrapScope(ctx, a.GetDB(ctx))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result, err := WrapRecycleQuery(ctx, db, a.NameColumn, params)
+	return result, errors.WithStack(err)
+}
+
+// GetDeleted 查询指定的已删除数据
+func (a *Repository[E, S]) GetDeleted(ctx context.Context, id string) (*S, error) {
+	db, err := a.WrapScope(ctx, a.GetDB(ctx))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	item := new(E)
+	ok, err := FindDeleted(ctx, db, id, item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+	return a.Mapper.ToSchema(item), nil
+}
+
+// Restore 恢复已删除的数据
+func (a *Repository[E, S]) Restore(ctx context.Context, id string) error {
+	err := RestoreDeleted(ctx, a.GetDB(ctx), id)
+	return errors.WithStack(err)
+}
+
+// QueryPurgeIDs 查询删除时间早于指定时间的数据ID
+func (a *Repository[E, S]) QueryPurgeIDs(ctx context.Context, before time.Time) ([]string, error) {
+	ids, err := FindDeletedIDs(ctx, a.GetDB(ctx), before)
+	return ids, errors.WithStack(err)
+}
+
+// Purge 彻底删除已删除的数据
+func (a *Repository[E, S]) Purge(ctx context.Context, ids []string) error {
+	err := PurgeDeleted(ctx, a.GetDB(ctx), ids, new(E))
+	return errors.WithStack(err)
+}
+
+// DeleteTenant 删除上下文中租户的全部数据，返回租户的全部数据ID(含之前已删除的数据，用于彻底删除租户)
+func (a *Repository[E, S]) DeleteTenant(ctx context.Context) ([]string, error) {
+	if _, ok := contextx.FromTenantID(ctx); !ok {
+		return nil, errors.WithStack(entity.ErrNoTenant)
+	}
+
+	err := a.GetDB(ctx).Delete(new(E)).Error
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var ids []string
+	err = a.GetDB(ctx).Unscoped().Pluck("id", &ids).Error
+	return ids, errors.WithStack(err)
+}
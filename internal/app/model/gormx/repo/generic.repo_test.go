@@ -0,0 +1,153 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/model/gormx/gormxtest"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	db := gormxtest.NewDB(t)
+
+	t.Run("demo", func(t *testing.T) {
+		testTenantIsolation(t, db, &NewDemo(db).Repository, func(id string) *entity.Demo {
+			return &entity.Demo{ID: id, Code: id, Name: id, Status: 1}
+		})
+	})
+	t.Run("menu", func(t *testing.T) {
+		testTenantIsolation(t, db, &NewMenu(db).Repository, func(id string) *entity.Menu {
+			return &entity.Menu{ID: id, Name: id, Status: 1, ShowStatus: 1}
+		})
+	})
+	t.Run("role", func(t *testing.T) {
+		testTenantIsolation(t, db, &NewRole(db).Repository, func(id string) *entity.Role {
+			return &entity.Role{ID: id, Name: id, Status: 1}
+		})
+	})
+	t.Run("user", func(t *testing.T) {
+		testTenantIsolation(t, db, &NewUser(db).Repository, func(id string) *entity.User {
+			return &entity.User{ID: id, UserName: id, RealName: id, Status: 1}
+		})
+	})
+}
+
+// 在租户t1及t2中各创建一条数据，检查t1不能读取、更新、删除、恢复及彻底删除t2的数据
+func testTenantIsolation[E any, S any](t *testing.T, db *gorm.DB, r *Repository[E, S], newEntity func(id string) *E) {
+	ctx1 := contextx.NewTenantID(context.Background(), "t1")
+	ctx2 := contextx.NewTenantID(context.Background(), "t2")
+	create := func(ctx context.Context, id string) {
+		if err := db.WithContext(ctx).Create(newEntity(id)).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	exists := func(id string) bool {
+		var n int64
+		if err := db.Model(new(E)).Where("id=?", id).Count(&n).Error; err != nil {
+			t.Fatal(err)
+		}
+		return n > 0
+	}
+	version := func(id string) int64 {
+		var v int64
+		if err := db.Unscoped().Model(new(E)).Where("id=?", id).Pluck("version", &v).Error; err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	create(ctx1, "a1")
+	create(ctx2, "b1")
+	create(ctx2, "b2")
+
+	// 读取
+	if item, err := r.Get(ctx1, "b1"); err != nil || item != nil {
+		t.Fatalf("read other tenant: %v, %v", item, err)
+	}
+	var list []*E
+	if err := r.GetDB(ctx1).Where("id IN (?)", []string{"a1", "b1"}).Find(&list).Error; err != nil {
+		t.Fatal(err)
+	} else if len(list) != 1 {
+		t.Fatalf("query other tenant: %d", len(list))
+	}
+
+	// 更新
+	if err := r.UpdateStatus(ctx1, "b1", 2, 1); err != errors.ErrPreconditionFailed {
+		t.Fatalf("update other tenant status: %v", err)
+	}
+	if err := r.UpdateVersion(ctx1, "b1", 1, map[string]interface{}{"version": 2}); err != errors.ErrPreconditionFailed {
+		t.Fatalf("update other tenant: %v", err)
+	}
+	if v := version("b1"); v != 1 {
+		t.Fatalf("other tenant updated: version=%d", v)
+	}
+	if err := r.UpdateStatus(ctx2, "b1", 2, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// 删除
+	if err := r.Delete(ctx1, "b1"); err != nil {
+		t.Fatal(err)
+	} else if !exists("b1") {
+		t.Fatal("other tenant deleted")
+	}
+
+	// 回收站
+	if err := r.Delete(ctx2, "b2"); err != nil {
+		t.Fatal(err)
+	}
+	if item, err := r.GetDeleted(ctx1, "b2"); err != nil || item != nil {
+		t.Fatalf("read other tenant deleted: %v, %v", item, err)
+	}
+	if err := r.Restore(ctx1, "b2"); err != nil {
+		t.Fatal(err)
+	} else if exists("b2") {
+		t.Fatal("other tenant restored")
+	}
+	if ids, err := r.QueryPurgeIDs(ctx1, time.Now().Add(time.Minute)); err != nil || len(ids) != 0 {
+		t.Fatalf("query other tenant purge ids: %v, %v", ids, err)
+	}
+	if err := r.Purge(ctx1, []string{"b2"}); err != nil {
+		t.Fatal(err)
+	} else if item, err := r.GetDeleted(ctx2, "b2"); err != nil || item == nil {
+		t.Fatalf("other tenant purged: %v, %v", item, err)
+	}
+
+	// 删除租户只影响上下文中的租户
+	if ids, err := r.DeleteTenant(ctx1); err != nil || len(ids) != 1 || ids[0] != "a1" {
+		t.Fatalf("delete tenant: %v, %v", ids, err)
+	} else if !exists("b1") {
+		t.Fatal("other tenant deleted with tenant")
+	}
+
+	// 上下文中没有租户
+	ctx := context.Background()
+	if _, err := r.Get(ctx, "b1"); !errors.Is(err, entity.ErrNoTenant) {
+		t.Fatalf("expected ErrNoTenant, got %v", err)
+	}
+	if _, _, err := r.QueryPage(ctx, r.GetDB(ctx), schema.PaginationParam{}, nil); !errors.Is(err, entity.ErrNoTenant) {
+		t.Fatalf("expected ErrNoTenant, got %v", err)
+	}
+	if err := r.UpdateStatus(ctx, "b1", 1, 2); !errors.Is(err, entity.ErrNoTenant) {
+		t.Fatalf("expected ErrNoTenant, got %v", err)
+	}
+	if err := r.Delete(ctx, "b1"); !errors.Is(err, entity.ErrNoTenant) {
+		t.Fatalf("expected ErrNoTenant, got %v", err)
+	}
+	if _, err := r.DeleteTenant(ctx); !errors.Is(err, entity.ErrNoTenant) {
+		t.Fatalf("expected ErrNoTenant, got %v", err)
+	}
+	if !exists("b1") || version("b1") != 2 {
+		t.Fatal("data changed without tenant")
+	}
+
+	// 系统任务不限定租户
+	if item, err := r.Get(contextx.NewSystem(ctx), "b1"); err != nil || item == nil {
+		t.Fatalf("system read: %v, %v", item, err)
+	}
+}
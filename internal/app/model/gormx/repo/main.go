@@ -4,13 +4,23 @@ import "github.com/google/wire"
 
 // RepoSet model 注入
 var RepoSet = wire.NewSet(
+	APIKeySet,
+	AuditLogSet,
 	DemoSet,
+	DeptSet,
 	MenuActionResourceSet,
 	MenuActionSet,
 	MenuSet,
+	PasswordHistorySet,
+	PasswordResetSet,
+	RoleDeptSet,
 	RoleMenuSet,
+	RoleParentSet,
 	RoleSet,
+	TenantSet,
 	TransSet,
+	UserIdentitySet,
+	UserMFASet,
 	UserRoleSet,
 	UserSet,
 )
@@ -10,11 +10,22 @@ import (
 )
 
 // MenuSet 注入Menu
-var MenuSet = wire.NewSet(wire.Struct(new(Menu), "*"))
+var MenuSet = wire.NewSet(NewMenu)
+
+// NewMenu 创建菜单储存
+func NewMenu(db *gorm.DB) *Menu {
+	return &Menu{
+		Repository: Repository[entity.Menu, schema.Menu]{
+			DB:         db,
+			Mapper:     Mapper[entity.Menu, schema.Menu]{ToEntity: entity.ToMenu, ToSchema: (*entity.Menu).ToSchemaMenu},
+			NameColumn: "name",
+		},
+	}
+}
 
 // Menu 菜单储存
 type Menu struct {
-	DB *gorm.DB
+	Repository[entity.Menu, schema.Menu]
 }
 
 func (a *Menu) getQueryOption(opts ...schema.MenuQueryOptions) schema.MenuQueryOptions {
@@ -29,7 +40,7 @@ func (a *Menu) getQueryOption(opts ...schema.MenuQueryOptions) schema.MenuQueryO
 func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...schema.MenuQueryOptions) (*schema.MenuQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	db := entity.GetMenuDB(ctx, a.DB)
+	db := a.GetDB(ctx)
 	if v := params.IDs; len(v) > 0 {
 		db = db.Where("id IN (?)", v)
 	}
@@ -53,62 +64,27 @@ func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...
 		db = db.Where("name LIKE ? OR memo LIKE ?", v, v)
 	}
 
-	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
-	db = db.Order(ParseOrder(opt.OrderFields))
-
-	var list entity.Menus
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	list, pr, err := a.QueryPage(ctx, db, params.PaginationParam, opt.OrderFields)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
 	qr := &schema.MenuQueryResult{
 		PageResult: pr,
-		Data:       list.ToSchemaMenus(),
+		Data:       list,
 	}
 	return qr, nil
 }
 
-// Get 查询指定数据
-func (a *Menu) Get(ctx context.Context, id string, opts ...schema.MenuQueryOptions) (*schema.Menu, error) {
-	var item entity.Menu
-	ok, err := FindOne(ctx, entity.GetMenuDB(ctx, a.DB).Where("id=?", id), &item)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	} else if !ok {
-		return nil, nil
-	}
-	return item.ToSchemaMenu(), nil
-}
-
-// Create 创建数据
-func (a *Menu) Create(ctx context.Context, item schema.Menu) error {
-	eitem := entity.SchemaMenu(item).ToMenu()
-	result := entity.GetMenuDB(ctx, a.DB).Create(eitem)
-	return errors.WithStack(result.Error)
-}
-
-// Update 更新数据
+// Update 更新数据(版本号与item.Version一致时才更新，同时递增版本号，否则返回ErrPreconditionFailed)
 func (a *Menu) Update(ctx context.Context, id string, item schema.Menu) error {
-	eitem := entity.SchemaMenu(item).ToMenu()
-	result := entity.GetMenuDB(ctx, a.DB).Where("id=?", id).Updates(eitem)
-	return errors.WithStack(result.Error)
+	eitem := entity.ToMenu(&item)
+	eitem.Version = item.Version + 1
+	return a.UpdateVersion(ctx, id, item.Version, eitem)
 }
 
 // UpdateParentPath 更新父级路径
 func (a *Menu) UpdateParentPath(ctx context.Context, id, parentPath string) error {
-	result := entity.GetMenuDB(ctx, a.DB).Where("id=?", id).Update("parent_path", parentPath)
-	return errors.WithStack(result.Error)
-}
-
-// Delete 删除数据
-func (a *Menu) Delete(ctx context.Context, id string) error {
-	result := entity.GetMenuDB(ctx, a.DB).Where("id=?", id).Delete(entity.Menu{})
-	return errors.WithStack(result.Error)
-}
-
-// UpdateStatus 更新状态
-func (a *Menu) UpdateStatus(ctx context.Context, id string, status int) error {
-	result := entity.GetMenuDB(ctx, a.DB).Where("id=?", id).Update("status", status)
+	result := a.GetDB(ctx).Where("id=?", id).Update("parent_path", parentPath)
 	return errors.WithStack(result.Error)
 }
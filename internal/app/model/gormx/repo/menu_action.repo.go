@@ -29,7 +29,8 @@ func (a *MenuAction) getQueryOption(opts ...schema.MenuActionQueryOptions) schem
 func (a *MenuAction) Query(ctx context.Context, params schema.MenuActionQueryParam, opts ...schema.MenuActionQueryOptions) (*schema.MenuActionQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	db := entity.GetMenuActionDB(ctx, a.DB)
+	// 已删除菜单(回收站中)的动作不再生效
+	db := entity.GetMenuActionDB(ctx, a.DB).Where("menu_id IN (?)", entity.GetMenuDB(ctx, a.DB).Select("id"))
 	if v := params.MenuID; v != "" {
 		db = db.Where("menu_id=?", v)
 	}
@@ -69,14 +70,14 @@ func (a *MenuAction) Get(ctx context.Context, id string, opts ...schema.MenuActi
 
 // Create 创建数据
 func (a *MenuAction) Create(ctx context.Context, item schema.MenuAction) error {
-	eitem := entity.SchemaMenuAction(item).ToMenuAction()
+	eitem := entity.ToMenuAction(&item)
 	result := entity.GetMenuActionDB(ctx, a.DB).Create(eitem)
 	return errors.WithStack(result.Error)
 }
 
 // Update 更新数据
 func (a *MenuAction) Update(ctx context.Context, id string, item schema.MenuAction) error {
-	eitem := entity.SchemaMenuAction(item).ToMenuAction()
+	eitem := entity.ToMenuAction(&item)
 	result := entity.GetMenuActionDB(ctx, a.DB).Where("id=?", id).Updates(eitem)
 	return errors.WithStack(result.Error)
 }
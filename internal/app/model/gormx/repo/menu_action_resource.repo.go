@@ -29,16 +29,21 @@ func (a *MenuActionResource) getQueryOption(opts ...schema.MenuActionResourceQue
 func (a *MenuActionResource) Query(ctx context.Context, params schema.MenuActionResourceQueryParam, opts ...schema.MenuActionResourceQueryOptions) (*schema.MenuActionResourceQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	db := entity.GetMenuActionResourceDB(ctx, a.DB)
+	// 已删除菜单(回收站中)的动作资源不再生效
+	actionQuery := entity.GetMenuActionDB(ctx, a.DB).Where("menu_id IN (?)", entity.GetMenuDB(ctx, a.DB).Select("id")).Select("id")
+	db := entity.GetMenuActionResourceDB(ctx, a.DB).Where("action_id IN (?)", actionQuery)
 	if v := params.MenuID; v != "" {
 		subQuery := entity.GetMenuActionDB(ctx, a.DB).
 			Where("menu_id=?", v).
 			Select("id")
-		db = db.Where("action_id IN ?", subQuery)
+		db = db.Where("action_id IN (?)", subQuery)
 	}
 	if v := params.MenuIDs; len(v) > 0 {
 		subQuery := entity.GetMenuActionDB(ctx, a.DB).Where("menu_id IN (?)", v).Select("id")
-		db = db.Where("action_id IN ?", subQuery)
+		db = db.Where("action_id IN (?)", subQuery)
+	}
+	if v := params.ActionIDs; len(v) > 0 {
+		db = db.Where("action_id IN (?)", v)
 	}
 
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByASC))
@@ -73,14 +78,14 @@ func (a *MenuActionResource) Get(ctx context.Context, id string, opts ...schema.
 
 // Create 创建数据
 func (a *MenuActionResource) Create(ctx context.Context, item schema.MenuActionResource) error {
-	eitem := entity.SchemaMenuActionResource(item).ToMenuActionResource()
+	eitem := entity.ToMenuActionResource(&item)
 	result := entity.GetMenuActionResourceDB(ctx, a.DB).Create(eitem)
 	return errors.WithStack(result.Error)
 }
 
 // Update 更新数据
 func (a *MenuActionResource) Update(ctx context.Context, id string, item schema.MenuActionResource) error {
-	eitem := entity.SchemaMenuActionResource(item).ToMenuActionResource()
+	eitem := entity.ToMenuActionResource(&item)
 	result := entity.GetMenuActionResourceDB(ctx, a.DB).Where("id=?", id).Updates(eitem)
 	return errors.WithStack(result.Error)
 }
@@ -100,6 +105,6 @@ func (a *MenuActionResource) DeleteByActionID(ctx context.Context, actionID stri
 // DeleteByMenuID 根据菜单ID删除数据
 func (a *MenuActionResource) DeleteByMenuID(ctx context.Context, menuID string) error {
 	subQuery := entity.GetMenuActionDB(ctx, a.DB).Where("menu_id=?", menuID).Select("id")
-	result := entity.GetMenuActionResourceDB(ctx, a.DB).Where("action_id IN ?", subQuery).Delete(entity.MenuActionResource{})
+	result := entity.GetMenuActionResourceDB(ctx, a.DB).Where("action_id IN (?)", subQuery).Delete(entity.MenuActionResource{})
 	return errors.WithStack(result.Error)
 }
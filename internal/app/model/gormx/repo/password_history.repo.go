@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// PasswordHistorySet 注入PasswordHistory
+var PasswordHistorySet = wire.NewSet(wire.Struct(new(PasswordHistory), "*"))
+
+// PasswordHistory 历史密码存储
+type PasswordHistory struct {
+	DB *gorm.DB
+}
+
+// QueryByUserID 查询用户的历史密码(按创建时间倒序)
+func (a *PasswordHistory) QueryByUserID(ctx context.Context, userID string) (schema.PasswordHistories, error) {
+	var list entity.PasswordHistories
+	result := entity.GetPasswordHistoryDB(ctx, a.DB).Where("user_id=?", userID).Order("created_at DESC").Find(&list)
+	if err := result.Error; err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return list.ToSchemaPasswordHistories(), nil
+}
+
+// Create 创建数据
+func (a *PasswordHistory) Create(ctx context.Context, item schema.PasswordHistory) error {
+	eitem := entity.ToPasswordHistory(&item)
+	result := entity.GetPasswordHistoryDB(ctx, a.DB).Create(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// Delete 删除数据
+func (a *PasswordHistory) Delete(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	result := entity.GetPasswordHistoryDB(ctx, a.DB).Where("id IN (?)", ids).Delete(entity.PasswordHistory{})
+	return errors.WithStack(result.Error)
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *PasswordHistory) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetPasswordHistoryDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.PasswordHistory{})
+	return errors.WithStack(result.Error)
+}
@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// PasswordResetSet 注入PasswordReset
+var PasswordResetSet = wire.NewSet(wire.Struct(new(PasswordReset), "*"))
+
+// PasswordReset 密码重置存储
+type PasswordReset struct {
+	DB *gorm.DB
+}
+
+// GetByTokenHash 根据令牌哈希查询数据
+func (a *PasswordReset) GetByTokenHash(ctx context.Context, tokenHash string) (*schema.PasswordReset, error) {
+	var item entity.PasswordReset
+	ok, err := FindOne(ctx, entity.GetPasswordResetDB(ctx, a.DB).Where("token_hash=?", tokenHash), &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaPasswordReset(), nil
+}
+
+// Create 创建数据
+func (a *PasswordReset) Create(ctx context.Context, item schema.PasswordReset) error {
+	eitem := entity.ToPasswordReset(&item)
+	result := entity.GetPasswordResetDB(ctx, a.DB).Create(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *PasswordReset) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetPasswordResetDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.PasswordReset{})
+	return errors.WithStack(result.Error)
+}
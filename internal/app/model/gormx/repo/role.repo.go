@@ -4,17 +4,28 @@ import (
 	"context"
 	"ginAdmin/internal/app/model/gormx/entity"
 	"ginAdmin/internal/app/schema"
-	"ginAdmin/pkg/errors"
 	"github.com/google/wire"
 	"gorm.io/gorm"
+	"time"
 )
 
 // RoleSet 注入Role
-var RoleSet = wire.NewSet(wire.Struct(new(Role), "*"))
+var RoleSet = wire.NewSet(NewRole)
+
+// NewRole 创建角色存储
+func NewRole(db *gorm.DB) *Role {
+	return &Role{
+		Repository: Repository[entity.Role, schema.Role]{
+			DB:         db,
+			Mapper:     Mapper[entity.Role, schema.Role]{ToEntity: entity.ToRole, ToSchema: (*entity.Role).ToSchemaRole},
+			NameColumn: "name",
+		},
+	}
+}
 
 // Role 角色存储
 type Role struct {
-	DB *gorm.DB
+	Repository[entity.Role, schema.Role]
 }
 
 func (a *Role) getQueryOption(opts ...schema.RoleQueryOptions) schema.RoleQueryOptions {
@@ -29,7 +40,7 @@ func (a *Role) getQueryOption(opts ...schema.RoleQueryOptions) schema.RoleQueryO
 func (a *Role) Query(ctx context.Context, params schema.RoleQueryParam, opts ...schema.RoleQueryOptions) (*schema.RoleQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	db := entity.GetRoleDB(ctx, a.DB)
+	db := a.GetDB(ctx)
 	if v := params.IDs; len(v) > 0 {
 		db = db.Where("id IN (?)", v)
 	}
@@ -37,68 +48,33 @@ func (a *Role) Query(ctx context.Context, params schema.RoleQueryParam, opts ...
 		db = db.Where("name=?", v)
 	}
 	if v := params.UserID; v != "" {
-		subQuery := entity.GetUserRoleDB(ctx, a.DB).
-			Where("deleted_at is null").
-			Where("user_id=?", v).
-			Select("role_id")
-		db = db.Where("id IN ?", subQuery)
+		subQuery := entity.GetUserRoleDB(ctx, a.DB).Where("user_id=?", v)
+		subQuery = WhereUserRoleValid(subQuery, time.Now()).Select("role_id")
+		db = db.Where("id IN (?)", subQuery)
+	}
+	if v := params.Status; v > 0 {
+		db = db.Where("status=?", v)
 	}
 	if v := params.QueryValue; v != "" {
 		v = "%" + v + "%"
 		db = db.Where("name LIKE ? OR memo LIKE ?", v, v)
 	}
 
-	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
-	db = db.Order(ParseOrder(opt.OrderFields))
-
-	var list entity.Roles
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	list, pr, err := a.QueryPage(ctx, db, params.PaginationParam, opt.OrderFields)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 	qr := &schema.RoleQueryResult{
 		PageResult: pr,
-		Data:       list.ToSchemaRoles(),
+		Data:       list,
 	}
 
 	return qr, nil
 }
 
-// Get 查询指定数据
-func (a *Role) Get(ctx context.Context, id string, opts ...schema.RoleQueryOptions) (*schema.Role, error) {
-	var role entity.Role
-	ok, err := FindOne(ctx, entity.GetRoleDB(ctx, a.DB).Where("id=?", id), &role)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	} else if !ok {
-		return nil, nil
-	}
-
-	return role.ToSchemaRole(), nil
-}
-
-// Create 创建数据
-func (a *Role) Create(ctx context.Context, item schema.Role) error {
-	eitem := entity.SchemaRole(item).ToRole()
-	result := entity.GetRoleDB(ctx, a.DB).Create(eitem)
-	return errors.WithStack(result.Error)
-}
-
-// Update 更新数据
+// Update 更新数据(版本号与item.Version一致时才更新，同时递增版本号，否则返回ErrPreconditionFailed)
 func (a *Role) Update(ctx context.Context, id string, item schema.Role) error {
-	eitem := entity.SchemaRole(item).ToRole()
-	result := entity.GetRoleDB(ctx, a.DB).Where("id=?", id).Updates(eitem)
-	return errors.WithStack(result.Error)
-}
-
-// Delete 删除数据
-func (a *Role) Delete(ctx context.Context, id string) error {
-	result := entity.GetRoleDB(ctx, a.DB).Where("id=?", id).Delete(entity.Role{})
-	return errors.WithStack(result.Error)
-}
-
-// UpdateStatus 更新状态
-func (a *Role) UpdateStatus(ctx context.Context, id string, status int) error {
-	result := entity.GetRoleDB(ctx, a.DB).Where("id=?", id).Update("status", status)
-	return errors.WithStack(result.Error)
+	eitem := entity.ToRole(&item)
+	eitem.Version = item.Version + 1
+	return a.UpdateVersion(ctx, id, item.Version, eitem)
 }
@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// RoleDeptSet 注入RoleDept
+var RoleDeptSet = wire.NewSet(wire.Struct(new(RoleDept), "*"))
+
+// RoleDept 角色部门存储
+type RoleDept struct {
+	DB *gorm.DB
+}
+
+func (a *RoleDept) getQueryOption(opts ...schema.RoleDeptQueryOptions) schema.RoleDeptQueryOptions {
+	var opt schema.RoleDeptQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *RoleDept) Query(ctx context.Context, params schema.RoleDeptQueryParam, opts ...schema.RoleDeptQueryOptions) (*schema.RoleDeptQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetRoleDeptDB(ctx, a.DB)
+	if v := params.RoleID; v != "" {
+		db = db.Where("role_id=?", v)
+	}
+	if v := params.RoleIDs; len(v) > 0 {
+		db = db.Where("role_id IN (?)", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.RoleDepts
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.RoleDeptQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaRoleDepts(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *RoleDept) Create(ctx context.Context, item schema.RoleDept) error {
+	eitem := entity.ToRoleDept(&item)
+	result := entity.GetRoleDeptDB(ctx, a.DB).Create(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// DeleteByRoleID 根据角色ID删除数据
+func (a *RoleDept) DeleteByRoleID(ctx context.Context, roleID string) error {
+	result := entity.GetRoleDeptDB(ctx, a.DB).Where("role_id=?", roleID).Delete(entity.RoleDept{})
+	return errors.WithStack(result.Error)
+}
+
+// DeleteByDeptID 根据部门ID删除数据
+func (a *RoleDept) DeleteByDeptID(ctx context.Context, deptID string) error {
+	result := entity.GetRoleDeptDB(ctx, a.DB).Where("dept_id=?", deptID).Delete(entity.RoleDept{})
+	return errors.WithStack(result.Error)
+}
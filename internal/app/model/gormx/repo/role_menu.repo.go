@@ -69,14 +69,14 @@ func (a *RoleMenu) Get(ctx context.Context, id string, opts ...schema.RoleMenuQu
 
 // Create 创建数据
 func (a *RoleMenu) Create(ctx context.Context, item schema.RoleMenu) error {
-	eitem := entity.SchemaRoleMenu(item).ToRoleMenu()
+	eitem := entity.ToRoleMenu(&item)
 	result := entity.GetRoleMenuDB(ctx, a.DB).Create(eitem)
 	return errors.WithStack(result.Error)
 }
 
 // Update 更新数据
 func (a *RoleMenu) Update(ctx context.Context, id string, item schema.RoleMenu) error {
-	eitem := entity.SchemaRoleMenu(item).ToRoleMenu()
+	eitem := entity.ToRoleMenu(&item)
 	result := entity.GetRoleMenuDB(ctx, a.DB).Where("id=?", id).Updates(eitem)
 	return errors.WithStack(result.Error)
 }
@@ -92,3 +92,9 @@ func (a *RoleMenu) DeleteByRoleID(ctx context.Context, roleID string) error {
 	result := entity.GetRoleMenuDB(ctx, a.DB).Where("role_id=?", roleID).Delete(entity.RoleMenu{})
 	return errors.WithStack(result.Error)
 }
+
+// DeleteByMenuID 根据菜单ID删除数据
+func (a *RoleMenu) DeleteByMenuID(ctx context.Context, menuID string) error {
+	result := entity.GetRoleMenuDB(ctx, a.DB).Where("menu_id=?", menuID).Delete(entity.RoleMenu{})
+	return errors.WithStack(result.Error)
+}
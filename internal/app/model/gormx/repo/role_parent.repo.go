@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// RoleParentSet 注入RoleParent
+var RoleParentSet = wire.NewSet(wire.Struct(new(RoleParent), "*"))
+
+// RoleParent 角色继承关系存储
+type RoleParent struct {
+	DB *gorm.DB
+}
+
+func (a *RoleParent) getQueryOption(opts ...schema.RoleParentQueryOptions) schema.RoleParentQueryOptions {
+	var opt schema.RoleParentQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *RoleParent) Query(ctx context.Context, params schema.RoleParentQueryParam, opts ...schema.RoleParentQueryOptions) (*schema.RoleParentQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetRoleParentDB(ctx, a.DB)
+	if v := params.RoleID; v != "" {
+		db = db.Where("role_id=?", v)
+	}
+	if v := params.RoleIDs; len(v) > 0 {
+		db = db.Where("role_id IN (?)", v)
+	}
+	if v := params.ParentID; v != "" {
+		db = db.Where("parent_id=?", v)
+	}
+	if v := params.ParentIDs; len(v) > 0 {
+		db = db.Where("parent_id IN (?)", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.RoleParents
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.RoleParentQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaRoleParents(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *RoleParent) Create(ctx context.Context, item schema.RoleParent) error {
+	eitem := entity.ToRoleParent(&item)
+	result := entity.GetRoleParentDB(ctx, a.DB).Create(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// Delete 删除数据
+func (a *RoleParent) Delete(ctx context.Context, id string) error {
+	result := entity.GetRoleParentDB(ctx, a.DB).Where("id=?", id).Delete(entity.RoleParent{})
+	return errors.WithStack(result.Error)
+}
+
+// DeleteByRoleID 根据角色ID删除数据
+func (a *RoleParent) DeleteByRoleID(ctx context.Context, roleID string) error {
+	result := entity.GetRoleParentDB(ctx, a.DB).Where("role_id=?", roleID).Delete(entity.RoleParent{})
+	return errors.WithStack(result.Error)
+}
@@ -0,0 +1,88 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(NewTenant)
+
+// NewTenant 创建租户存储
+func NewTenant(db *gorm.DB) *Tenant {
+	return &Tenant{
+		Repository: Repository[entity.Tenant, schema.Tenant]{
+			DB:         db,
+			Mapper:     Mapper[entity.Tenant, schema.Tenant]{ToEntity: entity.ToTenant, ToSchema: (*entity.Tenant).ToSchemaTenant},
+			NameColumn: "name",
+		},
+	}
+}
+
+// Tenant 租户存储
+type Tenant struct {
+	Repository[entity.Tenant, schema.Tenant]
+}
+
+func (a *Tenant) getQueryOption(opts ...schema.TenantQueryOptions) schema.TenantQueryOptions {
+	var opt schema.TenantQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Tenant) Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := a.GetDB(ctx)
+	if v := params.Code; v != "" {
+		db = db.Where("code=?", v)
+	}
+	if v := params.Status; v > 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.QueryValue; v != "" {
+		v = "%" + v + "%"
+		db = db.Where("code LIKE ? OR name LIKE ? OR memo LIKE ?", v, v, v)
+	}
+
+	list, pr, err := a.QueryPage(ctx, db, params.PaginationParam, opt.OrderFields)
+	if err != nil {
+		return nil, err
+	}
+
+	qr := &schema.TenantQueryResult{
+		PageResult: pr,
+		Data:       list,
+	}
+	return qr, nil
+}
+
+// GetByCode 根据租户编号查询数据
+func (a *Tenant) GetByCode(ctx context.Context, code string) (*schema.Tenant, error) {
+	return a.FindOne(ctx, a.GetDB(ctx).Where("code=?", code))
+}
+
+// GetDeletedByCode 根据编号查询已删除的数据(编号唯一索引包含已删除的数据)
+func (a *Tenant) GetDeletedByCode(ctx context.Context, code string) (*schema.Tenant, error) {
+	return a.FindOne(ctx, a.GetDB(ctx).Unscoped().Where("code=? AND deleted_at IS NOT NULL", code))
+}
+
+// Update 更新数据
+func (a *Tenant) Update(ctx context.Context, id string, item schema.Tenant) error {
+	eitem := entity.ToTenant(&item)
+	result := a.GetDB(ctx).Where("id=?", id).Updates(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// UpdateStatus 更新状态
+func (a *Tenant) UpdateStatus(ctx context.Context, id string, status int) error {
+	result := a.GetDB(ctx).Where("id=?", id).Update("status", status)
+	return errors.WithStack(result.Error)
+}
@@ -7,14 +7,27 @@ import (
 	"ginAdmin/pkg/errors"
 	"github.com/google/wire"
 	"gorm.io/gorm"
+	"time"
 )
 
 // UserSet 注入User
-var UserSet = wire.NewSet(wire.Struct(new(User), "*"))
+var UserSet = wire.NewSet(NewUser)
+
+// NewUser 创建用户存储
+func NewUser(db *gorm.DB) *User {
+	return &User{
+		Repository: Repository[entity.User, schema.User]{
+			DB:         db,
+			Mapper:     Mapper[entity.User, schema.User]{ToEntity: entity.ToUser, ToSchema: (*entity.User).ToSchemaUser},
+			NameColumn: "user_name",
+			DataScope:  &DataScopeColumns{Dept: "dept_id", Creator: "creator", Own: []string{"id"}},
+		},
+	}
+}
 
 // User 用户存储
 type User struct {
-	DB *gorm.DB
+	Repository[entity.User, schema.User]
 }
 
 func (a *User) getQueryOption(opts ...schema.UserQueryOptions) schema.UserQueryOptions {
@@ -29,81 +42,75 @@ func (a *User) getQueryOption(opts ...schema.UserQueryOptions) schema.UserQueryO
 func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...schema.UserQueryOptions) (*schema.UserQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	db := entity.GetUserDB(ctx, a.DB)
+	db := a.GetDB(ctx)
+	if v := params.IDs; len(v) > 0 {
+		db = db.Where("id IN (?)", v)
+	}
 	if v := params.UserName; v != "" {
 		db = db.Where("user_name=?", v)
 	}
 	if v := params.Status; v > 0 {
 		db = db.Where("status=?", v)
 	}
+	if params.OnlySuper {
+		db = db.Where("is_super=?", true)
+	}
+	if v := params.DeptID; v != "" {
+		db = db.Where("dept_id=?", v)
+	}
 	if v := params.RoleIDs; len(v) > 0 {
 		subQuery := entity.GetUserRoleDB(ctx, a.DB).
 			Select("user_id").
 			Where("role_id IN (?)", v)
-		db = db.Where("id IN ?", subQuery)
+		db = db.Where("id IN (?)", subQuery)
 	}
 	if v := params.QueryValue; v != "" {
 		v = "%" + v + "%"
 		db = db.Where("user_name LIKE ? OR real_name LIKE ? OR phone LIKE ? OR email LIKE ?", v, v, v, v)
 	}
 
-	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
-	db = db.Order(ParseOrder(opt.OrderFields))
-
-	var list entity.Users
-	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	list, pr, err := a.QueryPage(ctx, db, params.PaginationParam, opt.OrderFields)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
 	qr := &schema.UserQueryResult{
 		PageResult: pr,
-		Data:       list.ToSchemaUsers(),
+		Data:       list,
 	}
 	return qr, nil
 }
 
-// Get 查询指定数据
-func (a *User) Get(ctx context.Context, id string, opts ...schema.UserQueryOptions) (*schema.User, error) {
-	var item entity.User
-	ok, err := FindOne(ctx, entity.GetUserDB(ctx, a.DB).Where("id=?", id), &item)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	} else if !ok {
-		return nil, nil
-	}
-
-	return item.ToSchemaUser(), nil
-}
-
-// Create 创建数据
-func (a *User) Create(ctx context.Context, item schema.User) error {
-	sitem := entity.SchemaUser(item)
-	result := entity.GetUserDB(ctx, a.DB).Create(sitem.ToUser())
-	return errors.WithStack(result.Error)
-}
-
-// Update 更新数据
+// Update 更新数据(版本号与item.Version一致时才更新，同时递增版本号，否则返回ErrPreconditionFailed)
 func (a *User) Update(ctx context.Context, id string, item schema.User) error {
-	eitem := entity.SchemaUser(item).ToUser()
-	result := entity.GetUserDB(ctx, a.DB).Where("id=?", id).Updates(eitem)
-	return errors.WithStack(result.Error)
+	eitem := entity.ToUser(&item)
+	eitem.Version = item.Version + 1
+	// 所属部门可能为空(移出部门)，显式指定更新的字段
+	return a.UpdateVersion(ctx, id, item.Version, eitem,
+		"user_name", "real_name", "password", "email", "phone", "status", "dept_id", "password_changed_at", "version")
 }
 
-// Delete 删除数据
-func (a *User) Delete(ctx context.Context, id string) error {
-	result := entity.GetUserDB(ctx, a.DB).Where("id=?", id).Delete(entity.User{})
+// UpdatePassword 更新密码(同时递增版本号)
+func (a *User) UpdatePassword(ctx context.Context, id, password string) error {
+	result := a.GetDB(ctx).Where("id=?", id).Updates(map[string]interface{}{
+		"password": password,
+		"version":  gorm.Expr("version+1"),
+	})
 	return errors.WithStack(result.Error)
 }
 
-// UpdateStatus 更新状态
-func (a *User) UpdateStatus(ctx context.Context, id string, status int) error {
-	result := entity.GetUserDB(ctx, a.DB).Where("id=?", id).Update("status", status)
+// ChangePassword 修改密码(同时更新密码修改时间并递增版本号)
+func (a *User) ChangePassword(ctx context.Context, id, password string, changedAt time.Time) error {
+	result := a.GetDB(ctx).Where("id=?", id).Updates(map[string]interface{}{
+		"password":            password,
+		"password_changed_at": changedAt,
+		"version":             gorm.Expr("version+1"),
+	})
 	return errors.WithStack(result.Error)
 }
 
-// UpdatePassword 更新密码
-func (a *User) UpdatePassword(ctx context.Context, id, password string) error {
-	result := entity.GetUserDB(ctx, a.DB).Where("id=?", id).Update("password", password)
+// UpdatePasswordChangedAt 更新密码修改时间
+func (a *User) UpdatePasswordChangedAt(ctx context.Context, id string, changedAt time.Time) error {
+	result := a.GetDB(ctx).Where("id=?", id).Update("password_changed_at", changedAt)
 	return errors.WithStack(result.Error)
 }
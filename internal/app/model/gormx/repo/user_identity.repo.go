@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// UserIdentitySet 注入UserIdentity
+var UserIdentitySet = wire.NewSet(wire.Struct(new(UserIdentity), "*"))
+
+// UserIdentity 用户外部身份存储
+type UserIdentity struct {
+	DB *gorm.DB
+}
+
+// GetBySubject 根据身份提供者及用户唯一标识查询数据
+func (a *UserIdentity) GetBySubject(ctx context.Context, provider, subject string) (*schema.UserIdentity, error) {
+	var item entity.UserIdentity
+	db := entity.GetUserIdentityDB(ctx, a.DB).Where("provider=? AND subject=?", provider, subject)
+	ok, err := FindOne(ctx, db, &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaUserIdentity(), nil
+}
+
+// Create 创建数据
+func (a *UserIdentity) Create(ctx context.Context, item schema.UserIdentity) error {
+	eitem := entity.ToUserIdentity(&item)
+	result := entity.GetUserIdentityDB(ctx, a.DB).Create(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// UpdateEmail 更新邮箱
+func (a *UserIdentity) UpdateEmail(ctx context.Context, id, email string) error {
+	result := entity.GetUserIdentityDB(ctx, a.DB).Where("id=?", id).Update("email", email)
+	return errors.WithStack(result.Error)
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *UserIdentity) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetUserIdentityDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.UserIdentity{})
+	return errors.WithStack(result.Error)
+}
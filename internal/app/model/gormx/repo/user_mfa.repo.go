@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+	"gorm.io/gorm"
+)
+
+// UserMFASet 注入UserMFA
+var UserMFASet = wire.NewSet(wire.Struct(new(UserMFA), "*"))
+
+// UserMFA 用户两步验证存储
+type UserMFA struct {
+	DB *gorm.DB
+}
+
+// GetByUserID 查询用户的两步验证数据
+func (a *UserMFA) GetByUserID(ctx context.Context, userID string) (*schema.UserMFA, error) {
+	var item entity.UserMFA
+	ok, err := FindOne(ctx, entity.GetUserMFADB(ctx, a.DB).Where("user_id=?", userID), &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaUserMFA(), nil
+}
+
+// Create 创建数据
+func (a *UserMFA) Create(ctx context.Context, item schema.UserMFA) error {
+	eitem := entity.ToUserMFA(&item)
+	result := entity.GetUserMFADB(ctx, a.DB).Create(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// Update 更新数据
+func (a *UserMFA) Update(ctx context.Context, id string, item schema.UserMFA) error {
+	eitem := entity.ToUserMFA(&item)
+	result := entity.GetUserMFADB(ctx, a.DB).Where("id=?", id).Select("*").Omit("id", "created_at").Updates(eitem)
+	return errors.WithStack(result.Error)
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *UserMFA) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetUserMFADB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.UserMFA{})
+	return errors.WithStack(result.Error)
+}
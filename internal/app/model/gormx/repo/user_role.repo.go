@@ -2,11 +2,13 @@ package repo
 
 import (
 	"context"
+	"ginAdmin/internal/app/contextx"
 	"ginAdmin/internal/app/model/gormx/entity"
 	"ginAdmin/internal/app/schema"
 	"ginAdmin/pkg/errors"
 	"github.com/google/wire"
 	"gorm.io/gorm"
+	"time"
 )
 
 // UserRoleSet 注入UserRole
@@ -30,12 +32,50 @@ func (a *UserRole) Query(ctx context.Context, params schema.UserRoleQueryParam,
 	opt := a.getQueryOption(opts...)
 
 	db := entity.GetUserRoleDB(ctx, a.DB)
+	// 只查询当前租户用户的角色授权(系统任务不限定租户)
+	if _, ok := contextx.FromTenantID(ctx); ok || !contextx.FromSystem(ctx) {
+		userDB := entity.GetUserDB(ctx, a.DB)
+		if err := userDB.Error; err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if params.InDataScope {
+			var err error
+			userDB, err = WrapDataScope(ctx, a.DB, userDB, "dept_id", "creator", "id")
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+		db = db.Where("user_id IN (?)", userDB.Select("id"))
+	}
 	if v := params.UserID; v != "" {
 		db = db.Where("user_id=?", v)
 	}
 	if v := params.UserIDs; len(v) > 0 {
 		db = db.Where("user_id IN (?)", v)
 	}
+	if v := params.RoleID; v != "" {
+		db = db.Where("role_id=?", v)
+	}
+	if v := params.ValidAt; v != nil {
+		db = WhereUserRoleValid(db, *v)
+	}
+	if v := params.ExpireAfter; v != nil {
+		db = db.Where("valid_until>?", *v)
+	}
+	if v := params.ExpireBefore; v != nil {
+		db = db.Where("valid_until<=?", *v)
+	}
+	if params.ChangedAfter != nil || params.ChangedBefore != nil {
+		from, until := a.DB.Where("valid_from IS NOT NULL"), a.DB.Where("valid_until IS NOT NULL")
+		if v := params.ChangedAfter; v != nil {
+			from, until = from.Where("valid_from>?", *v), until.Where("valid_until>?", *v)
+		}
+		if v := params.ChangedBefore; v != nil {
+			from, until = from.Where("valid_from<=?", *v), until.Where("valid_until<=?", *v)
+		}
+		db = db.Where(from.Or(until))
+	}
 
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
 	db = db.Order(ParseOrder(opt.OrderFields))
@@ -69,18 +109,27 @@ func (a *UserRole) Get(ctx context.Context, id string, opts ...schema.UserRoleQu
 
 // Create 创建数据
 func (a *UserRole) Create(ctx context.Context, item schema.UserRole) error {
-	eitem := entity.SchemaUserRole(item).ToUserRole()
+	eitem := entity.ToUserRole(&item)
 	result := entity.GetUserRoleDB(ctx, a.DB).Create(eitem)
 	return errors.WithStack(result.Error)
 }
 
 // Update 更新数据
 func (a *UserRole) Update(ctx context.Context, id string, item schema.UserRole) error {
-	eitem := entity.SchemaUserRole(item).ToUserRole()
+	eitem := entity.ToUserRole(&item)
 	result := entity.GetUserRoleDB(ctx, a.DB).Where("id=?", id).Updates(eitem)
 	return errors.WithStack(result.Error)
 }
 
+// UpdateValidity 更新有效期(支持清空)
+func (a *UserRole) UpdateValidity(ctx context.Context, id string, validFrom, validUntil *time.Time) error {
+	result := entity.GetUserRoleDB(ctx, a.DB).Where("id=?", id).Updates(map[string]interface{}{
+		"valid_from":  validFrom,
+		"valid_until": validUntil,
+	})
+	return errors.WithStack(result.Error)
+}
+
 // Delete 删除数据
 func (a *UserRole) Delete(ctx context.Context, id string) error {
 	result := entity.GetUserRoleDB(ctx, a.DB).Where("id=?", id).Delete(entity.UserRole{})
@@ -92,3 +141,14 @@ func (a *UserRole) DeleteByUserID(ctx context.Context, userID string) error {
 	result := entity.GetUserRoleDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.UserRole{})
 	return errors.WithStack(result.Error)
 }
+
+// DeleteByRoleID 根据角色ID删除数据
+func (a *UserRole) DeleteByRoleID(ctx context.Context, roleID string) error {
+	result := entity.GetUserRoleDB(ctx, a.DB).Where("role_id=?", roleID).Delete(entity.UserRole{})
+	return errors.WithStack(result.Error)
+}
+
+// WhereUserRoleValid 过滤在指定时间有效的用户角色
+func WhereUserRoleValid(db *gorm.DB, t time.Time) *gorm.DB {
+	return db.Where("(valid_from IS NULL OR valid_from<=?) AND (valid_until IS NULL OR valid_until>?)", t, t)
+}
@@ -3,23 +3,35 @@ package adapter
 import (
 	"context"
 	"fmt"
+	"ginAdmin/internal/app/contextx"
 	"ginAdmin/internal/app/model/gormx/repo"
 	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
 	"ginAdmin/pkg/logger"
+	"ginAdmin/pkg/util/uuid"
 	casbinModel "github.com/casbin/casbin/v2/model"
 	"github.com/casbin/casbin/v2/persist"
 	"github.com/google/wire"
+	"strings"
+	"time"
 )
 
-var _ persist.Adapter = (*CasbinAdapter)(nil)
+var (
+	_ persist.Adapter      = (*CasbinAdapter)(nil)
+	_ persist.BatchAdapter = (*CasbinAdapter)(nil)
+)
 
 // CasbinAdapterSet 注入 CasbinAdapter
 var CasbinAdapterSet = wire.NewSet(wire.Struct(new(CasbinAdapter), "*"), wire.Bind(new(persist.Adapter), new(*CasbinAdapter)))
 
 // CasbinAdapter casbin适配器
+// 策略规则由业务数据派生：p规则来自已启用角色的菜单资源(超级管理员为p,user_id,tenant_id,/*,.*)，
+// g规则来自已启用用户的用户角色及已启用角色之间的继承关系(g,role_id,parent_id,tenant_id)
+// 租户作为casbin的域(dom)，角色及用户的规则只在所属租户内生效
 type CasbinAdapter struct {
 	RoleModel         *repo.Role
 	RoleMenuModel     *repo.RoleMenu
+	RoleParentModel   *repo.RoleParent
 	MenuResourceModel *repo.MenuActionResource
 	UserModel         *repo.User
 	UserRoleModel     *repo.UserRole
@@ -27,7 +39,7 @@ type CasbinAdapter struct {
 
 // LoadPolicy 从存储加载所有策略规则
 func (a *CasbinAdapter) LoadPolicy(model casbinModel.Model) error {
-	ctx := context.Background()
+	ctx := contextx.NewSystem(context.Background())
 	err := a.LoadRolePolicy(ctx, model)
 	if err != nil {
 		logger.WithContext(ctx).Errorf("Load casbin role policy error: %s", err.Error())
@@ -43,7 +55,7 @@ func (a *CasbinAdapter) LoadPolicy(model casbinModel.Model) error {
 	return nil
 }
 
-// 加载角色策略(p,role_id,path,method)
+// 加载角色策略(p,role_id,tenant_id,path,method)及角色继承关系(g,role_id,parent_id,tenant_id)
 func (a *CasbinAdapter) LoadRolePolicy(ctx context.Context, m casbinModel.Model) error {
 	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
 		Status: 1,
@@ -68,28 +80,23 @@ func (a *CasbinAdapter) LoadRolePolicy(ctx context.Context, m casbinModel.Model)
 	mMenuResources := menuResourceResult.Data.ToActionIDMap()
 
 	for _, item := range roleResult.Data {
-		mcache := make(map[string]struct{})
-		if rms, ok := mRoleMenus[item.ID]; ok {
-			for _, actionID := range rms.ToActionIDs() {
-				if mrs, ok := mMenuResources[actionID]; ok {
-					for _, mr := range mrs {
-						if mr.Path == "" || mr.Method == "" {
-							continue
-						} else if _, ok := mcache[mr.Path+mr.Method]; ok {
-							continue
-						}
-						mcache[mr.Path+mr.Method] = struct{}{}
-						line := fmt.Sprintf("p,%s,%s,%s", item.ID, mr.Path, mr.Method)
-						persist.LoadPolicyLine(line, m)
-					}
-				}
-			}
+		for _, rule := range rolePolicies(item, mRoleMenus[item.ID], mMenuResources) {
+			loadPolicyRule("p", rule, m)
 		}
 	}
+
+	roleParentResult, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{})
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range roleGroupings(roleResult.Data.ToMap(), roleParentResult.Data) {
+		loadPolicyRule("g", rule, m)
+	}
 	return nil
 }
 
-// 加载用户策略(g,user_id,role_id)
+// 加载用户策略(g,user_id,role_id,tenant_id，仅包含当前有效的授权)，超级管理员拥有所属租户内所有资源的访问权限(p,user_id,tenant_id,/*,.*)
 func (a *CasbinAdapter) LoadUserPolicy(ctx context.Context, m casbinModel.Model) error {
 	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
 		Status: 1,
@@ -97,43 +104,497 @@ func (a *CasbinAdapter) LoadUserPolicy(ctx context.Context, m casbinModel.Model)
 	if err != nil {
 		return err
 	} else if len(userResult.Data) > 0 {
-		userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{})
+		now := time.Now()
+		userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+			ValidAt: &now,
+		})
 		if err != nil {
 			return err
 		}
 
 		mUserRoles := userRoleResult.Data.ToUserIDMap()
 		for _, uitem := range userResult.Data {
-			if urs, ok := mUserRoles[uitem.ID]; ok {
-				for _, ur := range urs {
-					line := fmt.Sprintf("g,%s,%s", ur.UserID, ur.RoleID)
-					persist.LoadPolicyLine(line, m)
-				}
+			policies, groupings := userPolicies(uitem, mUserRoles[uitem.ID])
+			for _, rule := range policies {
+				loadPolicyRule("p", rule, m)
+			}
+			for _, rule := range groupings {
+				loadPolicyRule("g", rule, m)
 			}
 		}
 	}
 	return nil
 }
 
-// SavePolicy saves all policy rules to the storage.
+// QueryRolePolicies 查询角色当前在存储中对应的p规则及g规则(角色不存在或已禁用时为空)
+// g规则包括角色继承父级角色及其他角色继承该角色的规则
+func (a *CasbinAdapter) QueryRolePolicies(ctx context.Context, roleID string) (policies, groupings [][]string, err error) {
+	role, err := a.RoleModel.Get(ctx, roleID)
+	if err != nil {
+		return nil, nil, err
+	} else if role == nil || role.Status != 1 {
+		return nil, nil, nil
+	}
+
+	groupings, err = a.queryRoleGroupings(ctx, role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
+		RoleID: roleID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actionIDs := roleMenuResult.Data.ToActionIDs()
+	if len(actionIDs) == 0 {
+		return nil, groupings, nil
+	}
+
+	menuResourceResult, err := a.MenuResourceModel.Query(ctx, schema.MenuActionResourceQueryParam{
+		ActionIDs: actionIDs,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policies = rolePolicies(role, roleMenuResult.Data, menuResourceResult.Data.ToActionIDMap())
+	return policies, groupings, nil
+}
+
+// 查询已启用角色与其父级角色及子级角色之间的g规则
+func (a *CasbinAdapter) queryRoleGroupings(ctx context.Context, role *schema.Role) ([][]string, error) {
+	parentResult, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{
+		RoleID: role.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	childResult, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{
+		ParentID: role.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	roleParents := append(parentResult.Data, childResult.Data...)
+	if len(roleParents) == 0 {
+		return nil, nil
+	}
+
+	var roleIDs []string
+	for _, item := range roleParents {
+		roleIDs = append(roleIDs, item.RoleID, item.ParentID)
+	}
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		IDs:    roleIDs,
+		Status: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return roleGroupings(roleResult.Data.ToMap(), roleParents), nil
+}
+
+// QueryUserPolicies 查询用户当前在存储中对应的p规则及g规则(用户不存在或已禁用时为空，不包含未生效或已失效的授权)
+func (a *CasbinAdapter) QueryUserPolicies(ctx context.Context, userID string) (policies, groupings [][]string, err error) {
+	user, err := a.UserModel.Get(contextx.NewNoDataScope(ctx), userID)
+	if err != nil {
+		return nil, nil, err
+	} else if user == nil || user.Status != 1 {
+		return nil, nil, nil
+	}
+
+	now := time.Now()
+	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserID:  userID,
+		ValidAt: &now,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policies, groupings = userPolicies(user, userRoleResult.Data)
+	return policies, groupings, nil
+}
+
+// 根据角色菜单及菜单资源生成角色的p规则(去除重复的资源)
+func rolePolicies(role *schema.Role, roleMenus schema.RoleMenus, mMenuResources map[string]schema.MenuActionResources) [][]string {
+	var rules [][]string
+	mcache := make(map[string]struct{})
+	for _, actionID := range roleMenus.ToActionIDs() {
+		for _, mr := range mMenuResources[actionID] {
+			if mr.Path == "" || mr.Method == "" {
+				continue
+			} else if _, ok := mcache[mr.Path+mr.Method]; ok {
+				continue
+			}
+			mcache[mr.Path+mr.Method] = struct{}{}
+			rules = append(rules, []string{role.ID, role.TenantID, mr.Path, mr.Method})
+		}
+	}
+	return rules
+}
+
+// 根据角色继承关系生成角色之间的g规则(继承双方均为已启用的角色，且属于同一租户)
+func roleGroupings(mRoles map[string]*schema.Role, roleParents schema.RoleParents) [][]string {
+	var rules [][]string
+	for _, rp := range roleParents {
+		role, ok := mRoles[rp.RoleID]
+		if !ok {
+			continue
+		} else if parent, ok := mRoles[rp.ParentID]; !ok || parent.TenantID != role.TenantID {
+			continue
+		}
+		rules = append(rules, []string{rp.RoleID, rp.ParentID, role.TenantID})
+	}
+	return rules
+}
+
+// 根据用户及用户角色生成用户的p规则及g规则
+func userPolicies(user *schema.User, userRoles schema.UserRoles) (policies, groupings [][]string) {
+	if user.IsSuper {
+		policies = append(policies, []string{user.ID, user.TenantID, "/*", ".*"})
+	}
+	for _, ur := range userRoles {
+		groupings = append(groupings, []string{ur.UserID, ur.RoleID, user.TenantID})
+	}
+	return
+}
+
+func loadPolicyRule(ptype string, rule []string, m casbinModel.Model) {
+	persist.LoadPolicyLine(fmt.Sprintf("%s,%s", ptype, strings.Join(rule, ",")), m)
+}
+
+// SavePolicy 策略规则由业务数据派生，不支持整体保存
 func (a *CasbinAdapter) SavePolicy(model casbinModel.Model) error {
 	return nil
 }
 
-// AddPolicy adds a policy rule to the storage.
-// This is part of the Auto-Save feature.
+// AddPolicy 添加策略规则
 func (a *CasbinAdapter) AddPolicy(sec string, ptype string, rule []string) error {
-	return nil
+	return a.AddPolicies(sec, ptype, [][]string{rule})
 }
 
-// RemovePolicy removes a policy rule from the storage.
-// This is part of the Auto-Save feature.
+// RemovePolicy 移除策略规则
 func (a *CasbinAdapter) RemovePolicy(sec string, ptype string, rule []string) error {
-	return nil
+	return a.RemovePolicies(sec, ptype, [][]string{rule})
 }
 
-// RemoveFilteredPolicy removes policy rules that match the filter from the storage.
-// This is part of the Auto-Save feature.
+// AddPolicies 添加策略规则
+// g规则写入用户角色或角色继承关系(已存在时忽略)；p规则由角色菜单派生，只校验存储中已存在该规则，需先修改角色菜单
+func (a *CasbinAdapter) AddPolicies(sec string, ptype string, rules [][]string) error {
+	ctx := contextx.NewSystem(context.Background())
+	switch sec {
+	case "p":
+		return a.checkPolicies(ctx, rules, true)
+	case "g":
+		for _, rule := range rules {
+			if len(rule) < 2 {
+				return errors.Errorf("invalid casbin grouping rule: %v", rule)
+			}
+
+			role, err := a.RoleModel.Get(ctx, rule[0])
+			if err != nil {
+				return err
+			} else if role != nil {
+				err = a.addRoleParent(ctx, role, rule)
+			} else {
+				err = a.addUserRole(ctx, rule)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return errors.Errorf("unsupported casbin policy section: %s", sec)
+}
+
+// RemovePolicies 移除策略规则
+// g规则删除已启用用户的用户角色或已启用角色的继承关系(已禁用或已删除的用户及角色不产生g规则，无需修改存储)；
+// p规则只校验存储中已不存在该规则
+func (a *CasbinAdapter) RemovePolicies(sec string, ptype string, rules [][]string) error {
+	ctx := contextx.NewSystem(context.Background())
+	switch sec {
+	case "p":
+		return a.checkPolicies(ctx, rules, false)
+	case "g":
+		for _, rule := range rules {
+			if len(rule) < 2 {
+				return errors.Errorf("invalid casbin grouping rule: %v", rule)
+			}
+			err := a.deleteUserRoles(ctx, rule[0], rule[1])
+			if err != nil {
+				return err
+			}
+
+			err = a.deleteRoleParents(ctx, rule[0], rule[1])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return errors.Errorf("unsupported casbin policy section: %s", sec)
+}
+
+// RemoveFilteredPolicy 移除匹配过滤条件的策略规则
+// p规则仅支持按主体(fieldIndex=0)过滤；g规则支持按用户(或子级角色)及角色过滤(租户由用户及角色确定，不作为过滤条件)
 func (a *CasbinAdapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	ctx := contextx.NewSystem(context.Background())
+	switch sec {
+	case "p":
+		if fieldIndex != 0 || len(fieldValues) == 0 || fieldValues[0] == "" {
+			return errors.Errorf("unsupported casbin policy filter: %d %v", fieldIndex, fieldValues)
+		}
+
+		rules, err := a.querySubjectPolicies(ctx, fieldValues[0])
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			if matchPolicyFilter(rule, fieldIndex, fieldValues...) {
+				return errors.Errorf("casbin policy is derived from role menus: %v", rule)
+			}
+		}
+		return nil
+	case "g":
+		var userID, roleID string
+		for i, v := range fieldValues {
+			switch fieldIndex + i {
+			case 0:
+				userID = v
+			case 1:
+				roleID = v
+			}
+		}
+		if userID == "" && roleID == "" {
+			return errors.Errorf("unsupported casbin grouping filter: %d %v", fieldIndex, fieldValues)
+		}
+
+		err := a.deleteUserRoles(ctx, userID, roleID)
+		if err != nil {
+			return err
+		}
+		return a.deleteRoleParents(ctx, userID, roleID)
+	}
+	return errors.Errorf("unsupported casbin policy section: %s", sec)
+}
+
+// 查询主体(角色或用户)在存储中对应的p规则
+func (a *CasbinAdapter) querySubjectPolicies(ctx context.Context, subject string) ([][]string, error) {
+	rules, _, err := a.QueryRolePolicies(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	userRules, _, err := a.QueryUserPolicies(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	return append(rules, userRules...), nil
+}
+
+// 校验p规则在存储中存在(exists=true)或不存在(exists=false)
+func (a *CasbinAdapter) checkPolicies(ctx context.Context, rules [][]string, exists bool) error {
+	mSubjectRules := make(map[string]map[string]struct{})
+	for _, rule := range rules {
+		if len(rule) == 0 {
+			return errors.Errorf("invalid casbin policy rule: %v", rule)
+		}
+
+		mRules, ok := mSubjectRules[rule[0]]
+		if !ok {
+			subjectRules, err := a.querySubjectPolicies(ctx, rule[0])
+			if err != nil {
+				return err
+			}
+
+			mRules = make(map[string]struct{}, len(subjectRules))
+			for _, item := range subjectRules {
+				mRules[strings.Join(item, ",")] = struct{}{}
+			}
+			mSubjectRules[rule[0]] = mRules
+		}
+
+		if _, ok := mRules[strings.Join(rule, ",")]; ok != exists {
+			return errors.Errorf("casbin policy is derived from role menus: %v", rule)
+		}
+	}
+	return nil
+}
+
+// 为已启用的用户添加角色(已存在时忽略，用户与角色须属于同一租户)
+func (a *CasbinAdapter) addUserRole(ctx context.Context, rule []string) error {
+	if len(rule) < 2 {
+		return errors.Errorf("invalid casbin grouping rule: %v", rule)
+	}
+	userID, roleID := rule[0], rule[1]
+
+	user, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return err
+	} else if user == nil || user.Status != 1 {
+		return errors.Errorf("casbin grouping user is not available: %s", userID)
+	}
+
+	role, err := a.RoleModel.Get(ctx, roleID)
+	if err != nil {
+		return err
+	} else if role == nil {
+		return errors.Errorf("casbin grouping role does not exist: %s", roleID)
+	} else if role.TenantID != user.TenantID || (len(rule) > 2 && rule[2] != user.TenantID) {
+		return errors.Errorf("casbin grouping tenant mismatch: %v", rule)
+	}
+
+	result, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		UserID:          userID,
+		RoleID:          roleID,
+	})
+	if err != nil {
+		return err
+	} else if result.PageResult.Total > 0 {
+		return nil
+	}
+
+	return a.UserRoleModel.Create(ctx, schema.UserRole{
+		ID:     uuid.MustString(),
+		UserID: userID,
+		RoleID: roleID,
+	})
+}
+
+// 添加角色继承关系(已存在时忽略，父级角色须属于同一租户且不能形成循环继承)
+func (a *CasbinAdapter) addRoleParent(ctx context.Context, role *schema.Role, rule []string) error {
+	parentID := rule[1]
+	parent, err := a.RoleModel.Get(ctx, parentID)
+	if err != nil {
+		return err
+	} else if parent == nil {
+		return errors.Errorf("casbin grouping parent role does not exist: %s", parentID)
+	} else if parent.TenantID != role.TenantID || (len(rule) > 2 && rule[2] != role.TenantID) {
+		return errors.Errorf("casbin grouping tenant mismatch: %v", rule)
+	}
+
+	result, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range result.Data {
+		if item.RoleID == role.ID && item.ParentID == parentID {
+			return nil
+		}
+	}
+
+	if parentID == role.ID || contains(result.Data.Ancestors(parentID), role.ID) {
+		return errors.Errorf("casbin grouping role cycle: %v", rule)
+	}
+
+	return a.RoleParentModel.Create(ctx, schema.RoleParent{
+		ID:       uuid.MustString(),
+		RoleID:   role.ID,
+		ParentID: parentID,
+	})
+}
+
+// 删除已启用角色之间的继承关系(roleID或parentID为空时不作为过滤条件)
+func (a *CasbinAdapter) deleteRoleParents(ctx context.Context, roleID, parentID string) error {
+	result, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{
+		RoleID:   roleID,
+		ParentID: parentID,
+	})
+	if err != nil || len(result.Data) == 0 {
+		return err
+	}
+
+	var roleIDs []string
+	for _, item := range result.Data {
+		roleIDs = append(roleIDs, item.RoleID, item.ParentID)
+	}
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		IDs:    roleIDs,
+		Status: 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	mRoles := roleResult.Data.ToMap()
+	for _, item := range result.Data {
+		if _, ok := mRoles[item.RoleID]; !ok {
+			continue
+		} else if _, ok := mRoles[item.ParentID]; !ok {
+			continue
+		}
+
+		err := a.RoleParentModel.Delete(ctx, item.ID)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// 删除已启用用户当前有效的用户角色(userID或roleID为空时不作为过滤条件，未生效或已失效的授权不在策略中，予以保留)
+func (a *CasbinAdapter) deleteUserRoles(ctx context.Context, userID, roleID string) error {
+	now := time.Now()
+	result, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserID:  userID,
+		RoleID:  roleID,
+		ValidAt: &now,
+	})
+	if err != nil {
+		return err
+	}
+
+	mUserStatus := make(map[string]bool)
+	for _, ur := range result.Data {
+		enabled, ok := mUserStatus[ur.UserID]
+		if !ok {
+			user, err := a.UserModel.Get(ctx, ur.UserID)
+			if err != nil {
+				return err
+			}
+			enabled = user != nil && user.Status == 1
+			mUserStatus[ur.UserID] = enabled
+		}
+
+		if !enabled {
+			continue
+		}
+
+		err := a.UserRoleModel.Delete(ctx, ur.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 检查规则是否匹配过滤条件(空值表示不限制)
+func matchPolicyFilter(rule []string, fieldIndex int, fieldValues ...string) bool {
+	for i, v := range fieldValues {
+		if v == "" {
+			continue
+		} else if fieldIndex+i >= len(rule) || rule[fieldIndex+i] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"ginAdmin/internal/app/config"
+	"ginAdmin/pkg/notify"
+	"os"
+	"path/filepath"
+)
+
+// InitNotifier 初始化消息通知(未指定通知方式时返回nil，不启用密码重置)
+func InitNotifier() notify.Notifier {
+	cfg := config.C.PasswordReset
+
+	switch cfg.Notifier {
+	case "file":
+		_ = os.MkdirAll(filepath.Dir(cfg.FilePath), 0777)
+		return notify.NewFileNotifier(cfg.FilePath)
+	case "log":
+		return notify.NewLogNotifier()
+	default:
+		return nil
+	}
+}
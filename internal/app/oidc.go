@@ -0,0 +1,55 @@
+package app
+
+import (
+	"ginAdmin/internal/app/config"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/oidc"
+	"ginAdmin/pkg/auth/oidc/store/memory"
+	"ginAdmin/pkg/auth/oidc/store/redis"
+)
+
+// InitIdentityProviders 初始化第三方身份提供者(未启用时返回空集合)
+func InitIdentityProviders() auth.IdentityProviders {
+	cfg := config.C.OIDC
+
+	providers := make(auth.IdentityProviders)
+	if !cfg.Enable {
+		return providers
+	}
+
+	for _, item := range cfg.Providers {
+		providers[item.Name] = oidc.New(oidc.Config{
+			Name:         item.Name,
+			Issuer:       item.Issuer,
+			ClientID:     item.ClientID,
+			ClientSecret: item.ClientSecret,
+			RedirectURL:  item.RedirectURL,
+			Scopes:       item.Scopes,
+		})
+	}
+	return providers
+}
+
+// InitOIDCStore 初始化第三方登录状态存储
+func InitOIDCStore() (oidc.Storer, func(), error) {
+	cfg := config.C.OIDC
+
+	var store oidc.Storer
+	switch cfg.Store {
+	case "redis":
+		rcfg := config.C.Redis
+		store = redis.NewStore(&redis.Config{
+			Addr:      rcfg.Addr,
+			Password:  rcfg.Password,
+			DB:        cfg.RedisDB,
+			KeyPrefix: cfg.RedisPrefix,
+		})
+	default:
+		store = memory.NewStore(0)
+	}
+
+	cleanFunc := func() {
+		_ = store.Close()
+	}
+	return store, cleanFunc, nil
+}
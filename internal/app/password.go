@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/pkg/auth/password"
+	"time"
+)
+
+// InitPassword 初始化密码管理(使用配置的算法生成哈希，同时兼容校验其它算法及旧版SHA1哈希)
+func InitPassword() (*password.Manager, error) {
+	cfg := config.C.Password
+
+	argon2id := password.NewArgon2id(cfg.Argon2Time, cfg.Argon2Memory, cfg.Argon2Threads)
+	bcrypt := password.NewBcrypt(cfg.BcryptCost)
+	legacy := password.NewLegacySHA1()
+
+	switch cfg.Algorithm {
+	case password.Argon2idName, "":
+		return password.New(argon2id, bcrypt, legacy), nil
+	case password.BcryptName:
+		return password.New(bcrypt, argon2id, legacy), nil
+	default:
+		return nil, fmt.Errorf("unknown password algorithm: %s", cfg.Algorithm)
+	}
+}
+
+// InitPasswordPolicy 初始化密码策略
+func InitPasswordPolicy() *password.Policy {
+	cfg := config.C.PasswordPolicy
+
+	return &password.Policy{
+		MinLength:        cfg.MinLength,
+		RequireUpper:     cfg.RequireUpper,
+		RequireLower:     cfg.RequireLower,
+		RequireDigit:     cfg.RequireDigit,
+		RequireSymbol:    cfg.RequireSymbol,
+		DisallowUserName: cfg.DisallowUserName,
+		BannedPasswords:  cfg.BannedPasswords,
+		HistorySize:      cfg.HistorySize,
+		MaxAge:           time.Duration(cfg.MaxAge) * 24 * time.Hour,
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/service"
+	"ginAdmin/pkg/logger"
+	"time"
+)
+
+// InitRecycleBinPurger 定期彻底删除回收站中超过保留天数的数据
+func InitRecycleBinPurger(ctx context.Context, srv *service.RecycleBin) func() {
+	cfg := config.C.RecycleBin
+	if cfg.RetentionDays <= 0 || cfg.PurgeInterval <= 0 {
+		return func() {}
+	}
+
+	// 定期任务不限定租户
+	ctx = contextx.NewSystem(ctx)
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Duration(cfg.PurgeInterval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := srv.Purge(ctx, time.Now().AddDate(0, 0, -cfg.RetentionDays))
+				if err != nil {
+					logger.WithContext(ctx).Errorf("Purge recycle bin error: %s", err.Error())
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
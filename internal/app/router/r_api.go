@@ -2,6 +2,7 @@ package router
 
 import (
 	_ "ginAdmin/docs"
+	"ginAdmin/internal/app/config"
 	"ginAdmin/internal/app/middleware"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -12,14 +13,22 @@ import (
 func (a *Router) RegisterAPI(app *gin.Engine) {
 	g := app.Group("/api")
 
-	g.Use(middleware.UserAuthMiddleware(a.Auth,
-		middleware.AllowPathPrefixSkipper("/api/v1/pub/login"),
+	g.Use(middleware.UserAuthMiddleware(a.Auth, a.APIKeySrv.Verify, a.TenantSrv.IsActive, a.UserSrv.GetSuperUserID,
+		middleware.AllowPathPrefixSkipper("/api/v1/pub/login", "/api/v1/pub/refresh-token", "/api/v1/pub/password/reset"),
+	))
+
+	g.Use(middleware.ImpersonationMiddleware(
+		middleware.AllowRoutePrefixNoSkipper(config.C.Impersonation.BlockedPaths...),
 	))
 
 	g.Use(middleware.CasbinMiddleware(a.CasbinEnforcer,
 		middleware.AllowPathPrefixSkipper("/api/v1/pub"),
 	))
 
+	g.Use(middleware.DataScopeMiddleware(a.DataScopeSrv.Resolve,
+		middleware.AllowPathPrefixSkipper("/api/v1/pub"),
+	))
+
 	g.Use(middleware.RateLimiterMiddleware())
 
 	v1 := g.Group("/v1")
@@ -32,6 +41,10 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 				gLogin.GET("captcha", a.LoginAPI.ResCaptcha)
 				gLogin.POST("", a.LoginAPI.Login)
 				gLogin.POST("exit", a.LoginAPI.Logout)
+				gLogin.POST("mfa", a.LoginAPI.VerifyMFA)
+				gLogin.POST("password", a.LoginAPI.ChangeExpiredPassword)
+				gLogin.GET("oidc/:provider", a.LoginAPI.OIDCAuthURL)
+				gLogin.POST("oidc/:provider/callback", a.LoginAPI.OIDCCallback)
 			}
 
 			gCurrent := pub.Group("current")
@@ -39,6 +52,21 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 				gCurrent.PUT("password", a.LoginAPI.UpdatePassword)
 				gCurrent.GET("user", a.LoginAPI.GetUserInfo)
 				gCurrent.GET("menutree", a.LoginAPI.QueryUserMenuTree)
+				gCurrent.GET("sessions", a.SessionAPI.QueryCurrent)
+				gCurrent.DELETE("sessions", a.SessionAPI.RevokeCurrentAll)
+				gCurrent.DELETE("sessions/:id", a.SessionAPI.RevokeCurrent)
+				gCurrent.GET("mfa", a.MFAAPI.Get)
+				gCurrent.POST("mfa/totp", a.MFAAPI.Enroll)
+				gCurrent.POST("mfa/totp/confirm", a.MFAAPI.Confirm)
+				gCurrent.DELETE("mfa/totp", a.MFAAPI.Disable)
+				gCurrent.GET("api-keys", a.APIKeyAPI.QueryCurrent)
+				gCurrent.POST("api-keys", a.APIKeyAPI.CreateCurrent)
+				gCurrent.DELETE("api-keys/:id", a.APIKeyAPI.DeleteCurrent)
+			}
+			gPassword := pub.Group("password")
+			{
+				gPassword.POST("reset", a.PasswordResetAPI.Request)
+				gPassword.POST("reset/confirm", a.PasswordResetAPI.Confirm)
 			}
 			pub.POST("/refresh-token", a.LoginAPI.RefreshToken)
 		}
@@ -52,7 +80,9 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 			gDemo.DELETE(":id", a.DemoAPI.Delete)
 			gDemo.PATCH(":id/enable", a.DemoAPI.Enable)
 			gDemo.PATCH(":id/disable", a.DemoAPI.Disable)
+			gDemo.PATCH(":id/restore", a.DemoAPI.Restore)
 		}
+		v1.GET("/demos.deleted", a.DemoAPI.QueryDeleted)
 
 		gMenu := v1.Group("menus")
 		{
@@ -63,9 +93,44 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 			gMenu.DELETE(":id", a.MenuAPI.Delete)
 			gMenu.PATCH(":id/enable", a.MenuAPI.Enable)
 			gMenu.PATCH(":id/disable", a.MenuAPI.Disable)
+			gMenu.PATCH(":id/restore", a.MenuAPI.Restore)
 		}
+		v1.GET("/menus.deleted", a.MenuAPI.QueryDeleted)
 		v1.GET("/menus.tree", a.MenuAPI.QueryTree)
 
+		gPermission := v1.Group("permissions")
+		{
+			gPermission.GET("explain", a.PermissionAPI.Explain)
+			gPermission.GET("apis", a.PermissionAPI.QueryAPIs)
+		}
+
+		gTenant := v1.Group("tenants")
+		{
+			gTenant.GET("", a.TenantAPI.Query)
+			gTenant.GET(":id", a.TenantAPI.Get)
+			gTenant.POST("", a.TenantAPI.Create)
+			gTenant.PUT(":id", a.TenantAPI.Update)
+			gTenant.DELETE(":id", a.TenantAPI.Delete)
+			gTenant.PATCH(":id/enable", a.TenantAPI.Enable)
+			gTenant.PATCH(":id/disable", a.TenantAPI.Disable)
+			gTenant.PATCH(":id/restore", a.TenantAPI.Restore)
+		}
+		v1.GET("/tenants.deleted", a.TenantAPI.QueryDeleted)
+
+		gDept := v1.Group("depts")
+		{
+			gDept.GET("", a.DeptAPI.Query)
+			gDept.GET(":id", a.DeptAPI.Get)
+			gDept.POST("", a.DeptAPI.Create)
+			gDept.PUT(":id", a.DeptAPI.Update)
+			gDept.DELETE(":id", a.DeptAPI.Delete)
+			gDept.PATCH(":id/enable", a.DeptAPI.Enable)
+			gDept.PATCH(":id/disable", a.DeptAPI.Disable)
+			gDept.PATCH(":id/restore", a.DeptAPI.Restore)
+		}
+		v1.GET("/depts.deleted", a.DeptAPI.QueryDeleted)
+		v1.GET("/depts.tree", a.DeptAPI.QueryTree)
+
 		gRole := v1.Group("roles")
 		{
 			gRole.GET("", a.RoleAPI.Query)
@@ -75,7 +140,9 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 			gRole.DELETE(":id", a.RoleAPI.Delete)
 			gRole.PATCH(":id/enable", a.RoleAPI.Enable)
 			gRole.PATCH(":id/disable", a.RoleAPI.Disable)
+			gRole.PATCH(":id/restore", a.RoleAPI.Restore)
 		}
+		v1.GET("/roles.deleted", a.RoleAPI.QueryDeleted)
 		v1.GET("/roles.select", a.RoleAPI.QuerySelect)
 
 		gUser := v1.Group("users")
@@ -87,8 +154,35 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 			gUser.DELETE(":id", a.UserAPI.Delete)
 			gUser.PATCH(":id/enable", a.UserAPI.Enable)
 			gUser.PATCH(":id/disable", a.UserAPI.Disable)
+			gUser.PATCH(":id/restore", a.UserAPI.Restore)
+			gUser.GET(":id/sessions", a.SessionAPI.Query)
+			gUser.DELETE(":id/sessions", a.SessionAPI.Revoke)
+			gUser.GET(":id/lockout", a.LockoutAPI.GetUser)
+			gUser.DELETE(":id/lockout", a.LockoutAPI.UnlockUser)
+			gUser.POST(":id/impersonate", a.LoginAPI.Impersonate)
+			gUser.GET(":id/api-keys", a.APIKeyAPI.Query)
+			gUser.POST(":id/api-keys", a.APIKeyAPI.Create)
+			gUser.DELETE(":id/api-keys/:key_id", a.APIKeyAPI.Delete)
+		}
+		v1.GET("/users.deleted", a.UserAPI.QueryDeleted)
+
+		gUserRole := v1.Group("user-roles")
+		{
+			gUserRole.GET("expiring", a.UserRoleAPI.QueryExpiring)
+		}
+
+		gAuditLog := v1.Group("audit-logs")
+		{
+			gAuditLog.GET("", a.AuditLogAPI.Query)
+		}
+
+		gLockout := v1.Group("lockouts")
+		{
+			gLockout.GET("ips/:ip", a.LockoutAPI.GetIP)
+			gLockout.DELETE("ips/:ip", a.LockoutAPI.UnlockIP)
 		}
 	}
 
+	app.GET("/.well-known/jwks.json", a.JWKSAPI.Get)
 	app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
@@ -2,6 +2,7 @@ package router
 
 import (
 	"ginAdmin/internal/app/api"
+	"ginAdmin/internal/app/service"
 	"ginAdmin/pkg/auth"
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
@@ -21,13 +22,28 @@ type IRouter interface {
 
 // Router 路由管理器
 type Router struct {
-	Auth           auth.Auther
-	CasbinEnforcer *casbin.SyncedEnforcer
-	DemoAPI        *api.Demo
-	LoginAPI       *api.Login
-	MenuAPI        *api.Menu
-	RoleAPI        *api.Role
-	UserAPI        *api.User
+	Auth             auth.Auther
+	APIKeyAPI        *api.APIKey
+	APIKeySrv        *service.APIKey
+	AuditLogAPI      *api.AuditLog
+	CasbinEnforcer   *casbin.SyncedEnforcer
+	DataScopeSrv     *service.DataScope
+	DemoAPI          *api.Demo
+	DeptAPI          *api.Dept
+	JWKSAPI          *api.JWKS
+	LockoutAPI       *api.Lockout
+	LoginAPI         *api.Login
+	MenuAPI          *api.Menu
+	MFAAPI           *api.MFA
+	PasswordResetAPI *api.PasswordReset
+	PermissionAPI    *api.Permission
+	RoleAPI          *api.Role
+	SessionAPI       *api.Session
+	TenantAPI        *api.Tenant
+	TenantSrv        *service.Tenant
+	UserAPI          *api.User
+	UserRoleAPI      *api.UserRole
+	UserSrv          *service.User
 }
 
 func (a *Router) Register(app *gin.Engine) error {
@@ -40,4 +56,3 @@ func (a *Router) Prefixes() []string {
 		"/api/",
 	}
 }
-
@@ -0,0 +1,55 @@
+package schema
+
+import "time"
+
+// APIKey API密钥对象
+type APIKey struct {
+	ID         string       `json:"id"`           // 唯一标识
+	UserID     string       `json:"user_id"`      // 所属用户ID
+	Name       string       `json:"name"`         // 名称
+	Prefix     string       `json:"prefix"`       // 可见前缀(用于识别密钥)
+	TokenHash  string       `json:"-"`            // 密钥哈希
+	Scopes     APIKeyScopes `json:"scopes"`       // 权限范围(为空时与所属用户的权限相同)
+	ExpiresAt  *time.Time   `json:"expires_at"`   // 到期时间(为空时永不过期)
+	LastUsedAt *time.Time   `json:"last_used_at"` // 最后使用时间
+	Creator    string       `json:"creator"`      // 创建者
+	CreatedAt  time.Time    `json:"created_at"`   // 创建时间
+}
+
+// IsExpired 是否已过期
+func (a *APIKey) IsExpired(now time.Time) bool {
+	return a.ExpiresAt != nil && !now.Before(*a.ExpiresAt)
+}
+
+// APIKeys API密钥列表
+type APIKeys []*APIKey
+
+// APIKeyScope API密钥权限范围(与菜单动作资源的匹配规则相同)
+type APIKeyScope struct {
+	Method string `json:"method" binding:"required"` // 请求方式(正则匹配，例如：GET|POST)
+	Path   string `json:"path" binding:"required"`   // 请求路径(例如：/api/v1/users/:id)
+}
+
+// APIKeyScopes API密钥权限范围列表
+type APIKeyScopes []*APIKeyScope
+
+// APIKeyCreateParam 创建API密钥请求参数
+type APIKeyCreateParam struct {
+	Name      string       `json:"name" binding:"required,max=64"` // 名称
+	Scopes    APIKeyScopes `json:"scopes" binding:"dive"`          // 权限范围(为空时与所属用户的权限相同)
+	ExpiresAt *time.Time   `json:"expires_at"`                     // 到期时间(为空时永不过期)
+}
+
+// APIKeyToken 创建API密钥的结果
+type APIKeyToken struct {
+	APIKey
+	Token string `json:"token"` // 完整密钥(只在创建时返回一次，请妥善保存)
+}
+
+// APIKeyAuth API密钥认证结果
+type APIKeyAuth struct {
+	KeyID    string       // 密钥ID
+	UserID   string       // 所属用户ID
+	TenantID string       // 所属用户的租户ID
+	Scopes   APIKeyScopes // 权限范围
+}
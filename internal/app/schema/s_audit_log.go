@@ -0,0 +1,51 @@
+package schema
+
+import "time"
+
+// AuditLog 数据变更审计对象
+type AuditLog struct {
+	ID        string       `json:"id"`         // 唯一标识
+	UserID    string       `json:"user_id"`    // 操作用户ID(模拟登录时为被模拟的用户)
+	ActorID   string       `json:"actor_id"`   // 实际操作者ID
+	TraceID   string       `json:"trace_id"`   // 跟踪ID
+	Table     string       `json:"table"`      // 数据表(不含表名前缀，例如：user)
+	RecordID  string       `json:"record_id"`  // 数据主键
+	Action    string       `json:"action"`     // 变更类型(create:新增 update:修改 delete:删除)
+	Changes   AuditChanges `json:"changes"`    // 字段变更
+	CreatedAt time.Time    `json:"created_at"` // 变更时间
+}
+
+// AuditChange 字段变更(新增时old为空，删除时new为空，脱敏字段的值为******)
+type AuditChange struct {
+	Column string      `json:"column"` // 字段名
+	Old    interface{} `json:"old"`    // 变更前的值
+	New    interface{} `json:"new"`    // 变更后的值
+}
+
+// AuditChanges 字段变更列表(按字段名排序)
+type AuditChanges []*AuditChange
+
+// AuditLogQueryParam 查询条件
+type AuditLogQueryParam struct {
+	PaginationParam
+	Table     string    `form:"table"`                                       // 数据表(不含表名前缀)
+	RecordID  string    `form:"recordID"`                                    // 数据主键
+	ActorID   string    `form:"actorID"`                                     // 实际操作者ID
+	Action    string    `form:"action"`                                      // 变更类型
+	StartTime time.Time `form:"startTime" time_format:"2006-01-02 15:04:05"` // 开始时间
+	EndTime   time.Time `form:"endTime" time_format:"2006-01-02 15:04:05"`   // 结束时间
+}
+
+// AuditLogQueryOptions 查询可选参数项
+type AuditLogQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// AuditLogQueryResult 查询结果
+type AuditLogQueryResult struct {
+	Data       AuditLogs
+	PageResult *PaginationResult
+}
+
+// AuditLogs 数据变更审计列表
+type AuditLogs []*AuditLog
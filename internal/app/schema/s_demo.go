@@ -5,6 +5,7 @@ import "time"
 // Demo 示例对象
 type Demo struct {
 	ID        string    `json:"id"`                                    // 唯一标识
+	TenantID  string    `json:"tenant_id"`                             // 租户ID
 	Code      string    `json:"code" binding:"required"`               // 编号
 	Name      string    `json:"name" binding:"required"`               // 名称
 	Memo      string    `json:"memo"`                                  // 备注
@@ -12,6 +13,7 @@ type Demo struct {
 	Creator   string    `json:"creator"`                               // 创建者
 	CreatedAt time.Time `json:"created_at"`                            // 创建时间
 	UpdatedAt time.Time `json:"updated_at"`                            // 更新时间
+	Version   int64     `json:"version"`                               // 版本号
 }
 
 // DemoQueryParam 查询条件
@@ -0,0 +1,116 @@
+package schema
+
+import (
+	"ginAdmin/pkg/util/json"
+	"time"
+)
+
+// Dept 部门对象
+type Dept struct {
+	ID         string    `json:"id"`                                    // 唯一标识
+	TenantID   string    `json:"tenant_id"`                             // 租户ID
+	Name       string    `json:"name" binding:"required"`               // 部门名称
+	Sequence   int       `json:"sequence"`                              // 排序值
+	ParentID   string    `json:"parent_id"`                             // 父级ID
+	ParentPath string    `json:"parent_path"`                           // 父级路径
+	Status     int       `json:"status" binding:"required,max=2,min=1"` // 状态(1:启用 2:禁用)
+	Memo       string    `json:"memo"`                                  // 备注
+	Creator    string    `json:"creator"`                               // 创建者
+	CreatedAt  time.Time `json:"created_at"`                            // 创建时间
+	UpdatedAt  time.Time `json:"updated_at"`                            // 更新时间
+}
+
+func (a *Dept) String() string {
+	return json.MarshalToString(a)
+}
+
+// DeptQueryParam 查询条件
+type DeptQueryParam struct {
+	PaginationParam
+	IDs              []string `form:"-"`          // 唯一标识列表
+	Name             string   `form:"-"`          // 部门名称
+	PrefixParentPath string   `form:"-"`          // 父级路径(前缀模糊查询)
+	QueryValue       string   `form:"queryValue"` // 模糊查询
+	ParentID         *string  `form:"parentID"`   // 父级内码
+	Status           int      `form:"status"`     // 状态(1:启用 2:禁用)
+}
+
+// DeptQueryOptions 查询可选参数项
+type DeptQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// DeptQueryResult 查询结果
+type DeptQueryResult struct {
+	Data       Depts
+	PageResult *PaginationResult
+}
+
+// Depts 部门列表
+type Depts []*Dept
+
+// ToIDs 转换为唯一标识列表
+func (a Depts) ToIDs() []string {
+	idList := make([]string, len(a))
+	for i, item := range a {
+		idList[i] = item.ID
+	}
+	return idList
+}
+
+// ToTree 转换为部门树
+func (a Depts) ToTree() DeptTrees {
+	list := make(DeptTrees, len(a))
+	for i, item := range a {
+		list[i] = &DeptTree{
+			ID:         item.ID,
+			Name:       item.Name,
+			ParentID:   item.ParentID,
+			ParentPath: item.ParentPath,
+			Sequence:   item.Sequence,
+			Status:     item.Status,
+		}
+	}
+	return list.ToTree()
+}
+
+// ----------------------------------------DeptTree--------------------------------------
+
+// DeptTree 部门树
+type DeptTree struct {
+	ID         string     `json:"id"`                 // 唯一标识
+	Name       string     `json:"name"`               // 部门名称
+	ParentID   string     `json:"parent_id"`          // 父级ID
+	ParentPath string     `json:"parent_path"`        // 父级路径
+	Sequence   int        `json:"sequence"`           // 排序值
+	Status     int        `json:"status"`             // 状态(1:启用 2:禁用)
+	Children   *DeptTrees `json:"children,omitempty"` // 子级树
+}
+
+// DeptTrees 部门树列表
+type DeptTrees []*DeptTree
+
+// ToTree 转换为树形结构
+func (a DeptTrees) ToTree() DeptTrees {
+	mi := make(map[string]*DeptTree)
+	for _, item := range a {
+		mi[item.ID] = item
+	}
+
+	var list DeptTrees
+	for _, item := range a {
+		if item.ParentID == "" {
+			list = append(list, item)
+			continue
+		}
+		if pitem, ok := mi[item.ParentID]; ok {
+			if pitem.Children == nil {
+				children := DeptTrees{item}
+				pitem.Children = &children
+				continue
+			}
+			*pitem.Children = append(*pitem.Children, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,8 @@
+package schema
+
+// LoginLockout 登录失败锁定状态
+type LoginLockout struct {
+	Failures  int64 `json:"failures"`   // 统计窗口内的失败次数
+	Locked    bool  `json:"locked"`     // 是否处于锁定状态
+	LockedFor int64 `json:"locked_for"` // 剩余锁定时长(单位秒)
+}
@@ -2,24 +2,27 @@ package schema
 
 // LoginParam 登录参数
 type LoginParam struct {
-	UserName    string `json:"user_name" binding:"required"`    // 用户名
-	Password    string `json:"password" binding:"required"`     // 密码(md5加密)
-	CaptchaID   string `json:"captcha_id" binding:"required"`   // 验证码ID
-	CaptchaCode string `json:"captcha_code" binding:"required"` // 验证码
+	TenantCode  string `json:"tenant_code"`                  // 租户编号(为空时登录默认租户)
+	UserName    string `json:"user_name" binding:"required"` // 用户名
+	Password    string `json:"password" binding:"required"`  // 密码
+	CaptchaID   string `json:"captcha_id"`                   // 验证码ID(登录失败次数达到阈值后必填)
+	CaptchaCode string `json:"captcha_code"`                 // 验证码(登录失败次数达到阈值后必填)
 }
 
 // UserLoginInfo 用户登录信息
 type UserLoginInfo struct {
-	UserID   string `json:"user_id"`   // 用户ID
-	UserName string `json:"user_name"` // 用户名
-	RealName string `json:"real_name"` // 真实姓名
-	Roles    Roles  `json:"roles"`     // 角色列表
+	UserID   string `json:"user_id"`            // 用户ID
+	UserName string `json:"user_name"`          // 用户名
+	RealName string `json:"real_name"`          // 真实姓名
+	IsSuper  bool   `json:"is_super"`           // 是否超级管理员
+	Roles    Roles  `json:"roles"`              // 角色列表
+	ActorID  string `json:"actor_id,omitempty"` // 实际操作者ID(模拟登录时为管理员ID)
 }
 
 // UpdatePasswordParam 更新密码请求参数
 type UpdatePasswordParam struct {
-	OldPassword string `json:"old_password" binding:"required"` // 旧密码(md5加密)
-	NewPassword string `json:"new_password" binding:"required"` // 新密码(md5加密)
+	OldPassword string `json:"old_password" binding:"required"` // 旧密码
+	NewPassword string `json:"new_password" binding:"required"` // 新密码(需符合密码策略)
 }
 
 // LoginCaptcha 登录验证码
@@ -29,7 +32,39 @@ type LoginCaptcha struct {
 
 // LoginTokenInfo 登录令牌信息
 type LoginTokenInfo struct {
-	AccessToken string `json:"access_token"` // 访问令牌
-	TokenType   string `json:"token_type"`   // 令牌类型
-	ExpiresAt   int64  `json:"expires_at"`   // 令牌到期时间戳
+	AccessToken  string `json:"access_token"`  // 访问令牌
+	RefreshToken string `json:"refresh_token"` // 刷新令牌(仅能使用一次)
+	TokenType    string `json:"token_type"`    // 令牌类型
+	ExpiresAt    int64  `json:"expires_at"`    // 令牌到期时间戳
+}
+
+// RefreshTokenParam 刷新令牌请求参数
+type RefreshTokenParam struct {
+	RefreshToken string `json:"refresh_token" binding:"required"` // 刷新令牌
+}
+
+// LoginMFAChallenge 登录两步验证挑战(用户启用两步验证时由登录接口返回)
+type LoginMFAChallenge struct {
+	MFARequired bool   `json:"mfa_required"` // 是否需要两步验证
+	MFAToken    string `json:"mfa_token"`    // 挑战令牌(仅用于两步验证)
+	ExpiresAt   int64  `json:"expires_at"`   // 挑战令牌到期时间戳
+}
+
+// LoginMFAParam 登录两步验证请求参数
+type LoginMFAParam struct {
+	MFAToken string `json:"mfa_token" binding:"required"` // 挑战令牌
+	Code     string `json:"code" binding:"required"`      // TOTP验证码或恢复码
+}
+
+// LoginPasswordChallenge 登录修改密码挑战(用户密码过期时由登录接口返回)
+type LoginPasswordChallenge struct {
+	PasswordExpired bool   `json:"password_expired"` // 密码是否已过期
+	PasswordToken   string `json:"password_token"`   // 挑战令牌(仅用于修改过期密码)
+	ExpiresAt       int64  `json:"expires_at"`       // 挑战令牌到期时间戳
+}
+
+// LoginPasswordParam 登录修改过期密码请求参数
+type LoginPasswordParam struct {
+	PasswordToken string `json:"password_token" binding:"required"` // 挑战令牌
+	NewPassword   string `json:"new_password" binding:"required"`   // 新密码(需符合密码策略)
 }
@@ -9,6 +9,7 @@ import (
 // Menu 菜单对象
 type Menu struct {
 	ID         string      `json:"id"`                                         // 唯一标识
+	TenantID   string      `json:"tenant_id"`                                  // 租户ID
 	Name       string      `json:"name" binding:"required"`                    // 菜单名称
 	Sequence   int         `json:"sequence"`                                   // 排序值
 	Icon       string      `json:"icon"`                                       // 菜单图标
@@ -21,6 +22,7 @@ type Menu struct {
 	Creator    string      `json:"creator"`                                    // 创建者
 	CreatedAt  time.Time   `json:"created_at"`                                 // 创建时间
 	UpdatedAt  time.Time   `json:"updated_at"`                                 // 更新时间
+	Version    int64       `json:"version"`                                    // 版本号
 	Actions    MenuActions `json:"actions"`                                    // 动作列表
 }
 
@@ -242,8 +244,9 @@ type MenuActionResource struct {
 // MenuActionResourceQueryParam 查询条件
 type MenuActionResourceQueryParam struct {
 	PaginationParam
-	MenuID  string   // 菜单ID
-	MenuIDs []string // 菜单ID列表
+	MenuID    string   // 菜单ID
+	MenuIDs   []string // 菜单ID列表
+	ActionIDs []string // 动作ID列表
 }
 
 // MenuActionResourceQueryOptions 查询可选参数项
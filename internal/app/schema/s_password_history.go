@@ -0,0 +1,23 @@
+package schema
+
+import "time"
+
+// PasswordHistory 历史密码对象
+type PasswordHistory struct {
+	ID        string    `json:"id"`         // 唯一标识
+	UserID    string    `json:"user_id"`    // 用户ID
+	Password  string    `json:"-"`          // 密码哈希
+	CreatedAt time.Time `json:"created_at"` // 创建时间
+}
+
+// PasswordHistories 历史密码列表
+type PasswordHistories []*PasswordHistory
+
+// ToIDs 转换为唯一标识列表
+func (a PasswordHistories) ToIDs() []string {
+	ids := make([]string, len(a))
+	for i, item := range a {
+		ids[i] = item.ID
+	}
+	return ids
+}
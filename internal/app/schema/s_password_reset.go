@@ -0,0 +1,24 @@
+package schema
+
+import "time"
+
+// PasswordReset 密码重置对象
+type PasswordReset struct {
+	ID        string    `json:"id"`         // 唯一标识
+	UserID    string    `json:"user_id"`    // 用户ID
+	TokenHash string    `json:"-"`          // 重置令牌哈希
+	ExpiresAt time.Time `json:"expires_at"` // 到期时间
+	CreatedAt time.Time `json:"created_at"` // 创建时间
+}
+
+// PasswordResetRequestParam 申请密码重置请求参数
+type PasswordResetRequestParam struct {
+	TenantCode string `json:"tenant_code"`                  // 租户编号(为空时为默认租户)
+	UserName   string `json:"user_name" binding:"required"` // 用户名
+}
+
+// PasswordResetConfirmParam 确认密码重置请求参数
+type PasswordResetConfirmParam struct {
+	Token       string `json:"token" binding:"required"`        // 重置令牌
+	NewPassword string `json:"new_password" binding:"required"` // 新密码(需符合密码策略)
+}
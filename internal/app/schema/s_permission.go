@@ -0,0 +1,54 @@
+package schema
+
+// PermissionExplainParam 权限诊断参数
+type PermissionExplainParam struct {
+	UserID string `form:"userID" binding:"required"` // 用户ID
+	Path   string `form:"path" binding:"required"`   // 请求路径
+	Method string `form:"method" binding:"required"` // 请求方式
+}
+
+// PermissionExplain 权限诊断结果
+type PermissionExplain struct {
+	UserID   string             `json:"user_id"`   // 用户ID
+	TenantID string             `json:"tenant_id"` // 租户ID(casbin的域)
+	Path     string             `json:"path"`      // 请求路径
+	Method   string             `json:"method"`    // 请求方式
+	Allowed  bool               `json:"allowed"`   // 是否允许访问
+	Reason   string             `json:"reason"`    // 判定说明
+	Roles    []*PermissionRole  `json:"roles"`     // 用户经过的角色(包括继承的角色)
+	Policies []string           `json:"policies"`  // 匹配的策略规则
+	Grants   []*PermissionGrant `json:"grants"`    // 授予权限的菜单动作资源
+}
+
+// PermissionRole 权限诊断中的角色
+type PermissionRole struct {
+	ID     string `json:"id"`     // 角色ID
+	Name   string `json:"name"`   // 角色名称
+	Status int    `json:"status"` // 状态(1:启用 2:禁用)
+}
+
+// PermissionGrant 授予权限的菜单动作资源
+type PermissionGrant struct {
+	RoleID     string `json:"role_id"`     // 角色ID
+	RoleName   string `json:"role_name"`   // 角色名称
+	MenuID     string `json:"menu_id"`     // 菜单ID
+	MenuName   string `json:"menu_name"`   // 菜单名称
+	ActionID   string `json:"action_id"`   // 动作ID
+	ActionCode string `json:"action_code"` // 动作编号
+	ActionName string `json:"action_name"` // 动作名称
+	Method     string `json:"method"`      // 资源请求方式
+	Path       string `json:"path"`        // 资源请求路径
+}
+
+// PermissionAPIQueryParam 可调用接口查询条件(用户ID与角色ID二选一)
+type PermissionAPIQueryParam struct {
+	UserID string `form:"userID"` // 用户ID
+	RoleID string `form:"roleID"` // 角色ID
+}
+
+// PermissionAPI 可调用的接口
+type PermissionAPI struct {
+	Method   string   `json:"method"`   // 请求方式(支持正则)
+	Path     string   `json:"path"`     // 请求路径(支持/:id匹配)
+	Subjects []string `json:"subjects"` // 授予该接口的主体(角色ID或用户ID)
+}
@@ -0,0 +1,22 @@
+package schema
+
+import "time"
+
+// RecycleItem 回收站数据项(已删除的数据)
+type RecycleItem struct {
+	ID        string    `json:"id"`         // 唯一标识
+	Name      string    `json:"name"`       // 名称
+	DeletedAt time.Time `json:"deleted_at"` // 删除时间
+}
+
+// RecycleQueryParam 回收站查询条件
+type RecycleQueryParam struct {
+	PaginationParam
+	QueryValue string `form:"queryValue"` // 模糊查询(名称)
+}
+
+// RecycleQueryResult 回收站查询结果
+type RecycleQueryResult struct {
+	Data       []*RecycleItem
+	PageResult *PaginationResult
+}
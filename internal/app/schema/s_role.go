@@ -2,17 +2,38 @@ package schema
 
 import "time"
 
+// 数据权限范围
+const (
+	DataScopeAll        = 1 // 全部数据
+	DataScopeCustom     = 2 // 自定义部门数据
+	DataScopeDept       = 3 // 本部门数据
+	DataScopeDeptAndSub = 4 // 本部门及以下数据
+	DataScopeSelf       = 5 // 仅本人数据
+)
+
+// DataScope 数据权限范围(用户所有启用角色的数据权限合并结果，始终包含本人数据)
+type DataScope struct {
+	All     bool     // 全部数据
+	UserID  string   // 用户ID
+	DeptIDs []string // 可访问的部门ID列表
+}
+
 // Role 角色对象
 type Role struct {
 	ID        string    `json:"id"`                                    // 唯一标识
+	TenantID  string    `json:"tenant_id"`                             // 租户ID
 	Name      string    `json:"name" binding:"required"`               // 角色名称
 	Sequence  int       `json:"sequence"`                              // 排序值
 	Memo      string    `json:"memo"`                                  // 备注
 	Status    int       `json:"status" binding:"required,max=2,min=1"` // 状态(1:启用 2:禁用)
+	DataScope int       `json:"data_scope" binding:"max=5,min=0"`      // 数据权限范围(1:全部 2:自定义部门 3:本部门 4:本部门及以下 5:仅本人)
 	Creator   string    `json:"creator"`                               // 创建者
 	CreatedAt time.Time `json:"created_at"`                            // 创建时间
 	UpdatedAt time.Time `json:"updated_at"`                            // 更新时间
+	Version   int64     `json:"version"`                               // 版本号
 	RoleMenus RoleMenus `json:"role_menus" binding:"required,gt=0"`    // 角色菜单列表
+	DeptIDs   []string  `json:"dept_ids"`                              // 自定义数据权限的部门ID列表
+	ParentIDs []string  `json:"parent_ids"`                            // 继承的父级角色ID列表
 }
 
 // RoleQueryParam 查询条件
@@ -21,7 +42,7 @@ type RoleQueryParam struct {
 	IDs        []string `form:"-"`          // 唯一标识列表
 	Name       string   `form:"-"`          // 角色名称
 	QueryValue string   `form:"queryValue"` // 模糊查询
-	UserID     string   `form:"-"`          // 用户ID
+	UserID     string   `form:"-"`          // 用户ID(仅包含当前有效的授权)
 	Status     int      `form:"status"`     // 状态(1:启用 2:禁用)
 }
 
@@ -135,3 +156,127 @@ func (a RoleMenus) ToActionIDs() []string {
 	}
 	return idList
 }
+
+// ----------------------------------------RoleDept--------------------------------------
+
+// RoleDept 角色自定义数据权限部门对象
+type RoleDept struct {
+	ID     string `json:"id"`      // 唯一标识
+	RoleID string `json:"role_id"` // 角色ID
+	DeptID string `json:"dept_id"` // 部门ID
+}
+
+// RoleDeptQueryParam 查询条件
+type RoleDeptQueryParam struct {
+	PaginationParam
+	RoleID  string   // 角色ID
+	RoleIDs []string // 角色ID列表
+}
+
+// RoleDeptQueryOptions 查询可选参数项
+type RoleDeptQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// RoleDeptQueryResult 查询结果
+type RoleDeptQueryResult struct {
+	Data       RoleDepts
+	PageResult *PaginationResult
+}
+
+// RoleDepts 角色部门列表
+type RoleDepts []*RoleDept
+
+// ToDeptIDs 转换为部门ID列表
+func (a RoleDepts) ToDeptIDs() []string {
+	var idList []string
+	m := make(map[string]struct{})
+	for _, item := range a {
+		if _, ok := m[item.DeptID]; ok {
+			continue
+		}
+		idList = append(idList, item.DeptID)
+		m[item.DeptID] = struct{}{}
+	}
+	return idList
+}
+
+// ----------------------------------------RoleParent--------------------------------------
+
+// RoleParent 角色继承关系对象(角色拥有父级角色的所有权限)
+type RoleParent struct {
+	ID       string `json:"id"`        // 唯一标识
+	RoleID   string `json:"role_id"`   // 角色ID
+	ParentID string `json:"parent_id"` // 父级角色ID
+}
+
+// RoleParentQueryParam 查询条件
+type RoleParentQueryParam struct {
+	PaginationParam
+	RoleID    string   // 角色ID
+	RoleIDs   []string // 角色ID列表
+	ParentID  string   // 父级角色ID
+	ParentIDs []string // 父级角色ID列表
+}
+
+// RoleParentQueryOptions 查询可选参数项
+type RoleParentQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// RoleParentQueryResult 查询结果
+type RoleParentQueryResult struct {
+	Data       RoleParents
+	PageResult *PaginationResult
+}
+
+// RoleParents 角色继承关系列表
+type RoleParents []*RoleParent
+
+// ToParentIDs 转换为父级角色ID列表
+func (a RoleParents) ToParentIDs() []string {
+	var idList []string
+	m := make(map[string]struct{})
+	for _, item := range a {
+		if _, ok := m[item.ParentID]; ok {
+			continue
+		}
+		idList = append(idList, item.ParentID)
+		m[item.ParentID] = struct{}{}
+	}
+	return idList
+}
+
+// ToRoleIDMap 转换为角色ID到父级角色ID列表的映射
+func (a RoleParents) ToRoleIDMap() map[string][]string {
+	m := make(map[string][]string)
+	for _, item := range a {
+		m[item.RoleID] = append(m[item.RoleID], item.ParentID)
+	}
+	return m
+}
+
+// Ancestors 获取角色的所有祖先角色ID(不包含角色本身，按广度优先顺序)
+func (a RoleParents) Ancestors(roleIDs ...string) []string {
+	mParents := a.ToRoleIDMap()
+	visited := make(map[string]struct{}, len(roleIDs))
+	for _, roleID := range roleIDs {
+		visited[roleID] = struct{}{}
+	}
+
+	var list []string
+	queue := append([]string{}, roleIDs...)
+	for len(queue) > 0 {
+		roleID := queue[0]
+		queue = queue[1:]
+		for _, parentID := range mParents[roleID] {
+			if _, ok := visited[parentID]; ok {
+				continue
+			}
+			visited[parentID] = struct{}{}
+			list = append(list, parentID)
+			queue = append(queue, parentID)
+		}
+	}
+	return list
+}
@@ -0,0 +1,14 @@
+package schema
+
+// Session 登录会话
+type Session struct {
+	ID        string `json:"id"`         // 会话ID
+	IP        string `json:"ip"`         // 登录IP
+	UserAgent string `json:"user_agent"` // 客户端标识
+	IssuedAt  int64  `json:"issued_at"`  // 登录时间戳
+	ActorID   string `json:"actor_id"`   // 模拟登录的实际操作者ID(被模拟用户的会话)
+	TargetID  string `json:"target_id"`  // 模拟登录的目标用户ID(实际操作者的会话)
+}
+
+// Sessions 登录会话列表
+type Sessions []*Session
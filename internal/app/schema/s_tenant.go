@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"ginAdmin/pkg/util/json"
+	"time"
+)
+
+// DefaultTenantID 默认租户ID(平台租户，升级前的数据均属于该租户，只有该租户可以管理其他租户)
+const DefaultTenantID = "default"
+
+// SystemTenantID 系统任务的租户标识(系统任务变更的数据无法确定所属租户时，审计记录使用该标识)
+const SystemTenantID = "system"
+
+// Tenant 租户对象
+type Tenant struct {
+	ID        string    `json:"id"`                                    // 唯一标识
+	Code      string    `json:"code" binding:"required"`               // 租户编号(登录时用于区分租户)
+	Name      string    `json:"name" binding:"required"`               // 租户名称
+	Memo      string    `json:"memo"`                                  // 备注
+	Status    int       `json:"status" binding:"required,max=2,min=1"` // 状态(1:启用 2:停用)
+	Creator   string    `json:"creator"`                               // 创建者
+	CreatedAt time.Time `json:"created_at"`                            // 创建时间
+	UpdatedAt time.Time `json:"updated_at"`                            // 更新时间
+}
+
+func (a *Tenant) String() string {
+	return json.MarshalToString(a)
+}
+
+// TenantCreateParam 创建租户参数(同时初始化租户的菜单及管理员)
+type TenantCreateParam struct {
+	Tenant
+	AdminUserName string `json:"admin_user_name" binding:"required"` // 管理员用户名
+	AdminRealName string `json:"admin_real_name"`                    // 管理员真实姓名
+	AdminPassword string `json:"admin_password" binding:"required"`  // 管理员密码
+}
+
+// TenantQueryParam 查询条件
+type TenantQueryParam struct {
+	PaginationParam
+	Code       string `form:"-"`          // 租户编号
+	QueryValue string `form:"queryValue"` // 模糊查询
+	Status     int    `form:"status"`     // 状态(1:启用 2:停用)
+}
+
+// TenantQueryOptions 查询可选参数项
+type TenantQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// TenantQueryResult 查询结果
+type TenantQueryResult struct {
+	Data       Tenants
+	PageResult *PaginationResult
+}
+
+// Tenants 租户列表
+type Tenants []*Tenant
@@ -1,42 +1,28 @@
 package schema
 
 import (
-	"context"
-	"ginAdmin/internal/app/config"
-	"ginAdmin/pkg/util/hash"
 	"ginAdmin/pkg/util/json"
 	"ginAdmin/pkg/util/structure"
 	"time"
 )
 
-// GetRootUser 获取root用户
-func GetRootUser() *User {
-	user := config.C.Root
-	return &User{
-		ID:       user.UserName,
-		UserName: user.UserName,
-		RealName: user.RealName,
-		Password: hash.MD5String(user.Password),
-	}
-}
-
-// CheckIsRootUser 检查是否是root用户
-func CheckIsRootUser(ctx context.Context, userID string) bool {
-	return GetRootUser().ID == userID
-}
-
 // User 用户对象
 type User struct {
-	ID        string    `json:"id"`                                    // 唯一标识
-	UserName  string    `json:"user_name" binding:"required"`          // 用户名
-	RealName  string    `json:"real_name" binding:"required"`          // 真实姓名
-	Password  string    `json:"password"`                              // 密码
-	Phone     string    `json:"phone"`                                 // 手机号
-	Email     string    `json:"email"`                                 // 邮箱
-	Status    int       `json:"status" binding:"required,max=2,min=1"` // 用户状态(1:启用 2:停用)
-	Creator   string    `json:"creator"`                               // 创建者
-	CreatedAt time.Time `json:"created_at"`                            // 创建时间
-	UserRoles UserRoles `json:"user_roles" binding:"required,gt=0"`    // 角色授权
+	ID                string     `json:"id"`                                    // 唯一标识
+	TenantID          string     `json:"tenant_id"`                             // 租户ID
+	UserName          string     `json:"user_name" binding:"required"`          // 用户名
+	RealName          string     `json:"real_name" binding:"required"`          // 真实姓名
+	Password          string     `json:"password"`                              // 密码
+	Phone             string     `json:"phone"`                                 // 手机号
+	Email             string     `json:"email"`                                 // 邮箱
+	Status            int        `json:"status" binding:"required,max=2,min=1"` // 用户状态(1:启用 2:停用)
+	IsSuper           bool       `json:"is_super"`                              // 是否超级管理员(仅能通过初始化创建)
+	DeptID            string     `json:"dept_id"`                               // 所属部门ID
+	PasswordChangedAt *time.Time `json:"password_changed_at"`                   // 密码修改时间
+	Creator           string     `json:"creator"`                               // 创建者
+	CreatedAt         time.Time  `json:"created_at"`                            // 创建时间
+	Version           int64      `json:"version"`                               // 版本号
+	UserRoles         UserRoles  `json:"user_roles" binding:"required,gt=0"`    // 角色授权
 }
 
 func (a *User) String() string {
@@ -52,10 +38,13 @@ func (a *User) CleanSecure() *User {
 // UserQueryParam 查询条件
 type UserQueryParam struct {
 	PaginationParam
+	IDs        []string `form:"-"`          // 唯一标识列表
 	UserName   string   `form:"userName"`   // 用户名
 	QueryValue string   `form:"queryValue"` // 模糊查询
 	Status     int      `form:"status"`     // 用户状态(1:启用 2:停用)
+	DeptID     string   `form:"deptID"`     // 所属部门ID
 	RoleIDs    []string `form:"-"`          // 角色ID列表
+	OnlySuper  bool     `form:"-"`          // 仅查询超级管理员
 }
 
 // UserQueryOptions 查询可选参数项
@@ -110,16 +99,45 @@ func (a Users) ToUserShows(mUserRoles map[string]UserRoles, mRoles map[string]*R
 
 // UserRole 用户角色
 type UserRole struct {
-	ID     string `json:"id"`      // 唯一标识
-	UserID string `json:"user_id"` // 用户ID
-	RoleID string `json:"role_id"` // 角色ID
+	ID         string     `json:"id"`          // 唯一标识
+	UserID     string     `json:"user_id"`     // 用户ID
+	RoleID     string     `json:"role_id"`     // 角色ID
+	ValidFrom  *time.Time `json:"valid_from"`  // 生效时间(为空表示立即生效)
+	ValidUntil *time.Time `json:"valid_until"` // 失效时间(为空表示永久有效)
+}
+
+// IsValid 授权在指定时间是否有效
+func (a *UserRole) IsValid(t time.Time) bool {
+	if a.ValidFrom != nil && t.Before(*a.ValidFrom) {
+		return false
+	}
+	return a.ValidUntil == nil || t.Before(*a.ValidUntil)
+}
+
+// EqualValidity 有效期是否一致
+func (a *UserRole) EqualValidity(b *UserRole) bool {
+	return equalTime(a.ValidFrom, b.ValidFrom) && equalTime(a.ValidUntil, b.ValidUntil)
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
 }
 
 // UserRoleQueryParam 查询条件
 type UserRoleQueryParam struct {
 	PaginationParam
-	UserID  string   // 用户ID
-	UserIDs []string // 用户ID列表
+	UserID        string     // 用户ID
+	UserIDs       []string   // 用户ID列表
+	RoleID        string     // 角色ID
+	ValidAt       *time.Time // 仅查询在指定时间有效的授权
+	ExpireAfter   *time.Time // 失效时间晚于指定时间
+	ExpireBefore  *time.Time // 失效时间不晚于指定时间
+	ChangedAfter  *time.Time // 生效或失效时间晚于指定时间
+	ChangedBefore *time.Time // 生效或失效时间不晚于指定时间
+	InDataScope   bool       // 仅查询数据权限范围内用户的授权
 }
 
 // UserRoleQueryOptions 查询可选参数项
@@ -163,6 +181,46 @@ func (a UserRoles) ToUserIDMap() map[string]UserRoles {
 	return m
 }
 
+// ToUserIDs 转换为用户ID列表(去除重复项)
+func (a UserRoles) ToUserIDs() []string {
+	var list []string
+	m := make(map[string]struct{})
+	for _, item := range a {
+		if _, ok := m[item.UserID]; ok {
+			continue
+		}
+		m[item.UserID] = struct{}{}
+		list = append(list, item.UserID)
+	}
+	return list
+}
+
+// ----------------------------------------UserRoleExpiring--------------------------------------
+
+// UserRoleExpiringParam 即将到期的角色授权查询条件
+type UserRoleExpiringParam struct {
+	PaginationParam
+	Days int `form:"days,default=7" binding:"min=1,max=365"` // 到期天数(默认7天)
+}
+
+// UserRoleExpiring 即将到期的角色授权
+type UserRoleExpiring struct {
+	ID         string     `json:"id"`          // 唯一标识
+	UserID     string     `json:"user_id"`     // 用户ID
+	UserName   string     `json:"user_name"`   // 用户名
+	RealName   string     `json:"real_name"`   // 真实姓名
+	RoleID     string     `json:"role_id"`     // 角色ID
+	RoleName   string     `json:"role_name"`   // 角色名称
+	ValidFrom  *time.Time `json:"valid_from"`  // 生效时间
+	ValidUntil *time.Time `json:"valid_until"` // 失效时间
+}
+
+// UserRoleExpiringQueryResult 即将到期的角色授权查询结果
+type UserRoleExpiringQueryResult struct {
+	Data       []*UserRoleExpiring
+	PageResult *PaginationResult
+}
+
 // ----------------------------------------UserShow--------------------------------------
 
 // UserShow 用户显示项
@@ -174,6 +232,7 @@ type UserShow struct {
 	Email     string    `json:"email"`      // 邮箱
 	Status    int       `json:"status"`     // 用户状态(1:启用 2:停用)
 	CreatedAt time.Time `json:"created_at"` // 创建时间
+	Version   int64     `json:"version"`    // 版本号
 	Roles     []*Role   `json:"roles"`      // 授权角色列表
 }
 
@@ -0,0 +1,25 @@
+package schema
+
+import "time"
+
+// UserIdentity 用户外部身份对象
+type UserIdentity struct {
+	ID        string    `json:"id"`         // 唯一标识
+	UserID    string    `json:"user_id"`    // 用户ID
+	Provider  string    `json:"provider"`   // 身份提供者
+	Subject   string    `json:"subject"`    // 身份提供者中的用户唯一标识
+	Email     string    `json:"email"`      // 邮箱
+	CreatedAt time.Time `json:"created_at"` // 创建时间
+	UpdatedAt time.Time `json:"updated_at"` // 更新时间
+}
+
+// OIDCAuthURL 第三方登录授权地址
+type OIDCAuthURL struct {
+	AuthURL string `json:"auth_url"` // 授权地址(前端跳转到该地址进行登录)
+}
+
+// OIDCCallbackParam 第三方登录回调参数
+type OIDCCallbackParam struct {
+	Code  string `json:"code" binding:"required"`  // 授权码
+	State string `json:"state" binding:"required"` // 状态
+}
@@ -0,0 +1,36 @@
+package schema
+
+import "time"
+
+// UserMFA 用户两步验证对象
+type UserMFA struct {
+	ID            string    `json:"id"`         // 唯一标识
+	UserID        string    `json:"user_id"`    // 用户ID
+	Secret        string    `json:"-"`          // TOTP密钥(base32编码)
+	RecoveryCodes string    `json:"-"`          // 恢复码哈希(逗号分隔)
+	LastStep      int64     `json:"-"`          // 最近一次使用的TOTP时间步(防止验证码重放)
+	Status        int       `json:"status"`     // 状态(1:已启用 2:待确认)
+	CreatedAt     time.Time `json:"created_at"` // 创建时间
+	UpdatedAt     time.Time `json:"updated_at"` // 更新时间
+}
+
+// UserMFAStatus 用户两步验证状态
+type UserMFAStatus struct {
+	Enabled bool `json:"enabled"` // 是否已启用
+}
+
+// UserMFAEnrollResult 登记TOTP密钥的结果
+type UserMFAEnrollResult struct {
+	Secret string `json:"secret"` // TOTP密钥(base32编码，用于手动输入)
+	URL    string `json:"url"`    // otpauth地址(用于生成二维码)
+}
+
+// UserMFACodeParam 两步验证码请求参数
+type UserMFACodeParam struct {
+	Code string `json:"code" binding:"required"` // TOTP验证码或恢复码
+}
+
+// UserMFARecoveryCodes 两步验证恢复码(仅在启用时返回一次)
+type UserMFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"` // 恢复码列表(每个仅能使用一次)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/auth/apikey"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/logger"
+	"ginAdmin/pkg/util/uuid"
+	"github.com/google/wire"
+	"regexp"
+	"time"
+)
+
+// APIKeySet 注入APIKey
+var APIKeySet = wire.NewSet(wire.Struct(new(APIKey), "*"))
+
+// 最后使用时间的更新间隔(避免每次请求都写库)
+const apiKeyTouchInterval = time.Minute
+
+// 权限范围路径允许的字符(路径按keyMatch2规则匹配，支持:param及*)
+var apiKeyScopePath = regexp.MustCompile(`^/[a-zA-Z0-9/_:*.-]*$`)
+
+// APIKey API密钥管理
+type APIKey struct {
+	CasbinSrv   *Casbin
+	TenantModel *repo.Tenant
+	UserModel   *repo.User
+	APIKeyModel *repo.APIKey
+}
+
+// Query 查询用户的API密钥
+func (a *APIKey) Query(ctx context.Context, userID string) (schema.APIKeys, error) {
+	if _, err := a.getUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return a.APIKeyModel.QueryByUserID(ctx, userID)
+}
+
+// 获取当前租户及数据权限范围内的用户
+func (a *APIKey) getUser(ctx context.Context, userID string) (*schema.User, error) {
+	user, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrNotFound
+	}
+	return user, nil
+}
+
+// Create 为用户创建API密钥(完整密钥只在创建时返回一次)
+func (a *APIKey) Create(ctx context.Context, userID string, params schema.APIKeyCreateParam, creator string) (*schema.APIKeyToken, error) {
+	user, err := a.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != creator {
+		if err := a.checkCreator(ctx, user, creator); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(time.Now()) {
+		return nil, errors.New400Response("到期时间必须晚于当前时间")
+	}
+
+	if err := a.checkScopes(params.Scopes); err != nil {
+		return nil, err
+	}
+
+	token, prefix, err := apikey.Generate()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	item := schema.APIKey{
+		ID:        uuid.MustString(),
+		UserID:    userID,
+		Name:      params.Name,
+		Prefix:    prefix,
+		TokenHash: apikey.Hash(token),
+		Scopes:    params.Scopes,
+		ExpiresAt: params.ExpiresAt,
+		Creator:   creator,
+		CreatedAt: time.Now(),
+	}
+	err = a.APIKeyModel.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WithContext(ctx).Infof("为用户[%s]创建API密钥[%s]", user.UserName, prefix)
+	return &schema.APIKeyToken{APIKey: item, Token: token}, nil
+}
+
+// 检查创建者是否有权为指定用户创建API密钥(只有超级管理员可以为超级管理员创建，且用户的权限不能超出创建者)
+func (a *APIKey) checkCreator(ctx context.Context, user *schema.User, creator string) error {
+	current, err := a.UserModel.Get(contextx.NewNoDataScope(ctx), creator)
+	if err != nil {
+		return err
+	} else if current == nil {
+		return errors.ErrNoPerm
+	} else if current.IsSuper {
+		return nil
+	} else if user.IsSuper {
+		return errors.ErrNoPerm
+	}
+
+	ok, err := a.CasbinSrv.ContainsUser(ctx, user.TenantID, creator, user.ID)
+	if err != nil {
+		return err
+	} else if !ok {
+		return errors.ErrNoPerm
+	}
+	return nil
+}
+
+func (a *APIKey) checkScopes(scopes schema.APIKeyScopes) error {
+	for _, item := range scopes {
+		if !apiKeyScopePath.MatchString(item.Path) {
+			return errors.New400Response("无效的权限范围路径: %s", item.Path)
+		} else if _, err := regexp.Compile(item.Method); err != nil {
+			return errors.New400Response("无效的权限范围请求方式: %s", item.Method)
+		}
+	}
+	return nil
+}
+
+// Delete 撤销用户的API密钥
+func (a *APIKey) Delete(ctx context.Context, userID, id string) error {
+	if _, err := a.getUser(ctx, userID); err != nil {
+		return err
+	}
+
+	item, err := a.APIKeyModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if item == nil || item.UserID != userID {
+		return errors.ErrNotFound
+	}
+
+	err = a.APIKeyModel.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	logger.WithContext(ctx).Infof("撤销API密钥[%s]", item.Prefix)
+	return nil
+}
+
+// Verify 校验API密钥，返回所属用户及权限范围
+func (a *APIKey) Verify(ctx context.Context, token string) (*schema.APIKeyAuth, error) {
+	prefix, ok := apikey.ParsePrefix(token)
+	if !ok {
+		return nil, errors.ErrInvalidToken
+	}
+
+	item, err := a.APIKeyModel.GetByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	} else if item == nil || !apikey.Verify(token, item.TokenHash) {
+		return nil, errors.ErrInvalidToken
+	}
+
+	now := time.Now()
+	if item.IsExpired(now) {
+		return nil, errors.ErrInvalidToken
+	}
+
+	//	校验时还不知道所属租户，不限定租户查询用户
+	user, err := a.UserModel.Get(contextx.NewSystem(ctx), item.UserID)
+	if err != nil {
+		return nil, err
+	} else if user == nil || user.Status != 1 {
+		return nil, errors.ErrInvalidToken
+	}
+
+	tenant, err := a.TenantModel.Get(ctx, user.TenantID)
+	if err != nil {
+		return nil, err
+	} else if tenant == nil || tenant.Status != 1 {
+		return nil, errors.ErrInvalidToken
+	}
+
+	if item.LastUsedAt == nil || now.Sub(*item.LastUsedAt) >= apiKeyTouchInterval {
+		if err := a.APIKeyModel.UpdateLastUsedAt(ctx, item.ID, now); err != nil {
+			logger.WithContext(ctx).Warnf("更新API密钥最后使用时间失败: %s", err.Error())
+		}
+	}
+
+	return &schema.APIKeyAuth{
+		KeyID:    item.ID,
+		UserID:   item.UserID,
+		TenantID: user.TenantID,
+		Scopes:   item.Scopes,
+	}, nil
+}
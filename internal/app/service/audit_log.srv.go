@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+)
+
+// AuditLogSet 注入AuditLog
+var AuditLogSet = wire.NewSet(wire.Struct(new(AuditLog), "*"))
+
+// AuditLog 数据变更审计
+type AuditLog struct {
+	AuditLogModel *repo.AuditLog
+}
+
+// Query 查询数据(限定为当前租户的变更记录)
+func (a *AuditLog) Query(ctx context.Context, params schema.AuditLogQueryParam, opts ...schema.AuditLogQueryOptions) (*schema.AuditLogQueryResult, error) {
+	if !params.StartTime.IsZero() && !params.EndTime.IsZero() && params.EndTime.Before(params.StartTime) {
+		return nil, errors.New400Response("结束时间不能早于开始时间")
+	}
+	return a.AuditLogModel.Query(ctx, params, opts...)
+}
@@ -3,8 +3,14 @@ package service
 import (
 	"context"
 	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/module/adapter"
+	"ginAdmin/pkg/errors"
 	"ginAdmin/pkg/logger"
 	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/google/wire"
+	"strings"
+	"sync"
 )
 
 var chCasbinPolicy chan *chCasbinPolicyItem
@@ -12,6 +18,7 @@ var chCasbinPolicy chan *chCasbinPolicyItem
 type chCasbinPolicyItem struct {
 	ctx context.Context
 	e   *casbin.SyncedEnforcer
+	w   persist.Watcher
 }
 
 func init() {
@@ -21,13 +28,21 @@ func init() {
 			err := item.e.LoadPolicy()
 			if err != nil {
 				logger.WithContext(item.ctx).Errorf("The Load casbin policy error: %s", err.Error())
+				continue
+			}
+
+			if item.w != nil {
+				err = item.w.Update()
+				if err != nil {
+					logger.WithContext(item.ctx).Errorf("The notify casbin policy change error: %s", err.Error())
+				}
 			}
 		}
 	}()
 }
 
-// loadCasbinPolicy 异步加载casbin权限策略
-func LoadCasbinPolicy(ctx context.Context, e *casbin.SyncedEnforcer) {
+// loadCasbinPolicy 异步加载casbin权限策略，加载完成后通过watcher通知其他实例重新加载(w为空时不通知)
+func LoadCasbinPolicy(ctx context.Context, e *casbin.SyncedEnforcer, w persist.Watcher) {
 	if !config.C.Casbin.Enable {
 		return
 	}
@@ -39,5 +54,214 @@ func LoadCasbinPolicy(ctx context.Context, e *casbin.SyncedEnforcer) {
 	chCasbinPolicy <- &chCasbinPolicyItem{
 		ctx: ctx,
 		e:   e,
+		w:   w,
+	}
+}
+
+// CasbinSet 注入Casbin
+var CasbinSet = wire.NewSet(wire.Struct(new(Casbin), "*"))
+
+// 串行执行权限相关的变更，保证变更前后计算出的策略增量与内存中的策略一致
+var casbinPolicyLock sync.Mutex
+
+// Casbin 权限策略增量更新(增量通过enforcer自动通知其他实例)
+type Casbin struct {
+	Enforcer *casbin.SyncedEnforcer
+	Adapter  *adapter.CasbinAdapter
+	Watcher  persist.Watcher
+}
+
+// UpdateRole 执行角色变更，并将变更前后角色p规则及继承关系g规则的差异应用到enforcer
+func (a *Casbin) UpdateRole(ctx context.Context, roleID string, fn func() error) error {
+	if !config.C.Casbin.Enable {
+		return fn()
+	}
+
+	casbinPolicyLock.Lock()
+	defer casbinPolicyLock.Unlock()
+
+	oldPolicies, oldGroupings, err := a.Adapter.QueryRolePolicies(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	if err != nil {
+		return err
+	}
+
+	newPolicies, newGroupings, err := a.Adapter.QueryRolePolicies(ctx, roleID)
+	if err != nil {
+		a.reload(ctx, err)
+		return nil
+	}
+
+	a.apply(ctx, oldPolicies, newPolicies, oldGroupings, newGroupings)
+	return nil
+}
+
+// UpdateUser 执行用户变更，并将变更前后用户p规则及g规则的差异应用到enforcer
+func (a *Casbin) UpdateUser(ctx context.Context, userID string, fn func() error) error {
+	if !config.C.Casbin.Enable {
+		return fn()
+	}
+
+	casbinPolicyLock.Lock()
+	defer casbinPolicyLock.Unlock()
+
+	oldPolicies, oldGroupings, err := a.Adapter.QueryUserPolicies(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	if err != nil {
+		return err
+	}
+
+	newPolicies, newGroupings, err := a.Adapter.QueryUserPolicies(ctx, userID)
+	if err != nil {
+		a.reload(ctx, err)
+		return nil
+	}
+
+	a.apply(ctx, oldPolicies, newPolicies, oldGroupings, newGroupings)
+	return nil
+}
+
+// SyncUsers 将用户在存储中当前有效的p规则及g规则与enforcer内存中的规则对齐(用于角色授权到达生效或失效时间)
+func (a *Casbin) SyncUsers(ctx context.Context, userIDs ...string) error {
+	if !config.C.Casbin.Enable {
+		return nil
+	}
+
+	casbinPolicyLock.Lock()
+	defer casbinPolicyLock.Unlock()
+
+	for _, userID := range userIDs {
+		newPolicies, newGroupings, err := a.Adapter.QueryUserPolicies(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		oldPolicies := a.Enforcer.GetFilteredPolicy(0, userID)
+		oldGroupings := a.Enforcer.GetFilteredGroupingPolicy(0, userID)
+		a.apply(ctx, oldPolicies, newPolicies, oldGroupings, newGroupings)
+	}
+	return nil
+}
+
+// ContainsUser 检查用户在租户内是否拥有另一用户的全部权限(未启用casbin时不检查)
+func (a *Casbin) ContainsUser(ctx context.Context, tenantID, userID, otherID string) (bool, error) {
+	if !config.C.Casbin.Enable {
+		return true, nil
+	}
+
+	rules, err := a.Enforcer.GetImplicitPermissionsForUser(otherID, tenantID)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	for _, rule := range rules {
+		if len(rule) < 4 {
+			continue
+		}
+
+		ok, err := a.Enforcer.Enforce(userID, tenantID, rule[2], rule[3])
+		if err != nil {
+			return false, errors.WithStack(err)
+		} else if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// 应用策略增量(业务数据已经提交，增量应用失败时改为全量加载)
+func (a *Casbin) apply(ctx context.Context, oldPolicies, newPolicies, oldGroupings, newGroupings [][]string) {
+	addPolicies, delPolicies := diffCasbinRules(oldPolicies, newPolicies)
+	addGroupings, delGroupings := diffCasbinRules(oldGroupings, newGroupings)
+
+	err := a.applyRules(delGroupings, delPolicies, addPolicies, addGroupings)
+	if err != nil {
+		a.reload(ctx, err)
+		return
+	}
+
+	if n := len(addPolicies) + len(delPolicies) + len(addGroupings) + len(delGroupings); n > 0 {
+		logger.WithContext(ctx).Infof("增量更新casbin权限策略: p(+%d,-%d) g(+%d,-%d)",
+			len(addPolicies), len(delPolicies), len(addGroupings), len(delGroupings))
+	}
+}
+
+func (a *Casbin) applyRules(delGroupings, delPolicies, addPolicies, addGroupings [][]string) error {
+	// 只处理内存中确实需要变化的规则(批量添加时只要有一条已存在就会整体忽略)
+	if rules := a.filterRules(delGroupings, a.Enforcer.HasGroupingPolicy, true); len(rules) > 0 {
+		if _, err := a.Enforcer.RemoveGroupingPolicies(rules); err != nil {
+			return err
+		}
+	}
+
+	if rules := a.filterRules(delPolicies, a.Enforcer.HasPolicy, true); len(rules) > 0 {
+		if _, err := a.Enforcer.RemovePolicies(rules); err != nil {
+			return err
+		}
+	}
+
+	if rules := a.filterRules(addPolicies, a.Enforcer.HasPolicy, false); len(rules) > 0 {
+		if _, err := a.Enforcer.AddPolicies(rules); err != nil {
+			return err
+		}
+	}
+
+	if rules := a.filterRules(addGroupings, a.Enforcer.HasGroupingPolicy, false); len(rules) > 0 {
+		if _, err := a.Enforcer.AddGroupingPolicies(rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Casbin) filterRules(rules [][]string, has func(params ...interface{}) bool, exists bool) [][]string {
+	var list [][]string
+	for _, rule := range rules {
+		if has(rule) == exists {
+			list = append(list, rule)
+		}
+	}
+	return list
+}
+
+// Reload 全量重新加载权限策略(用于无法计算增量的变更，例如菜单的删除及恢复)
+func (a *Casbin) Reload(ctx context.Context) {
+	LoadCasbinPolicy(ctx, a.Enforcer, a.Watcher)
+}
+
+func (a *Casbin) reload(ctx context.Context, err error) {
+	logger.WithContext(ctx).Warnf("增量更新casbin权限策略失败，改为全量加载: %s", err.Error())
+	LoadCasbinPolicy(ctx, a.Enforcer, a.Watcher)
+}
+
+// 比较新旧规则，返回需要添加及移除的规则
+func diffCasbinRules(oldRules, newRules [][]string) (addList, delList [][]string) {
+	mOldRules := make(map[string][]string, len(oldRules))
+	for _, rule := range oldRules {
+		mOldRules[strings.Join(rule, ",")] = rule
+	}
+
+	for _, rule := range newRules {
+		k := strings.Join(rule, ",")
+		if _, ok := mOldRules[k]; ok {
+			delete(mOldRules, k)
+			continue
+		}
+		addList = append(addList, rule)
+	}
+
+	for _, rule := range oldRules {
+		if _, ok := mOldRules[strings.Join(rule, ",")]; ok {
+			delList = append(delList, rule)
+		}
 	}
+	return
 }
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/pkg/util/uuid"
+	"ginAdmin/pkg/watcher/local"
+	"github.com/casbin/casbin/v2"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 全量加载的策略
+func loadFullPolicy(t *testing.T, env *testEnv) (policies, groupings []string) {
+	e, err := casbin.NewSyncedEnforcer(testCasbinModel, env.Adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sortCasbinRules(e.GetPolicy()), sortCasbinRules(e.GetGroupingPolicy())
+}
+
+func sortCasbinRules(rules [][]string) []string {
+	list := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		list = append(list, strings.Join(rule, ","))
+	}
+	sort.Strings(list)
+	return list
+}
+
+// 检查enforcer内存中的策略与全量加载的策略一致
+func assertCasbinSynced(t *testing.T, env *testEnv, e *casbin.SyncedEnforcer) {
+	t.Helper()
+	policies, groupings := loadFullPolicy(t, env)
+	if v := sortCasbinRules(e.GetPolicy()); !reflect.DeepEqual(v, policies) {
+		t.Fatalf("p rules differ from full reload:\n got: %v\nwant: %v", v, policies)
+	}
+	if v := sortCasbinRules(e.GetGroupingPolicy()); !reflect.DeepEqual(v, groupings) {
+		t.Fatalf("g rules differ from full reload:\n got: %v\nwant: %v", v, groupings)
+	}
+}
+
+// 等待异步的全量加载完成后检查策略一致
+func waitCasbinSynced(t *testing.T, env *testEnv, e *casbin.SyncedEnforcer) {
+	t.Helper()
+	policies, groupings := loadFullPolicy(t, env)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if reflect.DeepEqual(sortCasbinRules(e.GetPolicy()), policies) &&
+			reflect.DeepEqual(sortCasbinRules(e.GetGroupingPolicy()), groupings) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assertCasbinSynced(t, env, e)
+}
+
+func assertEnforce(t *testing.T, e *casbin.SyncedEnforcer, sub, obj string, want bool) {
+	t.Helper()
+	ok, err := e.Enforce(sub, "t1", obj, "GET")
+	if err != nil {
+		t.Fatal(err)
+	} else if ok != want {
+		t.Fatalf("enforce %s %s: got %v, want %v", sub, obj, ok, want)
+	}
+}
+
+// 初始化测试数据：角色r1可访问users，r2可访问roles，用户u1属于r1，u2属于r2，返回roles菜单ID及动作ID
+func seedCasbinData(t *testing.T, env *testEnv) (string, string) {
+	userMenu, userAction := env.createMenu(t, "t1", "/api/v1/users", "GET")
+	roleMenu, roleAction := env.createMenu(t, "t1", "/api/v1/roles", "GET")
+	env.createRole(t, "t1", "r1", userMenu, userAction)
+	env.createRole(t, "t1", "r2", roleMenu, roleAction)
+	env.createUser(t, "t1", "u1", false, "r1")
+	env.createUser(t, "t1", "u2", false, "r2")
+	env.createUser(t, "t1", "root", true)
+	return roleMenu, roleAction
+}
+
+func TestCasbinIncrementalUpdate(t *testing.T) {
+	env := newTestEnv(t)
+	roleMenu, roleAction := seedCasbinData(t, env)
+	a := env.newCasbin(t, nil)
+	ctx := contextx.NewTenantID(context.Background(), "t1")
+	db := env.DB.WithContext(ctx)
+
+	assertCasbinSynced(t, env, a.Enforcer)
+	assertEnforce(t, a.Enforcer, "root", "/api/v1/demos", true)
+	assertEnforce(t, a.Enforcer, "u1", "/api/v1/roles", false)
+
+	steps := []struct {
+		name  string
+		apply func() error
+	}{
+		{"role add menu", func() error {
+			return a.UpdateRole(ctx, "r1", func() error {
+				return db.Create(&entity.RoleMenu{ID: uuid.MustString(), RoleID: "r1", MenuID: roleMenu, ActionID: roleAction}).Error
+			})
+		}},
+		{"role add parent", func() error {
+			return a.UpdateRole(ctx, "r2", func() error {
+				return db.Create(&entity.RoleParent{ID: uuid.MustString(), RoleID: "r2", ParentID: "r1"}).Error
+			})
+		}},
+		{"role disable", func() error {
+			return a.UpdateRole(ctx, "r1", func() error {
+				return db.Model(new(entity.Role)).Where("id=?", "r1").Update("status", 2).Error
+			})
+		}},
+		{"role enable", func() error {
+			return a.UpdateRole(ctx, "r1", func() error {
+				return db.Model(new(entity.Role)).Where("id=?", "r1").Update("status", 1).Error
+			})
+		}},
+		{"user change role", func() error {
+			return a.UpdateUser(ctx, "u1", func() error {
+				err := db.Where("user_id=?", "u1").Delete(new(entity.UserRole)).Error
+				if err != nil {
+					return err
+				}
+				return db.Create(&entity.UserRole{ID: uuid.MustString(), UserID: "u1", RoleID: "r2"}).Error
+			})
+		}},
+		{"user disable", func() error {
+			return a.UpdateUser(ctx, "u2", func() error {
+				return db.Model(new(entity.User)).Where("id=?", "u2").Update("status", 2).Error
+			})
+		}},
+		{"role delete", func() error {
+			return a.UpdateRole(ctx, "r2", func() error {
+				return db.Where("id=?", "r2").Delete(new(entity.Role)).Error
+			})
+		}},
+	}
+	for _, step := range steps {
+		if err := step.apply(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		assertCasbinSynced(t, env, a.Enforcer)
+	}
+
+	assertEnforce(t, a.Enforcer, "u1", "/api/v1/users", false)
+	assertEnforce(t, a.Enforcer, "u2", "/api/v1/roles", false)
+}
+
+func TestCasbinAdapterPolicies(t *testing.T) {
+	env := newTestEnv(t)
+	seedCasbinData(t, env)
+	a := env.newCasbin(t, nil)
+	e := a.Enforcer
+
+	// g规则写入用户角色及角色继承关系
+	if _, err := e.AddGroupingPolicy("u1", "r2", "t1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.AddGroupingPolicies([][]string{{"r1", "r2", "t1"}}); err != nil {
+		t.Fatal(err)
+	}
+	assertCasbinSynced(t, env, e)
+	assertEnforce(t, e, "u1", "/api/v1/roles", true)
+
+	// 循环继承及跨租户的规则被拒绝，内存中的规则不变
+	if _, err := e.AddGroupingPolicy("r2", "r1", "t1"); err == nil {
+		t.Fatal("expected role cycle error")
+	}
+	if _, err := e.AddGroupingPolicy("u2", "r1", "t2"); err == nil {
+		t.Fatal("expected tenant mismatch error")
+	}
+	assertCasbinSynced(t, env, e)
+
+	// p规则由角色菜单派生，不能直接添加或移除
+	if _, err := e.AddPolicy("r1", "t1", "/api/v1/tenants", "GET"); err == nil {
+		t.Fatal("expected derived policy error")
+	}
+	if _, err := e.RemovePolicy("r1", "t1", "/api/v1/users", "GET"); err == nil {
+		t.Fatal("expected derived policy error")
+	}
+	assertCasbinSynced(t, env, e)
+
+	if _, err := e.RemoveGroupingPolicies([][]string{{"u1", "r2", "t1"}, {"r1", "r2", "t1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.RemoveFilteredGroupingPolicy(0, "u2"); err != nil {
+		t.Fatal(err)
+	}
+	assertCasbinSynced(t, env, e)
+	assertEnforce(t, e, "u1", "/api/v1/roles", false)
+	assertEnforce(t, e, "u2", "/api/v1/roles", false)
+}
+
+func TestCasbinWatcherReplay(t *testing.T) {
+	env := newTestEnv(t)
+	seedCasbinData(t, env)
+
+	w1, w2 := local.NewWatcher(), local.NewWatcher()
+	defer w1.Close()
+	defer w2.Close()
+	a1 := env.newCasbin(t, w1)
+	a2 := env.newCasbin(t, w2)
+
+	// 记录各实例收到的通知(自身发出的通知应被忽略)
+	var n1, n2 int32
+	_ = w1.SetUpdateCallback(func(string) {
+		atomic.AddInt32(&n1, 1)
+		_ = a1.Enforcer.LoadPolicy()
+	})
+	_ = w2.SetUpdateCallback(func(string) {
+		atomic.AddInt32(&n2, 1)
+		_ = a2.Enforcer.LoadPolicy()
+	})
+
+	ctx := contextx.NewTenantID(context.Background(), "t1")
+	err := a1.UpdateUser(ctx, "u1", func() error {
+		return env.DB.WithContext(ctx).Create(&entity.UserRole{ID: uuid.MustString(), UserID: "u1", RoleID: "r2"}).Error
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertCasbinSynced(t, env, a1.Enforcer)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&n2) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("watcher notification not received")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assertCasbinSynced(t, env, a2.Enforcer)
+	assertEnforce(t, a2.Enforcer, "u1", "/api/v1/roles", true)
+
+	time.Sleep(50 * time.Millisecond)
+	if n := atomic.LoadInt32(&n1); n != 0 {
+		t.Fatalf("watcher must ignore its own notifications, got %d", n)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"github.com/google/wire"
+)
+
+// DataScopeSet 注入DataScope
+var DataScopeSet = wire.NewSet(wire.Struct(new(DataScope), "*"))
+
+// DataScope 数据权限范围
+type DataScope struct {
+	UserModel     *repo.User
+	RoleModel     *repo.Role
+	RoleDeptModel *repo.RoleDept
+	DeptModel     *repo.Dept
+}
+
+// Resolve 合并用户所有启用角色的数据权限范围(超级管理员为全部数据)
+func (a *DataScope) Resolve(ctx context.Context, userID string) (*schema.DataScope, error) {
+	ctx = contextx.NewNoDataScope(ctx)
+	scope := &schema.DataScope{UserID: userID}
+
+	user, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return scope, nil
+	} else if user.IsSuper {
+		scope.All = true
+		return scope, nil
+	}
+
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		UserID: userID,
+		Status: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var customRoleIDs []string
+	var withDept, withSubDept bool
+	for _, role := range roleResult.Data {
+		switch role.DataScope {
+		case schema.DataScopeCustom:
+			customRoleIDs = append(customRoleIDs, role.ID)
+		case schema.DataScopeDept:
+			withDept = true
+		case schema.DataScopeDeptAndSub:
+			withSubDept = true
+		case schema.DataScopeSelf:
+		default:
+			scope.All = true
+			return scope, nil
+		}
+	}
+
+	mDeptIDs := make(map[string]struct{})
+	addDeptIDs := func(ids ...string) {
+		for _, id := range ids {
+			if _, ok := mDeptIDs[id]; ok {
+				continue
+			}
+			scope.DeptIDs = append(scope.DeptIDs, id)
+			mDeptIDs[id] = struct{}{}
+		}
+	}
+
+	if len(customRoleIDs) > 0 {
+		result, err := a.RoleDeptModel.Query(ctx, schema.RoleDeptQueryParam{
+			RoleIDs: customRoleIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		addDeptIDs(result.Data.ToDeptIDs()...)
+	}
+
+	if user.DeptID != "" && (withDept || withSubDept) {
+		addDeptIDs(user.DeptID)
+	}
+
+	if user.DeptID != "" && withSubDept {
+		dept, err := a.DeptModel.Get(ctx, user.DeptID)
+		if err != nil {
+			return nil, err
+		} else if dept != nil {
+			path := dept.ID
+			if dept.ParentPath != "" {
+				path = dept.ParentPath + "/" + dept.ID
+			}
+
+			result, err := a.DeptModel.Query(ctx, schema.DeptQueryParam{
+				PrefixParentPath: path,
+			})
+			if err != nil {
+				return nil, err
+			}
+			addDeptIDs(result.Data.ToIDs()...)
+		}
+	}
+
+	return scope, nil
+}
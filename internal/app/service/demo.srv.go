@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
+	"ginAdmin/internal/app/contextx"
 	"ginAdmin/internal/app/model/gormx/repo"
 	"ginAdmin/internal/app/schema"
 	"ginAdmin/pkg/errors"
 	"ginAdmin/pkg/util/uuid"
 	"github.com/google/wire"
+	"time"
 )
 
 // DemoSet 注入Demo
@@ -24,7 +26,7 @@ func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...
 
 // Get 查询指定数据
 func (a *Demo) Get(ctx context.Context, id string, opts ...schema.DemoQueryOptions) (*schema.Demo, error) {
-	item, err := a.DemoModel.Get(ctx, id, opts...)
+	item, err := a.DemoModel.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	} else if item == nil {
@@ -34,7 +36,7 @@ func (a *Demo) Get(ctx context.Context, id string, opts ...schema.DemoQueryOptio
 }
 
 func (a *Demo) checkCode(ctx context.Context, code string) error {
-	result, err := a.DemoModel.Query(ctx, schema.DemoQueryParam{
+	result, err := a.DemoModel.Query(contextx.NewNoDataScope(ctx), schema.DemoQueryParam{
 		PaginationParam: schema.PaginationParam{
 			OnlyCount: true,
 		},
@@ -72,6 +74,8 @@ func (a *Demo) Update(ctx context.Context, id string, item schema.Demo) error {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
+	} else if oldItem.Version != item.Version {
+		return errors.ErrPreconditionFailed
 	} else if oldItem.Code != item.Code {
 		if err := a.checkCode(ctx, item.Code); err != nil {
 			return err
@@ -97,14 +101,56 @@ func (a *Demo) Delete(ctx context.Context, id string) error {
 	return a.DemoModel.Delete(ctx, id)
 }
 
-// UpdateStatus 更新状态
-func (a *Demo) UpdateStatus(ctx context.Context, id string, status int) error {
+// UpdateStatus 更新状态(version为客户端读取时的版本号)
+func (a *Demo) UpdateStatus(ctx context.Context, id string, status int, version int64) error {
 	oldItem, err := a.DemoModel.Get(ctx, id)
 	if err != nil {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
+	} else if oldItem.Version != version {
+		return errors.ErrPreconditionFailed
 	}
 
-	return a.DemoModel.UpdateStatus(ctx, id, status)
+	return a.DemoModel.UpdateStatus(ctx, id, status, version)
+}
+
+// QueryDeleted 查询回收站中的数据
+func (a *Demo) QueryDeleted(ctx context.Context, params schema.RecycleQueryParam) (*schema.RecycleQueryResult, error) {
+	return a.DemoModel.QueryDeleted(ctx, params)
+}
+
+// Restore 从回收站恢复数据
+func (a *Demo) Restore(ctx context.Context, id string) error {
+	oldItem, err := a.DemoModel.GetDeleted(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	err = a.checkCode(ctx, oldItem.Code)
+	if err != nil {
+		return err
+	}
+
+	return a.DemoModel.Restore(ctx, id)
+}
+
+// Purge 彻底删除删除时间早于指定时间的数据
+func (a *Demo) Purge(ctx context.Context, before time.Time) (int, error) {
+	ids, err := a.DemoModel.QueryPurgeIDs(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.DemoModel.Purge(ctx, ids)
+}
+
+// PurgeTenant 彻底删除上下文中租户的全部数据
+func (a *Demo) PurgeTenant(ctx context.Context) (int, error) {
+	ids, err := a.DemoModel.DeleteTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.DemoModel.Purge(ctx, ids)
 }
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/util/uuid"
+	"github.com/google/wire"
+	"strings"
+	"time"
+)
+
+// DeptSet 注入Dept
+var DeptSet = wire.NewSet(wire.Struct(new(Dept), "*"))
+
+// Dept 部门管理
+type Dept struct {
+	TransModel    *repo.Trans
+	DeptModel     *repo.Dept
+	RoleDeptModel *repo.RoleDept
+	UserModel     *repo.User
+}
+
+// Query 查询数据
+func (a *Dept) Query(ctx context.Context, params schema.DeptQueryParam, opts ...schema.DeptQueryOptions) (*schema.DeptQueryResult, error) {
+	return a.DeptModel.Query(ctx, params, opts...)
+}
+
+// Get 查询指定数据
+func (a *Dept) Get(ctx context.Context, id string, opts ...schema.DeptQueryOptions) (*schema.Dept, error) {
+	item, err := a.DeptModel.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return nil, errors.ErrNotFound
+	}
+	return item, nil
+}
+
+func (a *Dept) checkName(ctx context.Context, item schema.Dept) error {
+	result, err := a.DeptModel.Query(ctx, schema.DeptQueryParam{
+		PaginationParam: schema.PaginationParam{
+			OnlyCount: true,
+		},
+		ParentID: &item.ParentID,
+		Name:     item.Name,
+	})
+	if err != nil {
+		return err
+	} else if result.PageResult.Total > 0 {
+		return errors.New400Response("部门名称已经存在")
+	}
+	return nil
+}
+
+// Create 创建数据
+func (a *Dept) Create(ctx context.Context, item schema.Dept) (*schema.IDResult, error) {
+	if err := a.checkName(ctx, item); err != nil {
+		return nil, err
+	}
+
+	parentPath, err := a.getParentPath(ctx, item.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	item.ParentPath = parentPath
+	item.ID = uuid.MustString()
+
+	err = a.DeptModel.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.NewIDResult(item.ID), nil
+}
+
+// 获取父级路径
+func (a *Dept) getParentPath(ctx context.Context, parentID string) (string, error) {
+	if parentID == "" {
+		return "", nil
+	}
+
+	pitem, err := a.DeptModel.Get(ctx, parentID)
+	if err != nil {
+		return "", err
+	} else if pitem == nil {
+		return "", errors.ErrInvalidParent
+	}
+
+	return a.joinParentPath(pitem.ParentPath, pitem.ID), nil
+}
+
+func (a *Dept) joinParentPath(parent, id string) string {
+	if parent != "" {
+		return parent + "/" + id
+	}
+	return id
+}
+
+// Update 更新数据
+func (a *Dept) Update(ctx context.Context, id string, item schema.Dept) error {
+	if id == item.ParentID {
+		return errors.ErrInvalidParent
+	}
+
+	oldItem, err := a.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem.Name != item.Name || oldItem.ParentID != item.ParentID {
+		if err := a.checkName(ctx, item); err != nil {
+			return err
+		}
+	}
+
+	item.ID = oldItem.ID
+	item.Creator = oldItem.Creator
+	item.CreatedAt = oldItem.CreatedAt
+
+	if oldItem.ParentID != item.ParentID {
+		parentPath, err := a.getParentPath(ctx, item.ParentID)
+		if err != nil {
+			return err
+		}
+
+		// 不允许移动到自身的下级部门
+		for _, pid := range strings.Split(parentPath, "/") {
+			if pid == id {
+				return errors.ErrInvalidParent
+			}
+		}
+		item.ParentPath = parentPath
+	} else {
+		item.ParentPath = oldItem.ParentPath
+	}
+
+	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		err := a.updateChildParentPath(ctx, *oldItem, item)
+		if err != nil {
+			return err
+		}
+
+		return a.DeptModel.Update(ctx, id, item)
+	})
+}
+
+// 检查并更新下级节点的父级路径
+func (a *Dept) updateChildParentPath(ctx context.Context, oldItem, newItem schema.Dept) error {
+	if oldItem.ParentID == newItem.ParentID {
+		return nil
+	}
+
+	opath := a.joinParentPath(oldItem.ParentPath, oldItem.ID)
+	result, err := a.DeptModel.Query(contextx.NewNoTrans(ctx), schema.DeptQueryParam{
+		PrefixParentPath: opath,
+	})
+	if err != nil {
+		return err
+	}
+
+	npath := a.joinParentPath(newItem.ParentPath, newItem.ID)
+	for _, dept := range result.Data {
+		err = a.DeptModel.UpdateParentPath(ctx, dept.ID, npath+dept.ParentPath[len(opath):])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Dept) Delete(ctx context.Context, id string) error {
+	oldItem, err := a.DeptModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	result, err := a.DeptModel.Query(ctx, schema.DeptQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		ParentID:        &id,
+	})
+	if err != nil {
+		return err
+	} else if result.PageResult.Total > 0 {
+		return errors.ErrNotAllowDeleteWithChild
+	}
+
+	userResult, err := a.UserModel.Query(contextx.NewNoDataScope(ctx), schema.UserQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		DeptID:          id,
+	})
+	if err != nil {
+		return err
+	} else if userResult.PageResult.Total > 0 {
+		return errors.New400Response("该部门下存在用户，不允许删除")
+	}
+
+	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		err := a.RoleDeptModel.DeleteByDeptID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return a.DeptModel.Delete(ctx, id)
+	})
+}
+
+// UpdateStatus 更新状态
+func (a *Dept) UpdateStatus(ctx context.Context, id string, status int) error {
+	oldItem, err := a.DeptModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	return a.DeptModel.UpdateStatus(ctx, id, status)
+}
+
+// QueryDeleted 查询回收站中的数据
+func (a *Dept) QueryDeleted(ctx context.Context, params schema.RecycleQueryParam) (*schema.RecycleQueryResult, error) {
+	return a.DeptModel.QueryDeleted(ctx, params)
+}
+
+// Restore 从回收站恢复数据(上级部门须存在，删除时已移除的角色自定义数据权限不会恢复)
+func (a *Dept) Restore(ctx context.Context, id string) error {
+	oldItem, err := a.DeptModel.GetDeleted(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	if err := a.checkName(ctx, *oldItem); err != nil {
+		return err
+	}
+
+	parentPath, err := a.getParentPath(ctx, oldItem.ParentID)
+	if err != nil {
+		return err
+	}
+
+	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		err := a.DeptModel.Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// 删除期间上级部门可能被移动
+		if parentPath != oldItem.ParentPath {
+			return a.DeptModel.UpdateParentPath(ctx, id, parentPath)
+		}
+		return nil
+	})
+}
+
+// Purge 彻底删除删除时间早于指定时间的数据
+func (a *Dept) Purge(ctx context.Context, before time.Time) (int, error) {
+	ids, err := a.DeptModel.QueryPurgeIDs(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.DeptModel.Purge(ctx, ids)
+}
+
+// PurgeTenant 彻底删除上下文中租户的全部数据
+func (a *Dept) PurgeTenant(ctx context.Context) (int, error) {
+	ids, err := a.DeptModel.DeleteTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.DeptModel.Purge(ctx, ids)
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/oidc"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/logger"
+	"ginAdmin/pkg/util/uuid"
+	"github.com/google/wire"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ExternalLoginSet 注入ExternalLogin
+var ExternalLoginSet = wire.NewSet(wire.Struct(new(ExternalLogin), "*"))
+
+// ExternalLogin 第三方身份提供者登录
+type ExternalLogin struct {
+	CasbinSrv         *Casbin
+	LoginSrv          *Login
+	Providers         auth.IdentityProviders
+	Store             oidc.Storer
+	TransModel        *repo.Trans
+	UserModel         *repo.User
+	UserRoleModel     *repo.UserRole
+	RoleModel         *repo.Role
+	UserIdentityModel *repo.UserIdentity
+}
+
+// 授权请求状态(登录回调时使用)
+type externalLoginState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// 用户名中不允许出现的字符
+var invalidUserNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.@-]+`)
+
+func (a *ExternalLogin) getProvider(name string) (auth.IdentityProvider, error) {
+	p, ok := a.Providers[name]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return p, nil
+}
+
+// 获取身份提供者的用户初始化配置
+func (a *ExternalLogin) getProviderConfig(name string) config.OIDCProvider {
+	for _, item := range config.C.OIDC.Providers {
+		if item.Name == name {
+			return item
+		}
+	}
+	return config.OIDCProvider{Name: name}
+}
+
+// AuthURL 生成授权地址(state、nonce及PKCE校验码保存在服务端，回调时校验)
+func (a *ExternalLogin) AuthURL(ctx context.Context, provider string) (*schema.OIDCAuthURL, error) {
+	p, err := a.getProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := a.randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := a.randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	data, err := json.Marshal(externalLoginState{
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	expired := time.Duration(config.C.OIDC.StateExpired) * time.Second
+	if expired <= 0 {
+		expired = 10 * time.Minute
+	}
+	err = a.Store.Set(ctx, state, data, expired)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &schema.OIDCAuthURL{AuthURL: authURL}, nil
+}
+
+// Callback 登录回调(使用授权码换取外部身份，返回关联的系统用户；未关联时按配置自动创建用户)
+func (a *ExternalLogin) Callback(ctx context.Context, provider string, params schema.OIDCCallbackParam) (*schema.User, error) {
+	p, err := a.getProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	//	状态只能使用一次
+	data, err := a.Store.Take(ctx, params.State)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if data == nil {
+		return nil, errors.ErrInvalidOIDCState
+	}
+
+	var state externalLoginState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.WithStack(err)
+	} else if state.Provider != provider {
+		return nil, errors.ErrInvalidOIDCState
+	}
+
+	//	身份提供者属于配置的租户，外部身份关联的用户及自动创建的用户均限定在该租户内
+	tenantID, err := a.LoginSrv.GetTenantID(ctx, a.getProviderConfig(provider).TenantCode)
+	if err != nil {
+		return nil, err
+	}
+	ctx = contextx.NewTenantID(ctx, tenantID)
+
+	identity, err := p.Exchange(ctx, params.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("第三方登录[%s]失败: %s", provider, err.Error())
+		return nil, errors.ErrExternalLogin
+	}
+
+	user, err := a.getOrProvisionUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	} else if user.Status != 1 {
+		return nil, errors.ErrUserDisable
+	}
+	return user, nil
+}
+
+func (a *ExternalLogin) getOrProvisionUser(ctx context.Context, identity *auth.ExternalIdentity) (*schema.User, error) {
+	item, err := a.UserIdentityModel.GetBySubject(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return a.provisionUser(ctx, identity)
+	}
+
+	if identity.Email != "" && identity.Email != item.Email {
+		if err := a.UserIdentityModel.UpdateEmail(ctx, item.ID, identity.Email); err != nil {
+			logger.WithContext(ctx).Warnf("更新外部身份邮箱失败: %s", err.Error())
+		}
+	}
+
+	user, err := a.UserModel.Get(ctx, item.UserID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrInvalidUser
+	}
+	return user, nil
+}
+
+// 首次登录时创建用户并分配默认角色
+func (a *ExternalLogin) provisionUser(ctx context.Context, identity *auth.ExternalIdentity) (*schema.User, error) {
+	cfg := a.getProviderConfig(identity.Provider)
+	if !cfg.AutoProvision {
+		return nil, errors.ErrExternalUserNotBound
+	}
+
+	userName, err := a.uniqueUserName(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	realName := identity.Name
+	if realName == "" {
+		realName = userName
+	}
+
+	var email string
+	if identity.EmailVerified {
+		email = identity.Email
+	}
+
+	tenantID, _ := contextx.FromTenantID(ctx)
+	user := schema.User{
+		TenantID: tenantID,
+		ID:       uuid.MustString(),
+		UserName: userName,
+		RealName: realName,
+		Email:    email,
+		Status:   1,
+	}
+
+	roleIDs, err := a.getDefaultRoleIDs(ctx, cfg.DefaultRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.CasbinSrv.UpdateUser(ctx, user.ID, func() error {
+		return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			for _, roleID := range roleIDs {
+				err := a.UserRoleModel.Create(ctx, schema.UserRole{
+					ID:     uuid.MustString(),
+					UserID: user.ID,
+					RoleID: roleID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			err := a.UserModel.Create(ctx, user)
+			if err != nil {
+				return err
+			}
+
+			return a.UserIdentityModel.Create(ctx, schema.UserIdentity{
+				ID:       uuid.MustString(),
+				UserID:   user.ID,
+				Provider: identity.Provider,
+				Subject:  identity.Subject,
+				Email:    identity.Email,
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WithContext(logger.NewUserIDContext(ctx, user.ID)).Infof("第三方登录[%s]自动创建用户[%s]", identity.Provider, userName)
+	return &user, nil
+}
+
+// 根据角色名称获取已启用的默认角色
+func (a *ExternalLogin) getDefaultRoleIDs(ctx context.Context, names []string) ([]string, error) {
+	var roleIDs []string
+	for _, name := range names {
+		result, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+			Name:   name,
+			Status: 1,
+		})
+		if err != nil {
+			return nil, err
+		} else if len(result.Data) == 0 {
+			logger.WithContext(ctx).Warnf("第三方登录默认角色[%s]不存在或已禁用", name)
+			continue
+		}
+		roleIDs = append(roleIDs, result.Data[0].ID)
+	}
+	return roleIDs, nil
+}
+
+// 生成不重复的用户名(优先使用身份提供者中的用户名，其次是邮箱前缀)
+func (a *ExternalLogin) uniqueUserName(ctx context.Context, identity *auth.ExternalIdentity) (string, error) {
+	base := identity.UserName
+	if base == "" && identity.Email != "" {
+		base = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+	base = invalidUserNameChars.ReplaceAllString(base, "")
+	if base == "" {
+		base = identity.Provider
+	}
+	if len(base) > 48 {
+		base = base[:48]
+	}
+
+	userName := base
+	for i := 0; i < 5; i++ {
+		result, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+			PaginationParam: schema.PaginationParam{OnlyCount: true},
+			UserName:        userName,
+		})
+		if err != nil {
+			return "", err
+		} else if result.PageResult.Total == 0 {
+			return userName, nil
+		}
+
+		suffix, err := a.randomString()
+		if err != nil {
+			return "", err
+		}
+		userName = base + "_" + suffix[:6]
+	}
+	return "", errors.New400Response("无法生成唯一的用户名")
+}
+
+func (a *ExternalLogin) randomString() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return hex.EncodeToString(b), nil
+}
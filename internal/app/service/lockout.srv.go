@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/lockout"
+	"ginAdmin/pkg/logger"
+	"github.com/google/wire"
+	"math"
+)
+
+// LockoutSet 注入Lockout
+var LockoutSet = wire.NewSet(wire.Struct(new(Lockout), "*"))
+
+// 登录失败锁定的统计维度
+const (
+	LockoutUser = "user"
+	LockoutIP   = "ip"
+)
+
+// Lockout 登录失败锁定管理
+type Lockout struct {
+	Limiter   *lockout.Limiter
+	UserModel *repo.User
+}
+
+func (a *Lockout) loginContext(ctx context.Context) context.Context {
+	return logger.NewTagContext(ctx, "__login__")
+}
+
+// 用户名只在租户内唯一，用户维度按租户及用户名统计
+func lockoutUserValue(tenantID, userName string) string {
+	return tenantID + ":" + userName
+}
+
+// 上下文中租户的用户名及IP的统计维度
+func (a *Lockout) items(ctx context.Context, userName, ip string) [][2]string {
+	tenantID, _ := contextx.FromTenantID(ctx)
+	return [][2]string{{LockoutUser, lockoutUserValue(tenantID, userName)}, {LockoutIP, ip}}
+}
+
+// Check 检查上下文中租户的用户名及IP是否被锁定，返回登录是否需要验证码(未启用锁定时始终需要验证码)
+func (a *Lockout) Check(ctx context.Context, userName, ip string) (bool, error) {
+	if a.Limiter == nil {
+		return true, nil
+	}
+
+	var captchaRequired bool
+	for _, item := range a.items(ctx, userName, ip) {
+		status, err := a.Limiter.Status(ctx, item[0], item[1])
+		if err != nil {
+			return false, errors.WithStack(err)
+		} else if status.IsLocked() {
+			minutes := int(math.Ceil(status.LockedFor.Minutes()))
+			return false, errors.NewResponse(429, 429, "登录失败次数过多，请%d分钟后再试", minutes)
+		}
+
+		required, err := a.Limiter.CaptchaRequired(ctx, item[0], item[1])
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		captchaRequired = captchaRequired || required
+	}
+	return captchaRequired, nil
+}
+
+// Fail 记录一次登录失败(达到阈值时锁定)
+func (a *Lockout) Fail(ctx context.Context, userName, ip string) error {
+	if a.Limiter == nil {
+		return nil
+	}
+
+	for _, item := range a.items(ctx, userName, ip) {
+		duration, err := a.Limiter.Fail(ctx, item[0], item[1])
+		if err != nil {
+			return errors.WithStack(err)
+		} else if duration > 0 {
+			logger.WithContext(a.loginContext(ctx)).Warnf("登录失败次数过多，已锁定%s[%s]，锁定时长%s", item[0], item[1], duration)
+		}
+	}
+	return nil
+}
+
+// Succeed 登录成功后清除失败次数
+func (a *Lockout) Succeed(ctx context.Context, userName, ip string) error {
+	if a.Limiter == nil {
+		return nil
+	}
+
+	for _, item := range a.items(ctx, userName, ip) {
+		if err := a.Limiter.Reset(ctx, item[0], item[1]); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// GetByUser 查询指定用户的锁定状态
+func (a *Lockout) GetByUser(ctx context.Context, userID string) (*schema.LoginLockout, error) {
+	user, err := a.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return a.get(ctx, LockoutUser, lockoutUserValue(user.TenantID, user.UserName))
+}
+
+// GetByIP 查询指定IP的锁定状态
+func (a *Lockout) GetByIP(ctx context.Context, ip string) (*schema.LoginLockout, error) {
+	return a.get(ctx, LockoutIP, ip)
+}
+
+// UnlockUser 解除指定用户的锁定
+func (a *Lockout) UnlockUser(ctx context.Context, userID string) error {
+	user, err := a.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return a.unlock(ctx, LockoutUser, lockoutUserValue(user.TenantID, user.UserName))
+}
+
+// UnlockIP 解除指定IP的锁定
+func (a *Lockout) UnlockIP(ctx context.Context, ip string) error {
+	return a.unlock(ctx, LockoutIP, ip)
+}
+
+func (a *Lockout) getUser(ctx context.Context, userID string) (*schema.User, error) {
+	user, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrNotFound
+	}
+	return user, nil
+}
+
+func (a *Lockout) get(ctx context.Context, dimension, value string) (*schema.LoginLockout, error) {
+	if a.Limiter == nil {
+		return &schema.LoginLockout{}, nil
+	}
+
+	status, err := a.Limiter.Status(ctx, dimension, value)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &schema.LoginLockout{
+		Failures:  status.Failures,
+		Locked:    status.IsLocked(),
+		LockedFor: int64(math.Ceil(status.LockedFor.Seconds())),
+	}, nil
+}
+
+func (a *Lockout) unlock(ctx context.Context, dimension, value string) error {
+	if a.Limiter == nil {
+		return nil
+	}
+
+	if err := a.Limiter.Unlock(ctx, dimension, value); err != nil {
+		return errors.WithStack(err)
+	}
+	logger.WithContext(a.loginContext(ctx)).Infof("解除登录锁定%s[%s]", dimension, value)
+	return nil
+}
+
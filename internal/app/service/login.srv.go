@@ -2,15 +2,25 @@ package service
 
 import (
 	"context"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
 	"ginAdmin/internal/app/model/gormx/repo"
 	"ginAdmin/internal/app/schema"
 	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/password"
 	"ginAdmin/pkg/errors"
-	"ginAdmin/pkg/util/hash"
+	"ginAdmin/pkg/logger"
 	"github.com/LyricTian/captcha"
 	"github.com/google/wire"
 	"net/http"
 	"sort"
+	"time"
+)
+
+// 挑战令牌用途
+const (
+	challengeMFA      = "mfa"
+	challengePassword = "password"
 )
 
 // LoginSet 注入Login
@@ -18,13 +28,20 @@ var LoginSet = wire.NewSet(wire.Struct(new(Login), "*"))
 
 // Login 登陆管理
 type Login struct {
-	Auth            auth.Auther
-	UserModel       *repo.User
-	UserRoleModel   *repo.UserRole
-	RoleModel       *repo.Role
-	RoleMenuModel   *repo.RoleMenu
-	MenuModel       *repo.Menu
-	MenuActionModel *repo.MenuAction
+	Auth              auth.Auther
+	CasbinSrv         *Casbin
+	TenantModel       *repo.Tenant
+	UserModel         *repo.User
+	UserRoleModel     *repo.UserRole
+	RoleModel         *repo.Role
+	RoleMenuModel     *repo.RoleMenu
+	RoleParentModel   *repo.RoleParent
+	MenuModel         *repo.Menu
+	MenuActionModel   *repo.MenuAction
+	MFASrv            *MFA
+	LockoutSrv        *Lockout
+	Password          *password.Manager
+	PasswordPolicySrv *PasswordPolicy
 }
 
 // GetCaptcha 获取图形验证码信息
@@ -53,14 +70,70 @@ func (a *Login) ResCaptcha(ctx context.Context, w http.ResponseWriter, captchaID
 	return nil
 }
 
-// Verify 登录验证
-func (a *Login) Verify(ctx context.Context, userName, password string) (*schema.User, error) {
-	//	检查是否是超级用户
-	root := schema.GetRootUser()
-	if userName == root.UserName && root.Password == password {
-		return root, nil
+// GetTenantID 根据租户编号获取登录的租户ID(编号为空时为默认租户)
+func (a *Login) GetTenantID(ctx context.Context, code string) (string, error) {
+	if code == "" {
+		return schema.DefaultTenantID, nil
+	}
+
+	tenant, err := a.TenantModel.GetByCode(ctx, code)
+	if err != nil {
+		return "", err
+	} else if tenant == nil {
+		return "", errors.ErrInvalidTenant
+	} else if tenant.Status != 1 {
+		return "", errors.ErrTenantDisable
+	}
+	return tenant.ID, nil
+}
+
+// 检查租户是否可用
+func (a *Login) checkTenant(ctx context.Context, tenantID string) error {
+	tenant, err := a.TenantModel.Get(ctx, tenantID)
+	if err != nil {
+		return err
+	} else if tenant == nil {
+		return errors.ErrInvalidTenant
+	} else if tenant.Status != 1 {
+		return errors.ErrTenantDisable
+	}
+	return nil
+}
+
+// CheckLockout 检查登录是否被锁定，返回是否需要验证码
+func (a *Login) CheckLockout(ctx context.Context, userName, ip string) (bool, error) {
+	return a.LockoutSrv.Check(ctx, userName, ip)
+}
+
+// Verify 登录验证(记录失败次数，达到阈值时锁定用户名及IP)
+func (a *Login) Verify(ctx context.Context, userName, password, ip string) (*schema.User, error) {
+	user, err := a.verify(ctx, userName, password)
+	if err != nil {
+		if err == errors.ErrInvalidUserName || err == errors.ErrInvalidPassword {
+			if err := a.LockoutSrv.Fail(ctx, userName, ip); err != nil {
+				logger.WithContext(ctx).Errorf("记录登录失败次数发生错误: %s", err.Error())
+			}
+		}
+		return nil, err
+	}
+
+	//	启用两步验证的用户在通过二次验证后才清除失败次数
+	enabled, err := a.MFASrv.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	} else if !enabled {
+		a.resetLockout(ctx, userName, ip)
+	}
+	return user, nil
+}
+
+func (a *Login) resetLockout(ctx context.Context, userName, ip string) {
+	if err := a.LockoutSrv.Succeed(ctx, userName, ip); err != nil {
+		logger.WithContext(ctx).Errorf("清除登录失败次数发生错误: %s", err.Error())
 	}
+}
 
+func (a *Login) verify(ctx context.Context, userName, password string) (*schema.User, error) {
 	result, err := a.UserModel.Query(ctx, schema.UserQueryParam{
 		UserName: userName,
 	})
@@ -71,28 +144,225 @@ func (a *Login) Verify(ctx context.Context, userName, password string) (*schema.
 	}
 
 	item := result.Data[0]
-	if item.Password != hash.SHA1String(password) {
+	ok, rehash, err := a.verifyPassword(item.Password, password)
+	if err != nil {
+		return nil, err
+	} else if !ok {
 		return nil, errors.ErrInvalidPassword
 	} else if item.Status != 1 {
 		return nil, errors.ErrUserDisable
 	}
 
+	//	密码哈希使用的是旧算法或旧参数，使用当前算法重新生成
+	if rehash {
+		if err := a.rehashPassword(ctx, item.ID, password); err != nil {
+			logger.WithContext(ctx).Warnf("重新生成密码哈希失败: %s", err.Error())
+		}
+	}
+
+	//	没有密码修改时间的用户(策略启用前创建)从本次登录开始计算密码有效期
+	if item.PasswordChangedAt == nil {
+		if err := a.UserModel.UpdatePasswordChangedAt(ctx, item.ID, time.Now()); err != nil {
+			logger.WithContext(ctx).Warnf("更新密码修改时间失败: %s", err.Error())
+		}
+	}
+
 	return item, nil
 }
 
-// GenerateToken 生成令牌
-func (a *Login) GenerateToken(ctx context.Context, userID string) (*schema.LoginTokenInfo, error) {
-	tokenInfo, err := a.Auth.GenerateToken(ctx, userID)
+// 校验密码，返回是否匹配及是否需要重新生成哈希
+func (a *Login) verifyPassword(encoded, plain string) (bool, bool, error) {
+	ok, rehash, err := a.Password.Verify(encoded, plain)
+	if err != nil {
+		if err == password.ErrUnknownAlgorithm {
+			return false, false, nil
+		}
+		return false, false, errors.WithStack(err)
+	}
+	return ok, rehash, nil
+}
+
+func (a *Login) rehashPassword(ctx context.Context, userID, plain string) error {
+	encoded, err := a.Password.Hash(plain)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return a.UserModel.UpdatePassword(ctx, userID, encoded)
+}
+
+// GenerateToken 生成令牌(并登记客户端的登录会话，令牌携带用户所属的租户)
+func (a *Login) GenerateToken(ctx context.Context, userID, ip, userAgent string) (*schema.LoginTokenInfo, error) {
+	user, err := a.UserModel.Get(contextx.NewNoDataScope(ctx), userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrInvalidUser
+	} else if err := a.checkTenant(ctx, user.TenantID); err != nil {
+		return nil, err
+	}
+
+	ctx = auth.NewTenantContext(ctx, user.TenantID)
+	tokenInfo, err := a.Auth.GenerateToken(ctx, userID, auth.ClientInfo{
+		IP:        ip,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.toLoginTokenInfo(tokenInfo), nil
+}
+
+// GenerateMFAChallenge 生成两步验证挑战(用户启用两步验证时代替令牌返回)
+func (a *Login) GenerateMFAChallenge(ctx context.Context, userID string) (*schema.LoginMFAChallenge, error) {
+	tokenString, expiresAt, err := a.Auth.GenerateChallengeToken(ctx, userID, challengeMFA)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &schema.LoginMFAChallenge{
+		MFARequired: true,
+		MFAToken:    tokenString,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// VerifyMFA 校验两步验证挑战，返回用户(挑战令牌验证通过后即失效，验证码错误计入登录失败次数)
+func (a *Login) VerifyMFA(ctx context.Context, params schema.LoginMFAParam, ip string) (*schema.User, error) {
+	userID, err := a.Auth.ParseChallengeToken(ctx, params.MFAToken, challengeMFA)
+	if err != nil {
+		if err == auth.ErrInvalidToken {
+			return nil, errors.ErrInvalidToken
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	user, err := a.getChallengeUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	ctx = contextx.NewTenantID(ctx, user.TenantID)
 
-	item := &schema.LoginTokenInfo{
-		AccessToken: tokenInfo.GetAccessToken(),
-		TokenType:   tokenInfo.GetTokenType(),
-		ExpiresAt:   tokenInfo.GetExpiresAt(),
+	if _, err := a.LockoutSrv.Check(ctx, user.UserName, ip); err != nil {
+		return nil, err
 	}
-	return item, nil
+
+	if err := a.MFASrv.Verify(ctx, userID, params.Code); err != nil {
+		if err := a.LockoutSrv.Fail(ctx, user.UserName, ip); err != nil {
+			logger.WithContext(ctx).Errorf("记录登录失败次数发生错误: %s", err.Error())
+		}
+		return nil, err
+	}
+
+	if err := a.Auth.DestroyToken(ctx, params.MFAToken); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	a.resetLockout(ctx, user.UserName, ip)
+	return user, nil
+}
+
+// 获取挑战令牌对应的用户(挑战令牌只携带用户ID，不限定租户查询后再限定为用户所属租户)
+func (a *Login) getChallengeUser(ctx context.Context, userID string) (*schema.User, error) {
+	user, err := a.checkAndGetUser(contextx.NewSystem(ctx), userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrInvalidUser
+	}
+	return user, nil
+}
+
+// CheckPasswordExpired 检查用户密码是否已过期，过期时返回修改密码挑战(未过期时返回nil)
+func (a *Login) CheckPasswordExpired(ctx context.Context, userID string) (*schema.LoginPasswordChallenge, error) {
+	user, err := a.checkAndGetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrInvalidUser
+	} else if !a.PasswordPolicySrv.IsExpired(user) {
+		return nil, nil
+	}
+
+	tokenString, expiresAt, err := a.Auth.GenerateChallengeToken(ctx, userID, challengePassword)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &schema.LoginPasswordChallenge{
+		PasswordExpired: true,
+		PasswordToken:   tokenString,
+		ExpiresAt:       expiresAt,
+	}, nil
+}
+
+// ChangeExpiredPassword 使用修改密码挑战设置新密码，返回用户(挑战令牌使用后即失效)
+func (a *Login) ChangeExpiredPassword(ctx context.Context, params schema.LoginPasswordParam) (*schema.User, error) {
+	userID, err := a.Auth.ParseChallengeToken(ctx, params.PasswordToken, challengePassword)
+	if err != nil {
+		if err == auth.ErrInvalidToken {
+			return nil, errors.ErrInvalidToken
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	user, err := a.getChallengeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ctx = contextx.NewTenantID(ctx, user.TenantID)
+
+	if err := a.PasswordPolicySrv.Validate(ctx, user, params.NewPassword); err != nil {
+		return nil, err
+	}
+
+	if err := a.PasswordPolicySrv.Change(ctx, userID, params.NewPassword); err != nil {
+		return nil, err
+	}
+
+	if err := a.Auth.DestroyToken(ctx, params.PasswordToken); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return user, nil
+}
+
+func (a *Login) toLoginTokenInfo(tokenInfo auth.TokenInfo) *schema.LoginTokenInfo {
+	return &schema.LoginTokenInfo{
+		AccessToken:  tokenInfo.GetAccessToken(),
+		RefreshToken: tokenInfo.GetRefreshToken(),
+		TokenType:    tokenInfo.GetTokenType(),
+		ExpiresAt:    tokenInfo.GetExpiresAt(),
+	}
+}
+
+// RefreshToken 使用刷新令牌换取新的令牌
+func (a *Login) RefreshToken(ctx context.Context, refreshToken string) (*schema.LoginTokenInfo, error) {
+	tokenInfo, userID, err := a.Auth.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		if err == auth.ErrRefreshTokenReused {
+			logger.WithContext(ctx).Warnf("刷新令牌被重复使用，已撤销该令牌族")
+			return nil, errors.ErrInvalidToken
+		} else if err == auth.ErrInvalidToken {
+			return nil, errors.ErrInvalidToken
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	//	刷新令牌只携带用户ID，不限定租户查询后再检查用户所属租户
+	user, err := a.checkAndGetUser(contextx.NewSystem(ctx), userID)
+	if err == nil && user == nil {
+		err = errors.ErrInvalidUser
+	}
+	if err == nil {
+		err = a.checkTenant(ctx, user.TenantID)
+	}
+	if err != nil {
+		//	用户或租户已不可用，撤销新签发的令牌
+		_ = a.Auth.DestroyToken(ctx, tokenInfo.GetAccessToken())
+		return nil, err
+	}
+
+	return a.toLoginTokenInfo(tokenInfo), nil
 }
 
 // DestroyToken 销毁令牌
@@ -104,6 +374,65 @@ func (a *Login) DestroyToken(ctx context.Context, tokenString string) error {
 	return nil
 }
 
+// Impersonate 模拟指定用户登录(当前用户作为实际操作者记录在令牌中)
+func (a *Login) Impersonate(ctx context.Context, userID, ip, userAgent string) (*schema.LoginTokenInfo, error) {
+	if !config.C.Impersonation.Enable {
+		return nil, errors.New400Response("未启用模拟登录")
+	} else if _, ok := contextx.FromActorID(ctx); ok {
+		return nil, errors.ErrImpersonationForbidden
+	}
+
+	actorID, _ := contextx.FromUserID(ctx)
+	if actorID == userID {
+		return nil, errors.New400Response("不能模拟自己登录")
+	}
+
+	user, err := a.checkAndGetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrNotFound
+	} else if user.IsSuper {
+		return nil, errors.New400Response("不允许模拟超级管理员登录")
+	} else if err := a.checkImpersonator(ctx, user, actorID); err != nil {
+		return nil, err
+	}
+
+	tokenInfo, err := a.Auth.GenerateImpersonationToken(auth.NewTenantContext(ctx, user.TenantID), userID, actorID, auth.ClientInfo{
+		IP:        ip,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	ctx = logger.NewUserIDContext(ctx, userID)
+	ctx = logger.NewActorIDContext(ctx, actorID)
+	ctx = logger.NewTagContext(ctx, "__impersonate__")
+	logger.WithContext(ctx).Warnf("开始模拟用户[%s]登录", user.UserName)
+	return a.toLoginTokenInfo(tokenInfo), nil
+}
+
+// 检查实际操作者是否有权模拟指定用户(非超级管理员的权限必须包含被模拟用户的全部权限)
+func (a *Login) checkImpersonator(ctx context.Context, user *schema.User, actorID string) error {
+	actor, err := a.UserModel.Get(contextx.NewNoDataScope(ctx), actorID)
+	if err != nil {
+		return err
+	} else if actor == nil {
+		return errors.ErrNoPerm
+	} else if actor.IsSuper {
+		return nil
+	}
+
+	ok, err := a.CasbinSrv.ContainsUser(ctx, user.TenantID, actorID, user.ID)
+	if err != nil {
+		return err
+	} else if !ok {
+		return errors.ErrNoPerm
+	}
+	return nil
+}
+
 func (a *Login) checkAndGetUser(ctx context.Context, userID string) (*schema.User, error) {
 	user, err := a.UserModel.Get(ctx, userID)
 	if err != nil {
@@ -118,28 +447,24 @@ func (a *Login) checkAndGetUser(ctx context.Context, userID string) (*schema.Use
 
 // GetLoginInfo 获取当前用户登录信息
 func (a *Login) GetLoginInfo(ctx context.Context, userID string) (*schema.UserLoginInfo, error) {
-	if isRoot := schema.CheckIsRootUser(ctx, userID); isRoot {
-		root := schema.GetRootUser()
-		loginInfo := &schema.UserLoginInfo{
-			UserName: root.UserName,
-			RealName: root.RealName,
-		}
-		return loginInfo, nil
-	}
-
 	user, err := a.checkAndGetUser(ctx, userID)
 	if err != nil {
 		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrInvalidUser
 	}
 
 	info := &schema.UserLoginInfo{
 		UserID:   user.ID,
 		UserName: user.UserName,
 		RealName: user.RealName,
+		IsSuper:  user.IsSuper,
 	}
 
+	now := time.Now()
 	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
-		UserID: userID,
+		UserID:  userID,
+		ValidAt: &now,
 	})
 	if err != nil {
 		return nil, err
@@ -161,10 +486,15 @@ func (a *Login) GetLoginInfo(ctx context.Context, userID string) (*schema.UserLo
 
 // QueryUserMenuTree 查询当前用户的权限菜单
 func (a *Login) QueryUserMenuTree(ctx context.Context, userID string) (schema.MenuTrees, error) {
-	isRoot := schema.CheckIsRootUser(ctx, userID)
+	user, err := a.checkAndGetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrInvalidUser
+	}
 
-	// 如果是root用户 则查询所有显示的菜单树
-	if isRoot {
+	// 如果是超级管理员 则查询所有显示的菜单树
+	if user.IsSuper {
 		result, err := a.MenuModel.Query(ctx, schema.MenuQueryParam{
 			Status: 1,
 		}, schema.MenuQueryOptions{
@@ -181,8 +511,10 @@ func (a *Login) QueryUserMenuTree(ctx context.Context, userID string) (schema.Me
 		return result.Data.FillMenuAction(menuActionResult.Data.ToMenuIDMap()).ToTree(), nil
 	}
 
+	now := time.Now()
 	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
-		UserID: userID,
+		UserID:  userID,
+		ValidAt: &now,
 	})
 	if err != nil {
 		return nil, err
@@ -190,8 +522,13 @@ func (a *Login) QueryUserMenuTree(ctx context.Context, userID string) (schema.Me
 		return nil, errors.ErrNoPerm
 	}
 
+	roleIDs, err := a.queryInheritedRoleIDs(ctx, userRoleResult.Data.ToRoleIDs())
+	if err != nil {
+		return nil, err
+	}
+
 	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
-		RoleIDs: userRoleResult.Data.ToRoleIDs(),
+		RoleIDs: roleIDs,
 	})
 	if err != nil {
 		return nil, err
@@ -239,19 +576,55 @@ func (a *Login) QueryUserMenuTree(ctx context.Context, userID string) (schema.Me
 	return menuResult.Data.FillMenuAction(menuActionResult.Data.ToMenuIDMap()).ToTree(), nil
 }
 
+// 查询角色及其通过已启用角色继承的所有祖先角色ID
+func (a *Login) queryInheritedRoleIDs(ctx context.Context, roleIDs []string) ([]string, error) {
+	roleParentResult, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{})
+	if err != nil {
+		return nil, err
+	} else if len(roleParentResult.Data) == 0 {
+		return roleIDs, nil
+	}
+
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		Status: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	//	与casbin的g规则一致，只有继承双方均已启用时继承关系才生效
+	mRoles := roleResult.Data.ToMap()
+	var roleParents schema.RoleParents
+	for _, item := range roleParentResult.Data {
+		if _, ok := mRoles[item.RoleID]; !ok {
+			continue
+		} else if _, ok := mRoles[item.ParentID]; !ok {
+			continue
+		}
+		roleParents = append(roleParents, item)
+	}
+
+	return append(roleIDs, roleParents.Ancestors(roleIDs...)...), nil
+}
+
 // UpdatePassword 更新当前用户登陆密码
 func (a *Login) UpdatePassword(ctx context.Context, userID string, params schema.UpdatePasswordParam) error {
-	if schema.CheckIsRootUser(ctx, userID) {
-		return errors.New400Response("root用户不允许更新密码")
+	user, err := a.checkAndGetUser(ctx, userID)
+	if err != nil {
+		return err
+	} else if user == nil {
+		return errors.ErrInvalidUser
 	}
 
-	user, err := a.checkAndGetUser(ctx, userID)
+	ok, _, err := a.verifyPassword(user.Password, params.OldPassword)
 	if err != nil {
 		return err
-	} else if hash.SHA1String(params.OldPassword) != user.Password {
+	} else if !ok {
 		return errors.New400Response("旧密码不正确")
 	}
 
-	params.NewPassword = hash.SHA1String(params.NewPassword)
-	return a.UserModel.UpdatePassword(ctx, userID, params.NewPassword)
+	if err := a.PasswordPolicySrv.Validate(ctx, user, params.NewPassword); err != nil {
+		return err
+	}
+	return a.PasswordPolicySrv.Change(ctx, userID, params.NewPassword)
 }
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/jwtauth"
+	"ginAdmin/pkg/auth/jwtauth/store/memory"
+	"ginAdmin/pkg/errors"
+	"testing"
+)
+
+func TestLoginImpersonate(t *testing.T) {
+	env := newTestEnv(t)
+	casbinSrv := env.newCasbin(t, nil)
+	setTestConfig(t, func(c *config.Config) {
+		c.Impersonation.Enable = true
+	})
+
+	userMenu, userAction := env.createMenu(t, "t1", "/api/v1/users", "GET")
+	roleMenu, roleAction := env.createMenu(t, "t1", "/api/v1/roles", "GET")
+	env.createRole(t, "t1", "manager", userMenu, userAction, roleMenu, roleAction)
+	env.createRole(t, "t1", "viewer", userMenu, userAction)
+	env.createUser(t, "t1", "root", true)
+	env.createUser(t, "t1", "manager1", false, "manager")
+	env.createUser(t, "t1", "viewer1", false, "viewer")
+	if err := casbinSrv.Enforcer.LoadPolicy(); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &Login{
+		Auth:        jwtauth.New(memory.NewStore(0)),
+		CasbinSrv:   casbinSrv,
+		TenantModel: env.TenantModel,
+		UserModel:   env.UserModel,
+	}
+	newCtx := func(actorID string) context.Context {
+		return contextx.NewUserID(contextx.NewTenantID(context.Background(), "t1"), actorID)
+	}
+
+	// 权限包含被模拟用户时允许，超出操作者权限时拒绝
+	if _, err := a.Impersonate(newCtx("manager1"), "viewer1", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Impersonate(newCtx("viewer1"), "manager1", "", ""); err != errors.ErrNoPerm {
+		t.Fatalf("expected ErrNoPerm, got %v", err)
+	}
+	if _, err := a.Impersonate(newCtx("root"), "manager1", "", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// 撤销被模拟用户或实际操作者的会话后模拟登录令牌失效
+	for _, userID := range []string{"viewer1", "manager1"} {
+		tokenInfo, err := a.Impersonate(newCtx("manager1"), "viewer1", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		identity, err := a.Auth.ParseIdentity(context.Background(), tokenInfo.AccessToken)
+		if err != nil {
+			t.Fatal(err)
+		} else if identity.UserID != "viewer1" || identity.ActorID != "manager1" {
+			t.Fatalf("unexpected identity: %+v", identity)
+		}
+
+		if err := a.Auth.RevokeSessions(context.Background(), userID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := a.Auth.ParseIdentity(context.Background(), tokenInfo.AccessToken); err != auth.ErrInvalidToken {
+			t.Fatalf("expected impersonation token to be revoked with %s, got %v", userID, err)
+		}
+	}
+}
@@ -4,9 +4,24 @@ import "github.com/google/wire"
 
 // ServiceSet bll注入
 var ServiceSet = wire.NewSet(
+	APIKeySet,
+	AuditLogSet,
+	CasbinSet,
+	DataScopeSet,
 	DemoSet,
+	DeptSet,
+	ExternalLoginSet,
+	LockoutSet,
 	LoginSet,
+	MFASet,
 	MenuSet,
+	PasswordPolicySet,
+	PasswordResetSet,
+	PermissionSet,
+	RecycleBinSet,
 	RoleSet,
+	SessionSet,
+	TenantSet,
 	UserSet,
+	UserRoleSet,
 )
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/model/gormx/gormxtest"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/module/adapter"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/password"
+	"ginAdmin/pkg/util/uuid"
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/persist"
+	"gorm.io/gorm"
+	"testing"
+)
+
+// casbin模型文件
+const testCasbinModel = "../../../configs/model.conf"
+
+// 测试环境(基于sqlite的存储及casbin适配器)
+type testEnv struct {
+	DB                *gorm.DB
+	TransModel        *repo.Trans
+	TenantModel       *repo.Tenant
+	UserModel         *repo.User
+	UserRoleModel     *repo.UserRole
+	RoleModel         *repo.Role
+	RoleMenuModel     *repo.RoleMenu
+	RoleParentModel   *repo.RoleParent
+	MenuModel         *repo.Menu
+	MenuActionModel   *repo.MenuAction
+	MenuResourceModel *repo.MenuActionResource
+	Adapter           *adapter.CasbinAdapter
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	db := gormxtest.NewDB(t)
+	env := &testEnv{
+		DB:                db,
+		TransModel:        &repo.Trans{DB: db},
+		TenantModel:       repo.NewTenant(db),
+		UserModel:         repo.NewUser(db),
+		UserRoleModel:     &repo.UserRole{DB: db},
+		RoleModel:         repo.NewRole(db),
+		RoleMenuModel:     &repo.RoleMenu{DB: db},
+		RoleParentModel:   &repo.RoleParent{DB: db},
+		MenuModel:         repo.NewMenu(db),
+		MenuActionModel:   &repo.MenuAction{DB: db},
+		MenuResourceModel: &repo.MenuActionResource{DB: db},
+	}
+	env.Adapter = &adapter.CasbinAdapter{
+		RoleModel:         env.RoleModel,
+		RoleMenuModel:     env.RoleMenuModel,
+		RoleParentModel:   env.RoleParentModel,
+		MenuResourceModel: env.MenuResourceModel,
+		UserModel:         env.UserModel,
+		UserRoleModel:     env.UserRoleModel,
+	}
+	return env
+}
+
+// 设定测试使用的配置(测试结束后恢复)
+func setTestConfig(t *testing.T, fn func(c *config.Config)) {
+	old := *config.C
+	fn(config.C)
+	t.Cleanup(func() { *config.C = old })
+}
+
+// 启用casbin并从存储加载策略(w为空时不设置watcher)
+func (env *testEnv) newCasbin(t *testing.T, w persist.Watcher) *Casbin {
+	setTestConfig(t, func(c *config.Config) {
+		c.Casbin.Enable = true
+	})
+
+	e, err := casbin.NewSyncedEnforcer(testCasbinModel, env.Adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w != nil {
+		if err := e.SetWatcher(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &Casbin{Enforcer: e, Adapter: env.Adapter, Watcher: w}
+}
+
+func (env *testEnv) create(t *testing.T, tenantID string, items ...interface{}) {
+	ctx := contextx.NewTenantID(context.Background(), tenantID)
+	for _, item := range items {
+		if err := env.DB.WithContext(ctx).Create(item).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// 创建菜单及其动作资源，返回菜单ID及动作ID
+func (env *testEnv) createMenu(t *testing.T, tenantID, path, method string) (string, string) {
+	menu := &entity.Menu{ID: uuid.MustString(), Name: path, Status: 1, ShowStatus: 1}
+	action := &entity.MenuAction{ID: uuid.MustString(), MenuID: menu.ID, Code: method, Name: method}
+	resource := &entity.MenuActionResource{ID: uuid.MustString(), ActionID: action.ID, Path: path, Method: method}
+	env.create(t, tenantID, menu, action, resource)
+	return menu.ID, action.ID
+}
+
+// 创建已启用的角色，并授权指定菜单动作(menuActions为菜单ID及动作ID交替排列)
+func (env *testEnv) createRole(t *testing.T, tenantID, roleID string, menuActions ...string) {
+	env.create(t, tenantID, &entity.Role{ID: roleID, Name: roleID, Status: 1})
+	for i := 0; i+1 < len(menuActions); i += 2 {
+		env.create(t, tenantID, &entity.RoleMenu{ID: uuid.MustString(), RoleID: roleID, MenuID: menuActions[i], ActionID: menuActions[i+1]})
+	}
+}
+
+// 创建已启用的用户，并授权指定角色
+func (env *testEnv) createUser(t *testing.T, tenantID, userID string, isSuper bool, roleIDs ...string) {
+	env.create(t, tenantID, &entity.User{ID: userID, UserName: userID, RealName: userID, Status: 1, IsSuper: isSuper})
+	for _, roleID := range roleIDs {
+		env.create(t, tenantID, &entity.UserRole{ID: uuid.MustString(), UserID: userID, RoleID: roleID})
+	}
+}
+
+// 创建用户管理服务(密码使用低成本的bcrypt哈希)
+func (env *testEnv) newUserSrv(a auth.Auther, casbinSrv *Casbin) *User {
+	passwordHistoryModel := &repo.PasswordHistory{DB: env.DB}
+	return &User{
+		Auth:                 a,
+		CasbinSrv:            casbinSrv,
+		TransModel:           env.TransModel,
+		UserModel:            env.UserModel,
+		UserRoleModel:        env.UserRoleModel,
+		RoleModel:            env.RoleModel,
+		DeptModel:            repo.NewDept(env.DB),
+		PasswordHistoryModel: passwordHistoryModel,
+		UserIdentityModel:    &repo.UserIdentity{DB: env.DB},
+		APIKeyModel:          &repo.APIKey{DB: env.DB},
+		UserMFAModel:         &repo.UserMFA{DB: env.DB},
+		PasswordResetModel:   &repo.PasswordReset{DB: env.DB},
+		PasswordPolicySrv: &PasswordPolicy{
+			Policy:               &password.Policy{HistorySize: 3},
+			Password:             password.New(password.NewBcrypt(4)),
+			TransModel:           env.TransModel,
+			UserModel:            env.UserModel,
+			PasswordHistoryModel: passwordHistoryModel,
+		},
+	}
+}
+
+// 创建角色管理服务
+func (env *testEnv) newRoleSrv(casbinSrv *Casbin) *Role {
+	return &Role{
+		CasbinSrv:       casbinSrv,
+		TransModel:      env.TransModel,
+		RoleModel:       env.RoleModel,
+		RoleMenuModel:   env.RoleMenuModel,
+		RoleDeptModel:   &repo.RoleDept{DB: env.DB},
+		RoleParentModel: env.RoleParentModel,
+		DeptModel:       repo.NewDept(env.DB),
+		UserModel:       env.UserModel,
+		UserRoleModel:   env.UserRoleModel,
+	}
+}
+
+// 统计数据表中满足条件的全部数据(包括已删除的数据)
+func (env *testEnv) count(t *testing.T, model interface{}, conds ...interface{}) int64 {
+	db := env.DB.Unscoped().Model(model)
+	if len(conds) > 0 {
+		db = db.Where(conds[0], conds[1:]...)
+	}
+
+	var n int64
+	if err := db.Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
@@ -10,6 +10,7 @@ import (
 	"ginAdmin/pkg/util/yaml"
 	"github.com/google/wire"
 	"os"
+	"time"
 )
 
 // MenuSet 注入Menu
@@ -17,10 +18,12 @@ var MenuSet = wire.NewSet(wire.Struct(new(Menu), "*"))
 
 // Menu 菜单管理
 type Menu struct {
+	CasbinSrv               *Casbin
 	TransModel              *repo.Trans
 	MenuModel               *repo.Menu
 	MenuActionModel         *repo.MenuAction
 	MenuActionResourceModel *repo.MenuActionResource
+	RoleMenuModel           *repo.RoleMenu
 }
 
 // InitData 初始化菜单数据
@@ -108,7 +111,7 @@ func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...
 
 // Get 查询指定数据
 func (a *Menu) Get(ctx context.Context, id string, opts ...schema.MenuQueryOptions) (*schema.Menu, error) {
-	item, err := a.MenuModel.Get(ctx, id, opts...)
+	item, err := a.MenuModel.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	} else if item == nil {
@@ -248,6 +251,8 @@ func (a *Menu) Update(ctx context.Context, id string, item schema.Menu) error {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
+	} else if oldItem.Version != item.Version {
+		return errors.ErrPreconditionFailed
 	} else if oldItem.Name != item.Name {
 		if err := a.checkName(ctx, item); err != nil {
 			return err
@@ -268,18 +273,19 @@ func (a *Menu) Update(ctx context.Context, id string, item schema.Menu) error {
 		item.ParentPath = oldItem.ParentPath
 	}
 
+	// 先按版本号更新菜单，版本冲突时不再变更动作数据
 	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
-		err := a.updateActions(ctx, id, oldItem.Actions, item.Actions)
+		err := a.MenuModel.Update(ctx, id, item)
 		if err != nil {
 			return err
 		}
 
-		err = a.updateChildParentPath(ctx, *oldItem, item)
+		err = a.updateActions(ctx, id, oldItem.Actions, item.Actions)
 		if err != nil {
 			return err
 		}
 
-		return a.MenuModel.Update(ctx, id, item)
+		return a.updateChildParentPath(ctx, *oldItem, item)
 	})
 }
 
@@ -401,7 +407,7 @@ func (a *Menu) updateChildParentPath(ctx context.Context, oldItem, newItem schem
 	return nil
 }
 
-// Delete 删除数据
+// Delete 删除数据(软删除，角色通过该菜单获得的权限随之失效)
 func (a *Menu) Delete(ctx context.Context, id string) error {
 	oldItem, err := a.MenuModel.Get(ctx, id)
 	if err != nil {
@@ -420,29 +426,114 @@ func (a *Menu) Delete(ctx context.Context, id string) error {
 		return errors.ErrNotAllowDeleteWithChild
 	}
 
-	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
-		err = a.MenuActionResourceModel.DeleteByMenuID(ctx, id)
-		if err != nil {
-			return err
-		}
+	// 菜单的动作及资源保留到彻底删除，以便从回收站恢复
+	err = a.MenuModel.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	a.CasbinSrv.Reload(ctx)
+	return nil
+}
 
-		err := a.MenuActionModel.DeleteByMenuID(ctx, id)
+// UpdateStatus 更新状态(version为客户端读取时的版本号)
+func (a *Menu) UpdateStatus(ctx context.Context, id string, status int, version int64) error {
+	oldItem, err := a.MenuModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	} else if oldItem.Version != version {
+		return errors.ErrPreconditionFailed
+	}
+
+	return a.MenuModel.UpdateStatus(ctx, id, status, version)
+}
+
+// QueryDeleted 查询回收站中的数据
+func (a *Menu) QueryDeleted(ctx context.Context, params schema.RecycleQueryParam) (*schema.RecycleQueryResult, error) {
+	return a.MenuModel.QueryDeleted(ctx, params)
+}
+
+// Restore 从回收站恢复数据(上级菜单须存在，角色通过该菜单获得的权限随之恢复)
+func (a *Menu) Restore(ctx context.Context, id string) error {
+	oldItem, err := a.MenuModel.GetDeleted(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	if err := a.checkName(ctx, *oldItem); err != nil {
+		return err
+	}
+
+	parentPath, err := a.getParentPath(ctx, oldItem.ParentID)
+	if err != nil {
+		return err
+	}
+
+	err = a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		err := a.MenuModel.Restore(ctx, id)
 		if err != nil {
 			return err
 		}
 
-		return a.MenuModel.Delete(ctx, id)
+		// 删除期间上级菜单可能被移动
+		if parentPath != oldItem.ParentPath {
+			return a.MenuModel.UpdateParentPath(ctx, id, parentPath)
+		}
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	a.CasbinSrv.Reload(ctx)
+	return nil
 }
 
-// UpdateStatus 更新状态
-func (a *Menu) UpdateStatus(ctx context.Context, id string, status int) error {
-	oldItem, err := a.MenuModel.Get(ctx, id)
+// Purge 彻底删除删除时间早于指定时间的数据(同时删除菜单的动作、资源及角色授权)
+func (a *Menu) Purge(ctx context.Context, before time.Time) (int, error) {
+	ids, err := a.MenuModel.QueryPurgeIDs(ctx, before)
 	if err != nil {
-		return err
-	} else if oldItem == nil {
-		return errors.ErrNotFound
+		return 0, err
+	}
+	return len(ids), a.purge(ctx, ids)
+}
+
+// PurgeTenant 彻底删除上下文中租户的全部数据
+func (a *Menu) PurgeTenant(ctx context.Context) (int, error) {
+	ids, err := a.MenuModel.DeleteTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.purge(ctx, ids)
+}
+
+func (a *Menu) purge(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
 	}
 
-	return a.MenuModel.UpdateStatus(ctx, id, status)
+	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		for _, id := range ids {
+			err := a.MenuActionResourceModel.DeleteByMenuID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.MenuActionModel.DeleteByMenuID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.RoleMenuModel.DeleteByMenuID(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return a.MenuModel.Purge(ctx, ids)
+	})
 }
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/util/hash"
+	"ginAdmin/pkg/util/uuid"
+	"github.com/google/wire"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"strings"
+	"time"
+)
+
+// MFASet 注入MFA
+var MFASet = wire.NewSet(wire.Struct(new(MFA), "*"))
+
+// 两步验证状态
+const (
+	mfaStatusEnabled = 1
+	mfaStatusPending = 2
+)
+
+// TOTP参数(RFC 6238默认值，兼容主流身份验证器应用)
+const (
+	totpPeriod = 30
+	totpSkew   = 1
+	totpDigits = otp.DigitsSix
+)
+
+// MFA 两步验证管理
+type MFA struct {
+	TransModel   *repo.Trans
+	UserModel    *repo.User
+	UserMFAModel *repo.UserMFA
+}
+
+// GetStatus 查询用户的两步验证状态
+func (a *MFA) GetStatus(ctx context.Context, userID string) (*schema.UserMFAStatus, error) {
+	enabled, err := a.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.UserMFAStatus{Enabled: enabled}, nil
+}
+
+// IsEnabled 检查用户是否已启用两步验证
+func (a *MFA) IsEnabled(ctx context.Context, userID string) (bool, error) {
+	item, err := a.UserMFAModel.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return item != nil && item.Status == mfaStatusEnabled, nil
+}
+
+// Enroll 登记新的TOTP密钥(需要通过Confirm确认后才会启用)
+func (a *MFA) Enroll(ctx context.Context, userID string) (*schema.UserMFAEnrollResult, error) {
+	user, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrInvalidUser
+	}
+
+	oldItem, err := a.UserMFAModel.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if oldItem != nil && oldItem.Status == mfaStatusEnabled {
+		return nil, errors.New400Response("已启用两步验证，请先关闭")
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      config.C.MFA.Issuer,
+		AccountName: user.UserName,
+		Period:      totpPeriod,
+		Digits:      totpDigits,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	item := schema.UserMFA{
+		UserID: userID,
+		Secret: key.Secret(),
+		Status: mfaStatusPending,
+	}
+	if oldItem != nil {
+		item.ID = oldItem.ID
+		err = a.UserMFAModel.Update(ctx, oldItem.ID, item)
+	} else {
+		item.ID = uuid.MustString()
+		err = a.UserMFAModel.Create(ctx, item)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.UserMFAEnrollResult{
+		Secret: key.Secret(),
+		URL:    key.URL(),
+	}, nil
+}
+
+// Confirm 使用TOTP验证码确认并启用两步验证，返回一次性恢复码
+func (a *MFA) Confirm(ctx context.Context, userID, code string) (*schema.UserMFARecoveryCodes, error) {
+	item, err := a.UserMFAModel.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if item == nil || item.Status != mfaStatusPending {
+		return nil, errors.New400Response("请先登记两步验证密钥")
+	}
+
+	step, ok := a.validateTOTP(item, code)
+	if !ok {
+		return nil, errors.New400Response("无效的验证码")
+	}
+
+	codes, hashes, err := a.generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	item.LastStep = step
+	item.RecoveryCodes = strings.Join(hashes, ",")
+	item.Status = mfaStatusEnabled
+	err = a.UserMFAModel.Update(ctx, item.ID, *item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.UserMFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// Disable 关闭两步验证(需要提供TOTP验证码或恢复码)
+func (a *MFA) Disable(ctx context.Context, userID, code string) error {
+	enabled, err := a.IsEnabled(ctx, userID)
+	if err != nil {
+		return err
+	} else if !enabled {
+		return errors.New400Response("未启用两步验证")
+	}
+
+	err = a.Verify(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+
+	return a.UserMFAModel.DeleteByUserID(ctx, userID)
+}
+
+// Verify 校验用户的TOTP验证码或恢复码(验证码及恢复码均仅能使用一次)
+func (a *MFA) Verify(ctx context.Context, userID, code string) error {
+	//	加锁执行，防止同一验证码被并发使用
+	return a.TransModel.Exec(contextx.NewTransLock(ctx), func(ctx context.Context) error {
+		item, err := a.UserMFAModel.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		} else if item == nil || item.Status != mfaStatusEnabled {
+			return errors.New400Response("未启用两步验证")
+		}
+
+		if step, ok := a.validateTOTP(item, code); ok {
+			item.LastStep = step
+		} else if codes, ok := a.useRecoveryCode(item, code); ok {
+			item.RecoveryCodes = codes
+		} else {
+			return errors.New400Response("无效的验证码")
+		}
+
+		return a.UserMFAModel.Update(ctx, item.ID, *item)
+	})
+}
+
+// 校验TOTP验证码(允许前后一个时间步的偏差)，返回匹配的时间步
+func (a *MFA) validateTOTP(item *schema.UserMFA, code string) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits.Length() {
+		return 0, false
+	}
+
+	now := time.Now()
+	opts := totp.ValidateOpts{
+		Period:    totpPeriod,
+		Digits:    totpDigits,
+		Algorithm: otp.AlgorithmSHA1,
+	}
+	for i := -totpSkew; i <= totpSkew; i++ {
+		t := now.Add(time.Duration(i*totpPeriod) * time.Second)
+		step := t.Unix() / totpPeriod
+		if step <= item.LastStep {
+			//	已使用过的时间步不允许再次使用
+			continue
+		}
+
+		expected, err := totp.GenerateCodeCustom(item.Secret, t, opts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// 使用恢复码，返回剩余的恢复码哈希
+func (a *MFA) useRecoveryCode(item *schema.UserMFA, code string) (string, bool) {
+	if item.RecoveryCodes == "" {
+		return "", false
+	}
+
+	h := hash.SHA1String(a.normalizeRecoveryCode(code))
+	hashes := strings.Split(item.RecoveryCodes, ",")
+	for i, v := range hashes {
+		if subtle.ConstantTimeCompare([]byte(v), []byte(h)) == 1 {
+			hashes = append(hashes[:i], hashes[i+1:]...)
+			return strings.Join(hashes, ","), true
+		}
+	}
+	return "", false
+}
+
+func (a *MFA) normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
+// 生成恢复码，返回恢复码明文及其哈希
+func (a *MFA) generateRecoveryCodes() ([]string, []string, error) {
+	n := config.C.MFA.RecoveryCodes
+	if n <= 0 {
+		n = 10
+	}
+
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, n)
+	hashes := make([]string, n)
+	for i := 0; i < n; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		v := strings.ToLower(encoding.EncodeToString(b))
+		codes[i] = strings.Join([]string{v[:4], v[4:8], v[8:12], v[12:]}, "-")
+		hashes[i] = hash.SHA1String(v)
+	}
+	return codes, hashes, nil
+}
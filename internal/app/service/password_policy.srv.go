@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/auth/password"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/util/uuid"
+	"github.com/google/wire"
+	"time"
+)
+
+// PasswordPolicySet 注入PasswordPolicy
+var PasswordPolicySet = wire.NewSet(wire.Struct(new(PasswordPolicy), "*"))
+
+// PasswordPolicy 密码策略
+type PasswordPolicy struct {
+	Policy               *password.Policy
+	Password             *password.Manager
+	TransModel           *repo.Trans
+	UserModel            *repo.User
+	PasswordHistoryModel *repo.PasswordHistory
+}
+
+// Validate 校验新密码是否符合密码策略(user.ID为空时表示新用户，不校验历史密码)
+func (a *PasswordPolicy) Validate(ctx context.Context, user *schema.User, plain string) error {
+	violations := a.Policy.Validate(plain, user.UserName)
+
+	if user.ID != "" && a.Policy.HistorySize > 0 {
+		reused, err := a.isReused(ctx, user, plain)
+		if err != nil {
+			return err
+		} else if reused {
+			violations = append(violations, password.Violation{
+				Rule:    password.RuleHistory,
+				Message: "不能使用最近使用过的密码",
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.WithDetails(errors.ErrPasswordPolicy, violations)
+	}
+	return nil
+}
+
+// 检查密码是否与当前密码或最近使用过的密码相同
+func (a *PasswordPolicy) isReused(ctx context.Context, user *schema.User, plain string) (bool, error) {
+	list, err := a.PasswordHistoryModel.QueryByUserID(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+
+	encodeds := []string{user.Password}
+	for i, item := range list {
+		if i >= a.Policy.HistorySize {
+			break
+		}
+		encodeds = append(encodeds, item.Password)
+	}
+
+	for _, encoded := range encodeds {
+		if encoded == "" {
+			continue
+		}
+
+		ok, _, err := a.Password.Verify(encoded, plain)
+		if err != nil {
+			if err == password.ErrUnknownAlgorithm {
+				continue
+			}
+			return false, errors.WithStack(err)
+		} else if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Hash 生成密码哈希
+func (a *PasswordPolicy) Hash(plain string) (string, error) {
+	encoded, err := a.Password.Hash(plain)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return encoded, nil
+}
+
+// Change 修改用户密码(更新密码修改时间并记录历史密码，调用前应先通过Validate校验)
+func (a *PasswordPolicy) Change(ctx context.Context, userID, plain string) error {
+	encoded, err := a.Hash(plain)
+	if err != nil {
+		return err
+	}
+
+	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		err := a.UserModel.ChangePassword(ctx, userID, encoded, time.Now())
+		if err != nil {
+			return err
+		}
+		return a.Record(ctx, userID, encoded)
+	})
+}
+
+// Record 记录历史密码(仅保留最近HistorySize条)
+func (a *PasswordPolicy) Record(ctx context.Context, userID, encoded string) error {
+	if a.Policy.HistorySize <= 0 {
+		return nil
+	}
+
+	err := a.PasswordHistoryModel.Create(ctx, schema.PasswordHistory{
+		ID:        uuid.MustString(),
+		UserID:    userID,
+		Password:  encoded,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	list, err := a.PasswordHistoryModel.QueryByUserID(ctx, userID)
+	if err != nil {
+		return err
+	} else if len(list) <= a.Policy.HistorySize {
+		return nil
+	}
+	return a.PasswordHistoryModel.Delete(ctx, list[a.Policy.HistorySize:].ToIDs()...)
+}
+
+// IsExpired 检查用户密码是否已过期
+func (a *PasswordPolicy) IsExpired(user *schema.User) bool {
+	if user.PasswordChangedAt == nil {
+		return false
+	}
+	return a.Policy.IsExpired(*user.PasswordChangedAt)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/lockout"
+	"ginAdmin/pkg/logger"
+	"ginAdmin/pkg/notify"
+	"ginAdmin/pkg/util/hash"
+	"ginAdmin/pkg/util/uuid"
+	"github.com/google/wire"
+	"time"
+)
+
+// PasswordResetSet 注入PasswordReset
+var PasswordResetSet = wire.NewSet(wire.Struct(new(PasswordReset), "*"))
+
+// PasswordResetLimiter 密码重置申请频率限制(按用户及IP统计申请次数，未启用时为nil)
+type PasswordResetLimiter struct {
+	*lockout.Limiter
+}
+
+// PasswordReset 密码重置
+type PasswordReset struct {
+	Auth               auth.Auther
+	TransModel         *repo.Trans
+	UserModel          *repo.User
+	PasswordResetModel *repo.PasswordReset
+	PasswordPolicySrv  *PasswordPolicy
+	LoginSrv           *Login
+	Limiter            *PasswordResetLimiter
+	Notifier           notify.Notifier
+}
+
+// Request 申请密码重置(无论用户是否存在都返回成功，避免泄露用户信息)
+func (a *PasswordReset) Request(ctx context.Context, params schema.PasswordResetRequestParam, ip string) error {
+	if a.Notifier == nil {
+		return errors.New400Response("未启用密码重置")
+	}
+
+	//	用户名只在租户内唯一，在所属租户内查找用户(租户无效时同样计入申请频率并返回成功，避免泄露租户信息)
+	tenantID, err := a.LoginSrv.GetTenantID(ctx, params.TenantCode)
+	if err == errors.ErrInvalidTenant || err == errors.ErrTenantDisable {
+		logger.WithContext(ctx).Warnf("申请密码重置的租户[%s]无效: %s", params.TenantCode, err.Error())
+		return a.checkLimit(ctx, params.TenantCode, params.UserName, ip)
+	} else if err != nil {
+		return err
+	}
+	ctx = contextx.NewTenantID(ctx, tenantID)
+
+	if err := a.checkLimit(ctx, tenantID, params.UserName, ip); err != nil {
+		return err
+	}
+
+	result, err := a.UserModel.Query(contextx.NewNoDataScope(ctx), schema.UserQueryParam{
+		UserName: params.UserName,
+	})
+	if err != nil {
+		return err
+	} else if len(result.Data) == 0 {
+		return nil
+	}
+
+	user := result.Data[0]
+	if user.Status != 1 || user.Email == "" {
+		return nil
+	}
+
+	token, err := a.generateToken()
+	if err != nil {
+		return err
+	}
+
+	expired := config.C.PasswordReset.Expired
+	if expired <= 0 {
+		expired = 1800
+	}
+
+	item := schema.PasswordReset{
+		ID:        uuid.MustString(),
+		UserID:    user.ID,
+		TokenHash: hash.SHA256String(token),
+		ExpiresAt: time.Now().Add(time.Duration(expired) * time.Second),
+	}
+	if err := a.PasswordResetModel.Create(ctx, item); err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("您正在重置密码，重置令牌为：%s", token)
+	if url := config.C.PasswordReset.URL; url != "" {
+		content = fmt.Sprintf("您正在重置密码，请访问以下地址设置新密码：%s%s", url, token)
+	}
+	content += fmt.Sprintf("\n该令牌%d分钟内有效且只能使用一次，如非本人操作请忽略。", expired/60)
+
+	err = a.Notifier.Notify(ctx, &notify.Message{
+		To:      user.Email,
+		Subject: "密码重置",
+		Content: content,
+	})
+	if err != nil {
+		//	通知失败时同样返回成功，避免通过响应区分用户是否存在
+		logger.WithContext(logger.NewUserIDContext(ctx, user.ID)).Errorf("发送密码重置通知失败: %s", err.Error())
+		return nil
+	}
+
+	logger.WithContext(logger.NewUserIDContext(ctx, user.ID)).Infof("申请密码重置")
+	return nil
+}
+
+// 检查申请频率(用户名或IP在统计窗口内的申请次数达到上限后拒绝申请)
+func (a *PasswordReset) checkLimit(ctx context.Context, tenantID, userName, ip string) error {
+	if a.Limiter == nil {
+		return nil
+	}
+
+	items := [][2]string{{LockoutUser, lockoutUserValue(tenantID, userName)}, {LockoutIP, ip}}
+	for _, item := range items {
+		status, err := a.Limiter.Status(ctx, item[0], item[1])
+		if err != nil {
+			return errors.WithStack(err)
+		} else if status.IsLocked() {
+			return errors.ErrTooManyRequests
+		}
+	}
+
+	for _, item := range items {
+		if _, err := a.Limiter.Fail(ctx, item[0], item[1]); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Confirm 确认密码重置(令牌使用后即失效，并撤销用户的所有会话)
+func (a *PasswordReset) Confirm(ctx context.Context, params schema.PasswordResetConfirmParam) error {
+	var userID string
+	err := a.TransModel.Exec(contextx.NewTransLock(ctx), func(ctx context.Context) error {
+		item, err := a.PasswordResetModel.GetByTokenHash(ctx, hash.SHA256String(params.Token))
+		if err != nil {
+			return err
+		} else if item == nil || time.Now().After(item.ExpiresAt) {
+			return errors.ErrInvalidResetToken
+		}
+
+		//	重置令牌只关联用户ID，不限定租户查询后再限定为用户所属租户
+		user, err := a.UserModel.Get(contextx.NewSystem(ctx), item.UserID)
+		if err != nil {
+			return err
+		} else if user == nil || user.Status != 1 {
+			return errors.ErrInvalidResetToken
+		}
+		ctx = contextx.NewTenantID(ctx, user.TenantID)
+
+		if err := a.PasswordPolicySrv.Validate(ctx, user, params.NewPassword); err != nil {
+			return err
+		}
+
+		if err := a.PasswordPolicySrv.Change(ctx, user.ID, params.NewPassword); err != nil {
+			return err
+		}
+
+		//	同时作废该用户其它未使用的重置令牌
+		userID = user.ID
+		return a.PasswordResetModel.DeleteByUserID(ctx, user.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := a.Auth.RevokeSessions(ctx, userID); err != nil {
+		return errors.WithStack(err)
+	}
+
+	logger.WithContext(logger.NewUserIDContext(ctx, userID)).Infof("密码重置成功，已撤销所有会话")
+	return nil
+}
+
+func (a *PasswordReset) generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/notify"
+	"testing"
+)
+
+type testNotifier struct {
+	messages []*notify.Message
+	err      error
+}
+
+func (n *testNotifier) Notify(ctx context.Context, msg *notify.Message) error {
+	n.messages = append(n.messages, msg)
+	return n.err
+}
+
+func TestPasswordResetRequest(t *testing.T) {
+	env := newTestEnv(t)
+	env.create(t, schema.DefaultTenantID,
+		&entity.Tenant{ID: "t1", Code: "c1", Name: "c1", Status: 1},
+		&entity.Tenant{ID: "t2", Code: "c2", Name: "c2", Status: 2},
+	)
+	email := "u1@example.com"
+	env.create(t, "t1", &entity.User{ID: "u1", UserName: "u1", RealName: "u1", Email: &email, Status: 1})
+
+	notifier := new(testNotifier)
+	a := &PasswordReset{
+		UserModel:          env.UserModel,
+		PasswordResetModel: &repo.PasswordReset{DB: env.DB},
+		LoginSrv:           &Login{TenantModel: env.TenantModel},
+		Notifier:           notifier,
+	}
+	ctx := context.Background()
+
+	// 用户存在且通知成功
+	if err := a.Request(ctx, schema.PasswordResetRequestParam{TenantCode: "c1", UserName: "u1"}, ""); err != nil {
+		t.Fatal(err)
+	} else if len(notifier.messages) != 1 || notifier.messages[0].To != email {
+		t.Fatalf("unexpected messages: %+v", notifier.messages)
+	}
+
+	// 租户或用户无效、通知失败时的响应与成功时一致
+	notifier.messages = nil
+	for _, params := range []schema.PasswordResetRequestParam{
+		{TenantCode: "unknown", UserName: "u1"},
+		{TenantCode: "c2", UserName: "u1"},
+		{TenantCode: "c1", UserName: "unknown"},
+	} {
+		if err := a.Request(ctx, params, ""); err != nil {
+			t.Fatalf("%+v: %v", params, err)
+		}
+	}
+	if len(notifier.messages) != 0 {
+		t.Fatalf("unexpected messages: %+v", notifier.messages)
+	}
+
+	notifier.err = errors.New("smtp unavailable")
+	if err := a.Request(ctx, schema.PasswordResetRequestParam{TenantCode: "c1", UserName: "u1"}, ""); err != nil {
+		t.Fatalf("expected notify failure to be hidden, got %v", err)
+	} else if len(notifier.messages) != 1 {
+		t.Fatalf("unexpected messages: %+v", notifier.messages)
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/util"
+	"github.com/google/wire"
+	"sort"
+	"strings"
+)
+
+// PermissionSet 注入Permission
+var PermissionSet = wire.NewSet(wire.Struct(new(Permission), "*"))
+
+// Permission 权限诊断(基于内存中已加载的casbin策略)
+type Permission struct {
+	Enforcer                *casbin.SyncedEnforcer
+	UserModel               *repo.User
+	RoleModel               *repo.Role
+	RoleMenuModel           *repo.RoleMenu
+	MenuModel               *repo.Menu
+	MenuActionModel         *repo.MenuAction
+	MenuActionResourceModel *repo.MenuActionResource
+}
+
+// Explain 诊断用户访问指定接口的判定结果及原因
+func (a *Permission) Explain(ctx context.Context, params schema.PermissionExplainParam) (*schema.PermissionExplain, error) {
+	user, err := a.UserModel.Get(contextx.NewNoDataScope(ctx), params.UserID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrNotFound
+	}
+
+	item := &schema.PermissionExplain{
+		UserID:   user.ID,
+		TenantID: user.TenantID,
+		Path:     params.Path,
+		Method:   strings.ToUpper(params.Method),
+	}
+
+	item.Allowed, err = a.Enforcer.Enforce(user.ID, user.TenantID, item.Path, item.Method)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	roleIDs, err := a.Enforcer.GetImplicitRolesForUser(user.ID, user.TenantID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	roles, err := a.queryRoles(ctx, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, roleID := range roleIDs {
+		if role, ok := roles[roleID]; ok {
+			item.Roles = append(item.Roles, &schema.PermissionRole{ID: role.ID, Name: role.Name, Status: role.Status})
+		}
+	}
+
+	var matched [][]string
+	for _, subject := range append([]string{user.ID}, roleIDs...) {
+		for _, rule := range a.Enforcer.GetPermissionsForUserInDomain(subject, user.TenantID) {
+			if len(rule) < 4 || !util.KeyMatch2(item.Path, rule[2]) || !util.RegexMatch(item.Method, rule[3]) {
+				continue
+			}
+			matched = append(matched, rule)
+			item.Policies = append(item.Policies, "p, "+strings.Join(rule, ", "))
+		}
+	}
+
+	item.Grants, err = a.queryGrants(ctx, matched, roles)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !config.C.Casbin.Enable:
+		item.Reason = "未启用权限校验，允许所有请求"
+	case user.Status != 1:
+		item.Reason = "用户已禁用，不产生任何权限策略"
+	case item.Allowed && user.IsSuper:
+		item.Reason = "超级管理员拥有所属租户内所有资源的访问权限"
+	case item.Allowed:
+		item.Reason = "匹配到角色授予的策略规则"
+	case len(roleIDs) == 0:
+		item.Reason = "用户没有任何已启用的角色"
+	default:
+		item.Reason = "用户的角色均未授予该接口"
+	}
+	return item, nil
+}
+
+// QueryAPIs 查询用户或角色可调用的接口列表(用户包括其角色授予的接口)
+func (a *Permission) QueryAPIs(ctx context.Context, params schema.PermissionAPIQueryParam) ([]*schema.PermissionAPI, error) {
+	var rules [][]string
+	switch {
+	case params.UserID != "":
+		user, err := a.UserModel.Get(contextx.NewNoDataScope(ctx), params.UserID)
+		if err != nil {
+			return nil, err
+		} else if user == nil {
+			return nil, errors.ErrNotFound
+		}
+
+		roleIDs, err := a.Enforcer.GetImplicitRolesForUser(user.ID, user.TenantID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		for _, subject := range append([]string{user.ID}, roleIDs...) {
+			rules = append(rules, a.Enforcer.GetPermissionsForUserInDomain(subject, user.TenantID)...)
+		}
+	case params.RoleID != "":
+		role, err := a.RoleModel.Get(ctx, params.RoleID)
+		if err != nil {
+			return nil, err
+		} else if role == nil {
+			return nil, errors.ErrNotFound
+		}
+
+		roleIDs, err := a.Enforcer.GetImplicitRolesForUser(role.ID, role.TenantID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		for _, subject := range append([]string{role.ID}, roleIDs...) {
+			rules = append(rules, a.Enforcer.GetPermissionsForUserInDomain(subject, role.TenantID)...)
+		}
+	default:
+		return nil, errors.New400Response("请指定用户或角色")
+	}
+
+	var list []*schema.PermissionAPI
+	mAPIs := make(map[string]*schema.PermissionAPI)
+	for _, rule := range rules {
+		if len(rule) < 4 {
+			continue
+		}
+
+		key := rule[3] + " " + rule[2]
+		api, ok := mAPIs[key]
+		if !ok {
+			api = &schema.PermissionAPI{Method: rule[3], Path: rule[2]}
+			mAPIs[key] = api
+			list = append(list, api)
+		}
+		api.Subjects = append(api.Subjects, rule[0])
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Path == list[j].Path {
+			return list[i].Method < list[j].Method
+		}
+		return list[i].Path < list[j].Path
+	})
+	return list, nil
+}
+
+func (a *Permission) queryRoles(ctx context.Context, roleIDs []string) (map[string]*schema.Role, error) {
+	if len(roleIDs) == 0 {
+		return nil, nil
+	}
+
+	result, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{IDs: roleIDs})
+	if err != nil {
+		return nil, err
+	}
+	return result.Data.ToMap(), nil
+}
+
+// 根据匹配的角色策略查找授予权限的菜单动作资源
+func (a *Permission) queryGrants(ctx context.Context, rules [][]string, roles map[string]*schema.Role) ([]*schema.PermissionGrant, error) {
+	var roleIDs []string
+	mRules := make(map[string][][]string)
+	for _, rule := range rules {
+		if _, ok := roles[rule[0]]; !ok {
+			continue
+		} else if _, ok := mRules[rule[0]]; !ok {
+			roleIDs = append(roleIDs, rule[0])
+		}
+		mRules[rule[0]] = append(mRules[rule[0]], rule)
+	}
+	if len(roleIDs) == 0 {
+		return nil, nil
+	}
+
+	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{RoleIDs: roleIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	actionIDs := roleMenuResult.Data.ToActionIDs()
+	if len(actionIDs) == 0 {
+		return nil, nil
+	}
+
+	resourceResult, err := a.MenuActionResourceModel.Query(ctx, schema.MenuActionResourceQueryParam{ActionIDs: actionIDs})
+	if err != nil {
+		return nil, err
+	}
+	mResources := resourceResult.Data.ToActionIDMap()
+
+	actionResult, err := a.MenuActionModel.Query(ctx, schema.MenuActionQueryParam{IDs: actionIDs})
+	if err != nil {
+		return nil, err
+	}
+	mActions := make(map[string]*schema.MenuAction, len(actionResult.Data))
+	for _, action := range actionResult.Data {
+		mActions[action.ID] = action
+	}
+
+	menuResult, err := a.MenuModel.Query(ctx, schema.MenuQueryParam{IDs: roleMenuResult.Data.ToMenuIDs()})
+	if err != nil {
+		return nil, err
+	}
+	mMenus := menuResult.Data.ToMap()
+
+	var list []*schema.PermissionGrant
+	for _, rm := range roleMenuResult.Data {
+		action, ok := mActions[rm.ActionID]
+		if !ok {
+			continue
+		}
+		menu, ok := mMenus[rm.MenuID]
+		if !ok {
+			continue
+		}
+
+		for _, res := range mResources[rm.ActionID] {
+			for _, rule := range mRules[rm.RoleID] {
+				if res.Path != rule[2] || res.Method != rule[3] {
+					continue
+				}
+				list = append(list, &schema.PermissionGrant{
+					RoleID:     rm.RoleID,
+					RoleName:   roles[rm.RoleID].Name,
+					MenuID:     menu.ID,
+					MenuName:   menu.Name,
+					ActionID:   action.ID,
+					ActionCode: action.Code,
+					ActionName: action.Name,
+					Method:     res.Method,
+					Path:       res.Path,
+				})
+				break
+			}
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/pkg/logger"
+	"github.com/google/wire"
+	"time"
+)
+
+// RecycleBinSet 注入RecycleBin
+var RecycleBinSet = wire.NewSet(wire.Struct(new(RecycleBin), "*"))
+
+// RecycleBin 回收站(彻底删除超过保留期限的数据)
+type RecycleBin struct {
+	DemoSrv   *Demo
+	DeptSrv   *Dept
+	MenuSrv   *Menu
+	RoleSrv   *Role
+	UserSrv   *User
+	TenantSrv *Tenant
+}
+
+// Purge 彻底删除删除时间早于指定时间的所有数据(不限定租户)
+func (a *RecycleBin) Purge(ctx context.Context, before time.Time) error {
+	items := []struct {
+		name  string
+		purge func(context.Context, time.Time) (int, error)
+	}{
+		{"demo", a.DemoSrv.Purge},
+		{"dept", a.DeptSrv.Purge},
+		{"menu", a.MenuSrv.Purge},
+		{"role", a.RoleSrv.Purge},
+		{"user", a.UserSrv.Purge},
+		{"tenant", a.TenantSrv.Purge},
+	}
+
+	for _, item := range items {
+		n, err := item.purge(ctx, before)
+		if err != nil {
+			return err
+		} else if n > 0 {
+			logger.WithContext(ctx).Infof("彻底删除回收站中的数据: %s(%d)", item.name, n)
+		}
+	}
+	return nil
+}
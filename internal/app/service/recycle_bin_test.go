@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/pkg/util/uuid"
+	"testing"
+	"time"
+)
+
+func TestRecycleBinPurge(t *testing.T) {
+	env := newTestEnv(t)
+	tenantSrv := newTestTenantSrv(t, env)
+	a := &RecycleBin{
+		DemoSrv:   tenantSrv.DemoSrv,
+		DeptSrv:   tenantSrv.DeptSrv,
+		MenuSrv:   tenantSrv.MenuSrv,
+		RoleSrv:   tenantSrv.RoleSrv,
+		UserSrv:   tenantSrv.UserSrv,
+		TenantSrv: tenantSrv,
+	}
+	ctx := context.Background()
+
+	// 待删除的租户及其数据
+	result, err := tenantSrv.Create(ctx, newTestTenantParam("t1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantID := result.ID
+	menuID, actionID := env.createMenu(t, tenantID, "/api/v1/users", "GET")
+	env.createRole(t, tenantID, "r1", menuID, actionID)
+	env.createRole(t, tenantID, "r2")
+	env.create(t, tenantID,
+		&entity.RoleParent{ID: uuid.MustString(), RoleID: "r2", ParentID: "r1"},
+		&entity.Dept{ID: uuid.MustString(), Name: "dept", Status: 1},
+		&entity.Demo{ID: uuid.MustString(), Code: "demo", Name: "demo", Status: 1},
+	)
+	env.createUser(t, tenantID, "u1", false, "r2")
+
+	// 保留的租户：已删除的角色被彻底删除，其余数据保留
+	keepMenu, keepAction := env.createMenu(t, "t2", "/api/v1/roles", "GET")
+	env.createRole(t, "t2", "r3", keepMenu, keepAction)
+	env.createRole(t, "t2", "r4", keepMenu, keepAction)
+	env.createUser(t, "t2", "u2", false, "r4")
+
+	tctx := contextx.NewTenantID(ctx, "t2")
+	if err := tenantSrv.RoleSrv.Delete(tctx, "r3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantSrv.UpdateStatus(ctx, tenantID, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantSrv.Delete(ctx, tenantID); err != nil {
+		t.Fatal(err)
+	}
+
+	// 未超过保留期限的数据不删除
+	if err := a.Purge(contextx.NewSystem(ctx), time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if n := env.count(t, new(entity.Tenant), "id=?", tenantID); n != 1 {
+		t.Fatalf("unexpected tenants: %d", n)
+	}
+	if n := env.count(t, new(entity.Role), "id=?", "r3"); n != 1 {
+		t.Fatalf("unexpected roles: %d", n)
+	}
+
+	if err := a.Purge(contextx.NewSystem(ctx), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range []struct {
+		model interface{}
+		query string
+		arg   interface{}
+		want  int64
+	}{
+		{new(entity.Tenant), "id=?", tenantID, 0},
+		{new(entity.User), "tenant_id=?", tenantID, 0},
+		{new(entity.UserRole), "user_id=?", "u1", 0},
+		{new(entity.PasswordHistory), "user_id NOT IN (?)", env.DB.Model(new(entity.User)).Select("id"), 0},
+		{new(entity.Role), "tenant_id=?", tenantID, 0},
+		{new(entity.RoleParent), "role_id=?", "r2", 0},
+		{new(entity.RoleMenu), "role_id IN (?)", []string{"r1", "r3"}, 0},
+		{new(entity.Menu), "tenant_id=?", tenantID, 0},
+		{new(entity.MenuAction), "menu_id=?", menuID, 0},
+		{new(entity.MenuActionResource), "action_id=?", actionID, 0},
+		{new(entity.Dept), "tenant_id=?", tenantID, 0},
+		{new(entity.Demo), "tenant_id=?", tenantID, 0},
+		{new(entity.Role), "id=?", "r3", 0},
+		{new(entity.Role), "id=?", "r4", 1},
+		{new(entity.RoleMenu), "role_id=?", "r4", 1},
+		{new(entity.UserRole), "user_id=?", "u2", 1},
+		{new(entity.Menu), "id=?", keepMenu, 1},
+	} {
+		if n := env.count(t, item.model, item.query, item.arg); n != item.want {
+			t.Fatalf("%T where %s: got %d, want %d", item.model, item.query, n, item.want)
+		}
+	}
+
+	// 全量重新加载后删除租户用户及角色的权限策略随之移除
+	waitCasbinSynced(t, env, tenantSrv.CasbinSrv.Enforcer)
+	if ok, err := tenantSrv.CasbinSrv.Enforcer.Enforce("u2", "t2", "/api/v1/roles", "GET"); err != nil || !ok {
+		t.Fatalf("unexpected enforce result: %v, %v", ok, err)
+	}
+}
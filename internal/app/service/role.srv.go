@@ -2,12 +2,13 @@ package service
 
 import (
 	"context"
+	"ginAdmin/internal/app/contextx"
 	"ginAdmin/internal/app/model/gormx/repo"
 	"ginAdmin/internal/app/schema"
 	"ginAdmin/pkg/errors"
 	"ginAdmin/pkg/util/uuid"
-	"github.com/casbin/casbin/v2"
 	"github.com/google/wire"
+	"time"
 )
 
 // RoleSet 注入Role
@@ -15,11 +16,15 @@ var RoleSet = wire.NewSet(wire.Struct(new(Role), "*"))
 
 // Role 角色管理
 type Role struct {
-	Enforcer      *casbin.SyncedEnforcer
-	TransModel    *repo.Trans
-	RoleModel     *repo.Role
-	RoleMenuModel *repo.RoleMenu
-	UserModel     *repo.User
+	CasbinSrv       *Casbin
+	TransModel      *repo.Trans
+	RoleModel       *repo.Role
+	RoleMenuModel   *repo.RoleMenu
+	RoleDeptModel   *repo.RoleDept
+	RoleParentModel *repo.RoleParent
+	DeptModel       *repo.Dept
+	UserModel       *repo.User
+	UserRoleModel   *repo.UserRole
 }
 
 // Query 查询数据
@@ -29,7 +34,7 @@ func (a *Role) Query(ctx context.Context, params schema.RoleQueryParam, opts ...
 
 // Get 查询指定数据
 func (a *Role) Get(ctx context.Context, id string, opts ...schema.RoleQueryOptions) (*schema.Role, error) {
-	item, err := a.RoleModel.Get(ctx, id, opts...)
+	item, err := a.RoleModel.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	} else if item == nil {
@@ -42,6 +47,20 @@ func (a *Role) Get(ctx context.Context, id string, opts ...schema.RoleQueryOptio
 	}
 	item.RoleMenus = roleMenus
 
+	if item.DataScope == schema.DataScopeCustom {
+		deptIDs, err := a.QueryRoleDeptIDs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		item.DeptIDs = deptIDs
+	}
+
+	parentIDs, err := a.QueryRoleParentIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	item.ParentIDs = parentIDs
+
 	return item, nil
 }
 
@@ -56,6 +75,153 @@ func (a *Role) QueryRoleMenus(ctx context.Context, roleID string) (schema.RoleMe
 	return result.Data, nil
 }
 
+// QueryRoleDeptIDs 查询角色自定义数据权限的部门ID列表
+func (a *Role) QueryRoleDeptIDs(ctx context.Context, roleID string) ([]string, error) {
+	result, err := a.RoleDeptModel.Query(ctx, schema.RoleDeptQueryParam{
+		RoleID: roleID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Data.ToDeptIDs(), nil
+}
+
+// QueryRoleParentIDs 查询角色继承的父级角色ID列表
+func (a *Role) QueryRoleParentIDs(ctx context.Context, roleID string) ([]string, error) {
+	result, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{
+		RoleID: roleID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Data.ToParentIDs(), nil
+}
+
+// 检查父级角色(父级角色须属于当前租户，且继承关系不能形成循环)
+func (a *Role) checkParents(ctx context.Context, item *schema.Role) error {
+	var parentIDs []string
+	mParentIDs := make(map[string]struct{})
+	for _, parentID := range item.ParentIDs {
+		if parentID == item.ID {
+			return errors.New400Response("角色不能继承自身")
+		} else if _, ok := mParentIDs[parentID]; ok {
+			continue
+		}
+		parentIDs = append(parentIDs, parentID)
+		mParentIDs[parentID] = struct{}{}
+	}
+	item.ParentIDs = parentIDs
+	if len(parentIDs) == 0 {
+		return nil
+	}
+
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		IDs:             parentIDs,
+	})
+	if err != nil {
+		return err
+	} else if int(roleResult.PageResult.Total) != len(parentIDs) {
+		return errors.New400Response("无效的父级角色")
+	}
+
+	result, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{})
+	if err != nil {
+		return err
+	}
+
+	//	排除角色当前的继承关系后，父级角色的祖先中不能包含该角色
+	var roleParents schema.RoleParents
+	for _, rp := range result.Data {
+		if rp.RoleID != item.ID {
+			roleParents = append(roleParents, rp)
+		}
+	}
+	for _, ancestorID := range roleParents.Ancestors(parentIDs...) {
+		if ancestorID == item.ID {
+			return errors.ErrRoleCycle
+		}
+	}
+	return nil
+}
+
+// 重建角色继承的父级角色
+func (a *Role) updateRoleParents(ctx context.Context, roleID string, parentIDs []string) error {
+	err := a.RoleParentModel.DeleteByRoleID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	for _, parentID := range parentIDs {
+		err := a.RoleParentModel.Create(ctx, schema.RoleParent{
+			ID:       uuid.MustString(),
+			RoleID:   roleID,
+			ParentID: parentID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 检查数据权限范围(未指定时为全部数据，只有自定义部门时保留部门列表)
+func (a *Role) checkDataScope(ctx context.Context, item *schema.Role) error {
+	if item.DataScope == 0 {
+		item.DataScope = schema.DataScopeAll
+	}
+
+	if item.DataScope != schema.DataScopeCustom {
+		item.DeptIDs = nil
+		return nil
+	}
+
+	var deptIDs []string
+	mDeptIDs := make(map[string]struct{})
+	for _, deptID := range item.DeptIDs {
+		if _, ok := mDeptIDs[deptID]; ok {
+			continue
+		}
+		deptIDs = append(deptIDs, deptID)
+		mDeptIDs[deptID] = struct{}{}
+	}
+	if len(deptIDs) == 0 {
+		return errors.New400Response("自定义数据权限需要指定部门")
+	}
+	item.DeptIDs = deptIDs
+
+	result, err := a.DeptModel.Query(ctx, schema.DeptQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		IDs:             item.DeptIDs,
+	})
+	if err != nil {
+		return err
+	} else if int(result.PageResult.Total) != len(deptIDs) {
+		return errors.New400Response("无效的部门")
+	}
+	return nil
+}
+
+// 重建角色自定义数据权限的部门
+func (a *Role) updateRoleDepts(ctx context.Context, roleID string, deptIDs []string) error {
+	err := a.RoleDeptModel.DeleteByRoleID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	for _, deptID := range deptIDs {
+		err := a.RoleDeptModel.Create(ctx, schema.RoleDept{
+			ID:     uuid.MustString(),
+			RoleID: roleID,
+			DeptID: deptID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Create 创建数据
 func (a *Role) Create(ctx context.Context, item schema.Role) (*schema.IDResult, error) {
 	err := a.checkName(ctx, item)
@@ -63,22 +229,43 @@ func (a *Role) Create(ctx context.Context, item schema.Role) (*schema.IDResult,
 		return nil, err
 	}
 
+	err = a.checkDataScope(ctx, &item)
+	if err != nil {
+		return nil, err
+	}
+
 	item.ID = uuid.MustString()
-	err = a.TransModel.Exec(ctx, func(ctx context.Context) error {
-		for _, rmItem := range item.RoleMenus {
-			rmItem.ID = uuid.MustString()
-			rmItem.RoleID = item.ID
-			err := a.RoleMenuModel.Create(ctx, *rmItem)
+	err = a.checkParents(ctx, &item)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.CasbinSrv.UpdateRole(ctx, item.ID, func() error {
+		return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			for _, rmItem := range item.RoleMenus {
+				rmItem.ID = uuid.MustString()
+				rmItem.RoleID = item.ID
+				err := a.RoleMenuModel.Create(ctx, *rmItem)
+				if err != nil {
+					return err
+				}
+			}
+
+			err := a.updateRoleDepts(ctx, item.ID, item.DeptIDs)
 			if err != nil {
 				return err
 			}
-		}
-		return a.RoleModel.Create(ctx, item)
+
+			err = a.updateRoleParents(ctx, item.ID, item.ParentIDs)
+			if err != nil {
+				return err
+			}
+			return a.RoleModel.Create(ctx, item)
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
 	return schema.NewIDResult(item.ID), nil
 }
 
@@ -102,6 +289,8 @@ func (a *Role) Update(ctx context.Context, id string, item schema.Role) error {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
+	} else if oldItem.Version != item.Version {
+		return errors.ErrPreconditionFailed
 	} else if oldItem.Name != item.Name {
 		err := a.checkName(ctx, item)
 		if err != nil {
@@ -109,34 +298,52 @@ func (a *Role) Update(ctx context.Context, id string, item schema.Role) error {
 		}
 	}
 
+	err = a.checkDataScope(ctx, &item)
+	if err != nil {
+		return err
+	}
+
 	item.ID = oldItem.ID
+	err = a.checkParents(ctx, &item)
+	if err != nil {
+		return err
+	}
+
 	item.Creator = oldItem.Creator
 	item.CreatedAt = oldItem.CreatedAt
-	err = a.TransModel.Exec(ctx, func(ctx context.Context) error {
-		addRoleMenus, delRoleMenus := a.compareRoleMenus(ctx, oldItem.RoleMenus, item.RoleMenus)
-		for _, rmitem := range addRoleMenus {
-			rmitem.ID = uuid.MustString()
-			rmitem.RoleID = id
-			err := a.RoleMenuModel.Create(ctx, *rmitem)
+	// 先按版本号更新角色，版本冲突时不再应用菜单、部门及继承关系的差异
+	return a.CasbinSrv.UpdateRole(ctx, id, func() error {
+		return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			err := a.RoleModel.Update(ctx, id, item)
 			if err != nil {
 				return err
 			}
-		}
 
-		for _, rmitem := range delRoleMenus {
-			err := a.RoleMenuModel.Delete(ctx, rmitem.ID)
+			addRoleMenus, delRoleMenus := a.compareRoleMenus(ctx, oldItem.RoleMenus, item.RoleMenus)
+			for _, rmitem := range addRoleMenus {
+				rmitem.ID = uuid.MustString()
+				rmitem.RoleID = id
+				err := a.RoleMenuModel.Create(ctx, *rmitem)
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, rmitem := range delRoleMenus {
+				err := a.RoleMenuModel.Delete(ctx, rmitem.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = a.updateRoleDepts(ctx, id, item.DeptIDs)
 			if err != nil {
 				return err
 			}
-		}
 
-		return a.RoleModel.Update(ctx, id, item)
+			return a.updateRoleParents(ctx, id, item.ParentIDs)
+		})
 	})
-	if err != nil {
-		return err
-	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
-	return nil
 }
 
 func (a *Role) compareRoleMenus(ctx context.Context, oldRoleMenus, newRoleMenus schema.RoleMenus) (addList, delList schema.RoleMenus) {
@@ -166,7 +373,7 @@ func (a *Role) Delete(ctx context.Context, id string) error {
 		return errors.ErrNotFound
 	}
 
-	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+	userResult, err := a.UserModel.Query(contextx.NewNoDataScope(ctx), schema.UserQueryParam{
 		PaginationParam: schema.PaginationParam{OnlyCount: true},
 		RoleIDs:         []string{id},
 	})
@@ -176,35 +383,146 @@ func (a *Role) Delete(ctx context.Context, id string) error {
 		return errors.New400Response("该角色已被赋予用户，不允许删除")
 	}
 
-	err = a.TransModel.Exec(ctx, func(ctx context.Context) error {
-		err := a.RoleMenuModel.DeleteByRoleID(ctx, id)
-		if err != nil {
-			return err
-		}
-
-		return a.RoleModel.Delete(ctx, id)
+	childResult, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		ParentID:        id,
 	})
 	if err != nil {
 		return err
+	} else if childResult.PageResult.Total > 0 {
+		return errors.New400Response("该角色已被其他角色继承，不允许删除")
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
-	return nil
+	// 角色的菜单、数据权限及继承关系保留到彻底删除，以便从回收站恢复；
+	// 回收站中的用户仍持有的角色授权随之移除，用户恢复后不再拥有该角色
+	return a.CasbinSrv.UpdateRole(ctx, id, func() error {
+		return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			err := a.UserRoleModel.DeleteByRoleID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			return a.RoleModel.Delete(ctx, id)
+		})
+	})
 }
 
-// UpdateStatus 更新状态
-func (a *Role) UpdateStatus(ctx context.Context, id string, status int) error {
+// UpdateStatus 更新状态(version为客户端读取时的版本号)
+func (a *Role) UpdateStatus(ctx context.Context, id string, status int, version int64) error {
 	oldItem, err := a.RoleModel.Get(ctx, id)
 	if err != nil {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
+	} else if oldItem.Version != version {
+		return errors.ErrPreconditionFailed
 	}
 
-	err = a.RoleModel.UpdateStatus(ctx, id, status)
+	return a.CasbinSrv.UpdateRole(ctx, id, func() error {
+		return a.RoleModel.UpdateStatus(ctx, id, status, version)
+	})
+}
+
+// QueryDeleted 查询回收站中的数据
+func (a *Role) QueryDeleted(ctx context.Context, params schema.RecycleQueryParam) (*schema.RecycleQueryResult, error) {
+	return a.RoleModel.QueryDeleted(ctx, params)
+}
+
+// Restore 从回收站恢复数据(删除期间已被删除的父级角色不再继承)
+func (a *Role) Restore(ctx context.Context, id string) error {
+	oldItem, err := a.RoleModel.GetDeleted(ctx, id)
 	if err != nil {
 		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
 	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
-	return nil
+
+	if err := a.checkName(ctx, *oldItem); err != nil {
+		return err
+	}
+
+	parentResult, err := a.RoleParentModel.Query(ctx, schema.RoleParentQueryParam{
+		RoleID: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	var mParents map[string]*schema.Role
+	if parentIDs := parentResult.Data.ToParentIDs(); len(parentIDs) > 0 {
+		roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+			IDs: parentIDs,
+		})
+		if err != nil {
+			return err
+		}
+		mParents = roleResult.Data.ToMap()
+	}
+
+	return a.CasbinSrv.UpdateRole(ctx, id, func() error {
+		return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			for _, rp := range parentResult.Data {
+				if _, ok := mParents[rp.ParentID]; ok {
+					continue
+				}
+
+				err := a.RoleParentModel.Delete(ctx, rp.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			return a.RoleModel.Restore(ctx, id)
+		})
+	})
+}
+
+// Purge 彻底删除删除时间早于指定时间的数据(同时删除角色的菜单、数据权限、继承关系及用户授权)
+func (a *Role) Purge(ctx context.Context, before time.Time) (int, error) {
+	ids, err := a.RoleModel.QueryPurgeIDs(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.purge(ctx, ids)
+}
+
+// PurgeTenant 彻底删除上下文中租户的全部数据
+func (a *Role) PurgeTenant(ctx context.Context) (int, error) {
+	ids, err := a.RoleModel.DeleteTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.purge(ctx, ids)
+}
+
+func (a *Role) purge(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		for _, id := range ids {
+			err := a.RoleMenuModel.DeleteByRoleID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.RoleDeptModel.DeleteByRoleID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.RoleParentModel.DeleteByRoleID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.UserRoleModel.DeleteByRoleID(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return a.RoleModel.Purge(ctx, ids)
+	})
 }
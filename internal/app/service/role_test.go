@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/pkg/auth/jwtauth"
+	"ginAdmin/pkg/auth/jwtauth/store/memory"
+	"testing"
+)
+
+func TestRoleDeleteWithDeletedUser(t *testing.T) {
+	env := newTestEnv(t)
+	menuID, actionID := env.createMenu(t, "t1", "/api/v1/users", "GET")
+	env.createRole(t, "t1", "r1", menuID, actionID)
+	env.createUser(t, "t1", "u1", false, "r1")
+	env.createUser(t, "t1", "u2", false, "r1")
+
+	casbinSrv := env.newCasbin(t, nil)
+	a := env.newRoleSrv(casbinSrv)
+	userSrv := env.newUserSrv(jwtauth.New(memory.NewStore(0)), casbinSrv)
+	ctx := contextx.NewTenantID(context.Background(), "t1")
+
+	if err := userSrv.Delete(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	// 仍有未删除的用户持有该角色
+	if err := a.Delete(ctx, "r1"); err == nil {
+		t.Fatal("expected role in use error")
+	}
+
+	if err := userSrv.Delete(ctx, "u2"); err != nil {
+		t.Fatal(err)
+	}
+	// 回收站中的用户持有的角色授权随角色删除一并移除
+	if err := a.Delete(ctx, "r1"); err != nil {
+		t.Fatal(err)
+	}
+	if n := env.count(t, new(entity.UserRole), "role_id=?", "r1"); n != 0 {
+		t.Fatalf("unexpected user roles: %d", n)
+	}
+
+	// 恢复角色及用户后，用户不再拥有该角色
+	if err := a.Restore(ctx, "r1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := userSrv.Restore(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	assertCasbinSynced(t, env, casbinSrv.Enforcer)
+	assertEnforce(t, casbinSrv.Enforcer, "u1", "/api/v1/users", false)
+	assertEnforce(t, casbinSrv.Enforcer, "r1", "/api/v1/users", true)
+}
+
+func TestRoleRestore(t *testing.T) {
+	env := newTestEnv(t)
+	menuID, actionID := env.createMenu(t, "t1", "/api/v1/users", "GET")
+	env.createRole(t, "t1", "r1", menuID, actionID)
+	env.createRole(t, "t1", "r2")
+	env.create(t, "t1", &entity.RoleParent{ID: "rp1", RoleID: "r2", ParentID: "r1"})
+
+	casbinSrv := env.newCasbin(t, nil)
+	a := env.newRoleSrv(casbinSrv)
+	ctx := contextx.NewTenantID(context.Background(), "t1")
+	assertEnforce(t, casbinSrv.Enforcer, "r2", "/api/v1/users", true)
+
+	if err := a.Delete(ctx, "r2"); err != nil {
+		t.Fatal(err)
+	}
+	assertCasbinSynced(t, env, casbinSrv.Enforcer)
+	assertEnforce(t, casbinSrv.Enforcer, "r2", "/api/v1/users", false)
+
+	// 回收站中的角色保留继承关系，父级角色不能删除
+	if err := a.Delete(ctx, "r1"); err == nil {
+		t.Fatal("expected inherited role error")
+	}
+
+	// 恢复后角色的继承关系及权限随之恢复
+	if err := a.Restore(ctx, "r2"); err != nil {
+		t.Fatal(err)
+	}
+	assertCasbinSynced(t, env, casbinSrv.Enforcer)
+	assertEnforce(t, casbinSrv.Enforcer, "r2", "/api/v1/users", true)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/errors"
+	"github.com/google/wire"
+)
+
+// SessionSet 注入Session
+var SessionSet = wire.NewSet(wire.Struct(new(Session), "*"))
+
+// Session 会话管理
+type Session struct {
+	Auth      auth.Auther
+	UserModel *repo.User
+}
+
+// Query 查询用户的有效会话
+func (a *Session) Query(ctx context.Context, userID string) (schema.Sessions, error) {
+	sessions, err := a.Auth.QuerySessions(ctx, userID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	list := make(schema.Sessions, len(sessions))
+	for i, item := range sessions {
+		list[i] = &schema.Session{
+			ID:        item.ID,
+			IP:        item.IP,
+			UserAgent: item.UserAgent,
+			IssuedAt:  item.IssuedAt,
+			ActorID:   item.ActorID,
+			TargetID:  item.TargetID,
+		}
+	}
+	return list, nil
+}
+
+// Revoke 撤销用户的指定会话
+func (a *Session) Revoke(ctx context.Context, userID, sessionID string) error {
+	sessions, err := a.Auth.QuerySessions(ctx, userID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, item := range sessions {
+		if item.ID == sessionID {
+			return errors.WithStack(a.Auth.RevokeSession(ctx, userID, sessionID))
+		}
+	}
+	return errors.ErrNotFound
+}
+
+// RevokeAll 撤销用户的所有会话
+func (a *Session) RevokeAll(ctx context.Context, userID string) error {
+	err := a.Auth.RevokeSessions(ctx, userID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// QueryByUser 查询指定用户的有效会话(管理员)
+func (a *Session) QueryByUser(ctx context.Context, userID string) (schema.Sessions, error) {
+	if err := a.checkUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return a.Query(ctx, userID)
+}
+
+// RevokeByUser 强制指定用户下线(管理员)
+func (a *Session) RevokeByUser(ctx context.Context, userID string) error {
+	if err := a.checkUser(ctx, userID); err != nil {
+		return err
+	}
+	return a.RevokeAll(ctx, userID)
+}
+
+func (a *Session) checkUser(ctx context.Context, userID string) error {
+	user, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return err
+	} else if user == nil {
+		return errors.ErrNotFound
+	}
+	return nil
+}
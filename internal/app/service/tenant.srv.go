@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/logger"
+	"ginAdmin/pkg/util/uuid"
+	"github.com/google/wire"
+	"time"
+)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"))
+
+// Tenant 租户管理(只有默认租户的用户可以管理租户)
+type Tenant struct {
+	TransModel  *repo.Trans
+	TenantModel *repo.Tenant
+	CasbinSrv   *Casbin
+	DemoSrv     *Demo
+	DeptSrv     *Dept
+	MenuSrv     *Menu
+	RoleSrv     *Role
+	UserSrv     *User
+}
+
+// InitData 初始化默认租户(升级前的数据均属于默认租户)
+func (a *Tenant) InitData(ctx context.Context) error {
+	item, err := a.TenantModel.Get(ctx, schema.DefaultTenantID)
+	if err != nil {
+		return err
+	} else if item != nil {
+		return nil
+	}
+
+	return a.TenantModel.Create(ctx, schema.Tenant{
+		ID:     schema.DefaultTenantID,
+		Code:   schema.DefaultTenantID,
+		Name:   "默认租户",
+		Status: 1,
+	})
+}
+
+// IsActive 检查租户是否存在且已启用
+func (a *Tenant) IsActive(ctx context.Context, id string) (bool, error) {
+	item, err := a.TenantModel.Get(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return item != nil && item.Status == 1, nil
+}
+
+// 检查当前用户是否属于默认租户
+func (a *Tenant) checkPlatform(ctx context.Context) error {
+	if tenantID, ok := contextx.FromTenantID(ctx); ok && tenantID != schema.DefaultTenantID {
+		return errors.ErrNoPerm
+	}
+	return nil
+}
+
+// Query 查询数据
+func (a *Tenant) Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error) {
+	if err := a.checkPlatform(ctx); err != nil {
+		return nil, err
+	}
+	return a.TenantModel.Query(ctx, params, opts...)
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(ctx context.Context, id string, opts ...schema.TenantQueryOptions) (*schema.Tenant, error) {
+	if err := a.checkPlatform(ctx); err != nil {
+		return nil, err
+	}
+
+	item, err := a.TenantModel.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return nil, errors.ErrNotFound
+	}
+	return item, nil
+}
+
+func (a *Tenant) checkCode(ctx context.Context, code string) error {
+	item, err := a.TenantModel.GetByCode(ctx, code)
+	if err != nil {
+		return err
+	} else if item != nil {
+		return errors.New400Response("租户编号已经存在")
+	}
+
+	item, err = a.TenantModel.GetDeletedByCode(ctx, code)
+	if err != nil {
+		return err
+	} else if item != nil {
+		return errors.New400Response("租户编号已被回收站中的租户使用")
+	}
+	return nil
+}
+
+// Create 创建数据(同时在新租户内初始化菜单及超级管理员)
+func (a *Tenant) Create(ctx context.Context, params schema.TenantCreateParam) (*schema.IDResult, error) {
+	if err := a.checkPlatform(ctx); err != nil {
+		return nil, err
+	}
+
+	item := params.Tenant
+	if err := a.checkCode(ctx, item.Code); err != nil {
+		return nil, err
+	}
+
+	item.ID = uuid.MustString()
+	tctx := contextx.NewTenantID(ctx, item.ID)
+	admin, err := a.UserSrv.newSuperUser(tctx, params.AdminUserName, params.AdminRealName, params.AdminPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	//	租户、菜单及超级管理员在同一事务中创建，任一失败时整体回滚
+	err = a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		err := a.TenantModel.Create(ctx, item)
+		if err != nil {
+			return err
+		}
+
+		tctx := contextx.NewTenantID(ctx, item.ID)
+		if config.C.Menu.Enable && config.C.Menu.Data != "" {
+			err := a.MenuSrv.InitData(tctx, config.C.Menu.Data)
+			if err != nil {
+				return err
+			}
+		}
+		return a.UserSrv.saveSuperUser(tctx, admin)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	//	超级管理员的权限策略需要在事务提交后生成
+	err = a.CasbinSrv.SyncUsers(tctx, admin.ID)
+	if err != nil {
+		logger.WithContext(ctx).Errorf("同步租户[%s]超级管理员的权限策略失败: %s", item.Code, err.Error())
+		a.CasbinSrv.Reload(ctx)
+	}
+
+	logger.WithContext(logger.NewUserIDContext(tctx, admin.ID)).Infof("创建租户[%s]的超级管理员[%s]", item.Code, admin.UserName)
+	return schema.NewIDResult(item.ID), nil
+}
+
+// Update 更新数据
+func (a *Tenant) Update(ctx context.Context, id string, item schema.Tenant) error {
+	if err := a.checkPlatform(ctx); err != nil {
+		return err
+	}
+
+	oldItem, err := a.TenantModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	} else if oldItem.Code != item.Code {
+		if id == schema.DefaultTenantID {
+			return errors.New400Response("不允许修改默认租户的编号")
+		} else if err := a.checkCode(ctx, item.Code); err != nil {
+			return err
+		}
+	}
+
+	if id == schema.DefaultTenantID && item.Status != 1 {
+		return errors.New400Response("不允许停用默认租户")
+	}
+
+	item.ID = oldItem.ID
+	item.Creator = oldItem.Creator
+	item.CreatedAt = oldItem.CreatedAt
+	return a.TenantModel.Update(ctx, id, item)
+}
+
+// Delete 删除数据(需要先停用租户，租户内的数据保留，但不能再登录)
+func (a *Tenant) Delete(ctx context.Context, id string) error {
+	if err := a.checkPlatform(ctx); err != nil {
+		return err
+	} else if id == schema.DefaultTenantID {
+		return errors.ErrNotAllowDelete
+	}
+
+	oldItem, err := a.TenantModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	} else if oldItem.Status == 1 {
+		return errors.New400Response("请先停用租户")
+	}
+
+	err = a.TenantModel.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	//	停用时已撤销会话，此处覆盖升级前停用的租户
+	return a.UserSrv.RevokeTenantSessions(contextx.NewTenantID(ctx, id))
+}
+
+// UpdateStatus 更新状态(停用后租户内的用户不能登录，已签发的令牌立即失效)
+func (a *Tenant) UpdateStatus(ctx context.Context, id string, status int) error {
+	if err := a.checkPlatform(ctx); err != nil {
+		return err
+	} else if id == schema.DefaultTenantID && status != 1 {
+		return errors.New400Response("不允许停用默认租户")
+	}
+
+	oldItem, err := a.TenantModel.Get(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	err = a.TenantModel.UpdateStatus(ctx, id, status)
+	if err != nil {
+		return err
+	}
+
+	if status != 1 {
+		return a.UserSrv.RevokeTenantSessions(contextx.NewTenantID(ctx, id))
+	}
+	return nil
+}
+
+// QueryDeleted 查询回收站中的数据
+func (a *Tenant) QueryDeleted(ctx context.Context, params schema.RecycleQueryParam) (*schema.RecycleQueryResult, error) {
+	if err := a.checkPlatform(ctx); err != nil {
+		return nil, err
+	}
+	return a.TenantModel.QueryDeleted(ctx, params)
+}
+
+// Restore 从回收站恢复数据(恢复后仍为停用状态)
+func (a *Tenant) Restore(ctx context.Context, id string) error {
+	if err := a.checkPlatform(ctx); err != nil {
+		return err
+	}
+
+	oldItem, err := a.TenantModel.GetDeleted(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	return a.TenantModel.Restore(ctx, id)
+}
+
+// Purge 彻底删除删除时间早于指定时间的数据(同时彻底删除租户内的用户、角色、菜单、部门及示例数据)
+func (a *Tenant) Purge(ctx context.Context, before time.Time) (int, error) {
+	ids, err := a.TenantModel.QueryPurgeIDs(ctx, before)
+	if err != nil {
+		return 0, err
+	} else if len(ids) == 0 {
+		return 0, nil
+	}
+
+	for i, id := range ids {
+		err := a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			tctx := contextx.NewTenantID(ctx, id)
+			for _, purge := range []func(context.Context) (int, error){
+				a.UserSrv.PurgeTenant,
+				a.RoleSrv.PurgeTenant,
+				a.MenuSrv.PurgeTenant,
+				a.DeptSrv.PurgeTenant,
+				a.DemoSrv.PurgeTenant,
+			} {
+				if _, err := purge(tctx); err != nil {
+					return err
+				}
+			}
+			return a.TenantModel.Purge(ctx, []string{id})
+		})
+		if err != nil {
+			return i, err
+		}
+	}
+
+	//	租户内用户及角色的权限策略随数据一起删除
+	a.CasbinSrv.Reload(ctx)
+	return len(ids), nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/apikey"
+	"ginAdmin/pkg/auth/jwtauth"
+	"ginAdmin/pkg/auth/jwtauth/store/memory"
+	"ginAdmin/pkg/errors"
+	"ginAdmin/pkg/util/uuid"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func newTestTenantSrv(t *testing.T, env *testEnv) *Tenant {
+	casbinSrv := env.newCasbin(t, nil)
+	roleDeptModel := &repo.RoleDept{DB: env.DB}
+	return &Tenant{
+		TransModel:  env.TransModel,
+		TenantModel: env.TenantModel,
+		CasbinSrv:   casbinSrv,
+		DemoSrv:     &Demo{DemoModel: repo.NewDemo(env.DB)},
+		DeptSrv: &Dept{
+			TransModel:    env.TransModel,
+			DeptModel:     repo.NewDept(env.DB),
+			RoleDeptModel: roleDeptModel,
+			UserModel:     env.UserModel,
+		},
+		MenuSrv: &Menu{
+			CasbinSrv:               casbinSrv,
+			TransModel:              env.TransModel,
+			MenuModel:               env.MenuModel,
+			MenuActionModel:         env.MenuActionModel,
+			MenuActionResourceModel: env.MenuResourceModel,
+			RoleMenuModel:           env.RoleMenuModel,
+		},
+		RoleSrv: env.newRoleSrv(casbinSrv),
+		UserSrv: env.newUserSrv(jwtauth.New(memory.NewStore(0)), casbinSrv),
+	}
+}
+
+func newTestTenantParam(code string) schema.TenantCreateParam {
+	return schema.TenantCreateParam{
+		Tenant:        schema.Tenant{Code: code, Name: code, Status: 1},
+		AdminUserName: "admin",
+		AdminRealName: "admin",
+		AdminPassword: "123456",
+	}
+}
+
+func TestTenantCreate(t *testing.T) {
+	env := newTestEnv(t)
+	a := newTestTenantSrv(t, env)
+	ctx := context.Background()
+
+	result, err := a.Create(ctx, newTestTenantParam("t1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := a.UserSrv.UserModel.Query(contextx.NewTenantID(ctx, result.ID), schema.UserQueryParam{OnlySuper: true})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(users.Data) != 1 {
+		t.Fatalf("unexpected super users: %d", len(users.Data))
+	}
+
+	// 超级管理员的权限策略在提交后同步到enforcer
+	ok, err := a.CasbinSrv.Enforcer.Enforce(users.Data[0].ID, result.ID, "/api/v1/users", "GET")
+	if err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("expected super user policy")
+	}
+}
+
+func TestTenantCreateRollback(t *testing.T) {
+	env := newTestEnv(t)
+	a := newTestTenantSrv(t, env)
+
+	// 超级管理员的密码历史写入失败时租户及用户整体回滚
+	err := env.DB.Callback().Create().Before("gorm:create").Register("test:fail", func(db *gorm.DB) {
+		if db.Statement.Table == "password_history" {
+			_ = db.AddError(errors.New("failed"))
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.Create(context.Background(), newTestTenantParam("t1")); err == nil {
+		t.Fatal("expected create error")
+	}
+	if n := env.count(t, new(entity.Tenant)); n != 0 {
+		t.Fatalf("unexpected tenants: %d", n)
+	}
+	if n := env.count(t, new(entity.User)); n != 0 {
+		t.Fatalf("unexpected users: %d", n)
+	}
+	if n := len(a.CasbinSrv.Enforcer.GetPolicy()); n != 0 {
+		t.Fatalf("unexpected policies: %d", n)
+	}
+}
+
+func TestTenantDisable(t *testing.T) {
+	env := newTestEnv(t)
+	a := newTestTenantSrv(t, env)
+	ctx := context.Background()
+
+	result, err := a.Create(ctx, newTestTenantParam("t1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := a.UserSrv.UserModel.Query(contextx.NewTenantID(ctx, result.ID), schema.UserQueryParam{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID := users.Data[0].ID
+
+	tokenInfo, err := a.UserSrv.Auth.GenerateToken(auth.NewTenantContext(ctx, result.ID), userID, auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, prefix, err := apikey.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.create(t, result.ID, &entity.APIKey{ID: uuid.MustString(), UserID: userID, Name: "key", Prefix: prefix, TokenHash: apikey.Hash(token)})
+	apiKeySrv := &APIKey{TenantModel: env.TenantModel, UserModel: env.UserModel, APIKeyModel: a.UserSrv.APIKeyModel}
+	if _, err := apiKeySrv.Verify(ctx, token); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.UpdateStatus(ctx, result.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// 停用租户后已签发的令牌及API密钥立即失效
+	if _, err := a.UserSrv.Auth.ParseIdentity(ctx, tokenInfo.GetAccessToken()); err != auth.ErrInvalidToken {
+		t.Fatalf("expected revoked token, got %v", err)
+	}
+	if _, err := apiKeySrv.Verify(ctx, token); err != errors.ErrInvalidToken {
+		t.Fatalf("expected rejected api key, got %v", err)
+	}
+	if ok, err := a.IsActive(ctx, result.ID); err != nil || ok {
+		t.Fatalf("unexpected tenant status: %v, %v", ok, err)
+	}
+}
@@ -2,13 +2,16 @@ package service
 
 import (
 	"context"
+	"ginAdmin/internal/app/config"
+	"ginAdmin/internal/app/contextx"
 	"ginAdmin/internal/app/model/gormx/repo"
 	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/auth"
 	"ginAdmin/pkg/errors"
-	"ginAdmin/pkg/util/hash"
+	"ginAdmin/pkg/logger"
 	"ginAdmin/pkg/util/uuid"
-	"github.com/casbin/casbin/v2"
 	"github.com/google/wire"
+	"time"
 )
 
 // UserSet 注入User
@@ -16,11 +19,19 @@ var UserSet = wire.NewSet(wire.Struct(new(User), "*"))
 
 // User 用户管理
 type User struct {
-	Enforcer      *casbin.SyncedEnforcer
-	TransModel    *repo.Trans
-	UserModel     *repo.User
-	UserRoleModel *repo.UserRole
-	RoleModel     *repo.Role
+	Auth                 auth.Auther
+	CasbinSrv            *Casbin
+	TransModel           *repo.Trans
+	UserModel            *repo.User
+	UserRoleModel        *repo.UserRole
+	RoleModel            *repo.Role
+	DeptModel            *repo.Dept
+	PasswordHistoryModel *repo.PasswordHistory
+	UserIdentityModel    *repo.UserIdentity
+	APIKeyModel          *repo.APIKey
+	UserMFAModel         *repo.UserMFA
+	PasswordResetModel   *repo.PasswordReset
+	PasswordPolicySrv    *PasswordPolicy
 }
 
 // Query 查询数据
@@ -56,7 +67,7 @@ func (a *User) QueryShow(ctx context.Context, params schema.UserQueryParam, opts
 
 // Get 查询指定数据
 func (a *User) Get(ctx context.Context, id string, opts ...schema.UserQueryOptions) (*schema.User, error) {
-	item, err := a.UserModel.Get(ctx, id, opts...)
+	item, err := a.UserModel.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	} else if item == nil {
@@ -81,34 +92,103 @@ func (a *User) Create(ctx context.Context, item schema.User) (*schema.IDResult,
 		return nil, err
 	}
 
-	item.Password = hash.SHA1String(item.Password)
+	err = a.checkDept(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.checkRoles(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.PasswordPolicySrv.Validate(ctx, &schema.User{UserName: item.UserName}, item.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	item.Password, err = a.PasswordPolicySrv.Hash(item.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
 	item.ID = uuid.MustString()
-	err = a.TransModel.Exec(ctx, func(ctx context.Context) error {
-		for _, urItem := range item.UserRoles {
-			urItem.ID = uuid.MustString()
-			urItem.UserID = item.ID
-			err := a.UserRoleModel.Create(ctx, *urItem)
+	item.IsSuper = false
+	item.PasswordChangedAt = &now
+	err = a.CasbinSrv.UpdateUser(ctx, item.ID, func() error {
+		return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			for _, urItem := range item.UserRoles {
+				urItem.ID = uuid.MustString()
+				urItem.UserID = item.ID
+				err := a.UserRoleModel.Create(ctx, *urItem)
+				if err != nil {
+					return err
+				}
+			}
+
+			err := a.UserModel.Create(ctx, item)
 			if err != nil {
 				return err
 			}
-		}
 
-		return a.UserModel.Create(ctx, item)
+			return a.PasswordPolicySrv.Record(ctx, item.ID, item.Password)
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
 	return schema.NewIDResult(item.ID), nil
 }
 
-func (a *User) checkUserName(ctx context.Context, item schema.User) error {
-	if item.UserName == schema.GetRootUser().UserName {
-		return errors.New400Response("用户名不合法")
+// 检查所属部门是否存在
+func (a *User) checkDept(ctx context.Context, item schema.User) error {
+	if item.DeptID == "" {
+		return nil
 	}
 
-	result, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+	dept, err := a.DeptModel.Get(ctx, item.DeptID)
+	if err != nil {
+		return err
+	} else if dept == nil {
+		return errors.New400Response("无效的部门")
+	}
+	return nil
+}
+
+// 检查用户角色均存在于当前租户，且授权有效期合法
+func (a *User) checkRoles(ctx context.Context, item schema.User) error {
+	roleIDs := item.UserRoles.ToRoleIDs()
+	if len(roleIDs) == 0 {
+		return nil
+	}
+
+	for _, ur := range item.UserRoles {
+		if ur.ValidFrom != nil && ur.ValidUntil != nil && !ur.ValidUntil.After(*ur.ValidFrom) {
+			return errors.New400Response("角色授权的失效时间必须晚于生效时间")
+		}
+	}
+
+	mRoleIDs := make(map[string]struct{}, len(roleIDs))
+	for _, roleID := range roleIDs {
+		mRoleIDs[roleID] = struct{}{}
+	}
+
+	result, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		IDs:             roleIDs,
+	})
+	if err != nil {
+		return err
+	} else if result.PageResult.Total != int64(len(mRoleIDs)) {
+		return errors.New400Response("无效的角色")
+	}
+	return nil
+}
+
+func (a *User) checkUserName(ctx context.Context, item schema.User) error {
+	result, err := a.UserModel.Query(contextx.NewNoDataScope(ctx), schema.UserQueryParam{
 		PaginationParam: schema.PaginationParam{OnlyCount: true},
 		UserName:        item.UserName,
 	})
@@ -127,6 +207,10 @@ func (a *User) Update(ctx context.Context, id string, item schema.User) error {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
+	} else if oldItem.Version != item.Version {
+		return errors.ErrPreconditionFailed
+	} else if err := a.checkSuper(ctx, oldItem); err != nil {
+		return err
 	} else if oldItem.UserName != item.UserName {
 		err := a.checkUserName(ctx, item)
 		if err != nil {
@@ -134,50 +218,96 @@ func (a *User) Update(ctx context.Context, id string, item schema.User) error {
 		}
 	}
 
-	if item.Password != "" {
-		item.Password = hash.SHA1String(item.Password)
+	if oldItem.DeptID != item.DeptID {
+		err := a.checkDept(ctx, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = a.checkRoles(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	passwordChanged := item.Password != ""
+	if passwordChanged {
+		err = a.PasswordPolicySrv.Validate(ctx, &schema.User{
+			ID:       oldItem.ID,
+			UserName: item.UserName,
+			Password: oldItem.Password,
+		}, item.Password)
+		if err != nil {
+			return err
+		}
+
+		item.Password, err = a.PasswordPolicySrv.Hash(item.Password)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		item.PasswordChangedAt = &now
 	} else {
 		item.Password = oldItem.Password
+		item.PasswordChangedAt = oldItem.PasswordChangedAt
 	}
 
 	item.ID = oldItem.ID
+	item.IsSuper = oldItem.IsSuper
 	item.Creator = oldItem.Creator
 	item.CreatedAt = oldItem.CreatedAt
-	err = a.TransModel.Exec(ctx, func(ctx context.Context) error {
-		addUserRoles, delUserRoles := a.compareUserRoles(ctx, oldItem.UserRoles, item.UserRoles)
-		for _, rmitem := range addUserRoles {
-			rmitem.ID = uuid.MustString()
-			rmitem.UserID = id
-			err := a.UserRoleModel.Create(ctx, *rmitem)
+	// 先按版本号更新用户，版本冲突时不再应用角色授权的差异
+	return a.CasbinSrv.UpdateUser(ctx, id, func() error {
+		return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			err := a.UserModel.Update(ctx, id, item)
 			if err != nil {
 				return err
 			}
-		}
 
-		for _, rmitem := range delUserRoles {
-			err := a.UserRoleModel.Delete(ctx, rmitem.ID)
-			if err != nil {
-				return err
+			addUserRoles, updUserRoles, delUserRoles := a.compareUserRoles(ctx, oldItem.UserRoles, item.UserRoles)
+			for _, rmitem := range addUserRoles {
+				rmitem.ID = uuid.MustString()
+				rmitem.UserID = id
+				err := a.UserRoleModel.Create(ctx, *rmitem)
+				if err != nil {
+					return err
+				}
 			}
-		}
 
-		return a.UserModel.Update(ctx, id, item)
-	})
-	if err != nil {
-		return err
-	}
+			for _, rmitem := range updUserRoles {
+				err := a.UserRoleModel.UpdateValidity(ctx, rmitem.ID, rmitem.ValidFrom, rmitem.ValidUntil)
+				if err != nil {
+					return err
+				}
+			}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
-	return nil
+			for _, rmitem := range delUserRoles {
+				err := a.UserRoleModel.Delete(ctx, rmitem.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			if passwordChanged {
+				return a.PasswordPolicySrv.Record(ctx, id, item.Password)
+			}
+			return nil
+		})
+	})
 }
 
-func (a *User) compareUserRoles(ctx context.Context, oldUserRoles, newUserRoles schema.UserRoles) (addList, delList schema.UserRoles) {
+func (a *User) compareUserRoles(ctx context.Context, oldUserRoles, newUserRoles schema.UserRoles) (addList, updList, delList schema.UserRoles) {
 	mOldUserRoles := oldUserRoles.ToMap()
 	mNewUserRoles := newUserRoles.ToMap()
 
 	for k, item := range mNewUserRoles {
-		if _, ok := mOldUserRoles[k]; ok {
+		if oldItem, ok := mOldUserRoles[k]; ok {
 			delete(mOldUserRoles, k)
+			if !oldItem.EqualValidity(item) {
+				item.ID = oldItem.ID
+				updList = append(updList, item)
+			}
 			continue
 		}
 		addList = append(addList, item)
@@ -196,39 +326,292 @@ func (a *User) Delete(ctx context.Context, id string) error {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
+	} else if oldItem.IsSuper {
+		return errors.New400Response("超级管理员不允许删除")
 	}
 
-	err = a.TransModel.Exec(ctx, func(ctx context.Context) error {
-		err := a.UserRoleModel.DeleteByUserID(ctx, id)
-		if err != nil {
-			return err
-		}
-
+	// 用户的角色授权、第三方账号及API密钥等保留到彻底删除，以便从回收站恢复(用户删除后均不可用)
+	err = a.CasbinSrv.UpdateUser(ctx, id, func() error {
 		return a.UserModel.Delete(ctx, id)
 	})
 	if err != nil {
 		return err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
-	return nil
+	return a.revokeSessions(ctx, id)
 }
 
-// UpdateStatus 更新状态
-func (a *User) UpdateStatus(ctx context.Context, id string, status int) error {
+// UpdateStatus 更新状态(version为客户端读取时的版本号)
+func (a *User) UpdateStatus(ctx context.Context, id string, status int, version int64) error {
 	oldItem, err := a.UserModel.Get(ctx, id)
 	if err != nil {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
+	} else if oldItem.Version != version {
+		return errors.ErrPreconditionFailed
+	} else if err := a.checkSuper(ctx, oldItem); err != nil {
+		return err
 	}
 	oldItem.Status = status
 
-	err = a.UserModel.UpdateStatus(ctx, id, status)
+	err = a.CasbinSrv.UpdateUser(ctx, id, func() error {
+		return a.UserModel.UpdateStatus(ctx, id, status, version)
+	})
+	if err != nil {
+		return err
+	}
+
+	//	禁用用户时立即撤销其所有会话
+	if status != 1 {
+		return a.revokeSessions(ctx, id)
+	}
+	return nil
+}
+
+func (a *User) revokeSessions(ctx context.Context, id string) error {
+	err := a.Auth.RevokeSessions(ctx, id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// 检查当前用户是否有权修改指定用户(只有超级管理员可以修改超级管理员)
+func (a *User) checkSuper(ctx context.Context, item *schema.User) error {
+	if !item.IsSuper {
+		return nil
+	}
+
+	userID, _ := contextx.FromUserID(ctx)
+	current, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return err
+	} else if current == nil || !current.IsSuper {
+		return errors.ErrNoPerm
+	}
+	return nil
+}
+
+// GetSuperUserID 获取默认租户中已启用的超级管理员ID
+func (a *User) GetSuperUserID(ctx context.Context) (string, error) {
+	ctx = contextx.NewTenantID(ctx, schema.DefaultTenantID)
+	result, err := a.UserModel.Query(contextx.NewNoDataScope(ctx), schema.UserQueryParam{
+		Status:    1,
+		OnlySuper: true,
+	}, schema.UserQueryOptions{
+		OrderFields: schema.NewOrderFields(schema.NewOrderField("created_at", schema.OrderByASC)),
+	})
+	if err != nil {
+		return "", err
+	} else if len(result.Data) == 0 {
+		return "", errors.ErrInvalidUser
+	}
+	return result.Data[0].ID, nil
+}
+
+// InitSuperUser 初始化超级管理员(数据库中不存在超级管理员时使用配置创建)
+func (a *User) InitSuperUser(ctx context.Context) error {
+	cfg := config.C.Root
+	ctx = logger.NewTagContext(ctx, "__bootstrap__")
+
+	result, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		OnlySuper:       true,
+	})
 	if err != nil {
 		return err
+	} else if result.PageResult.Total > 0 {
+		logger.WithContext(ctx).Warnf("超级管理员已存在，请关闭超级管理员初始化并清除配置中的初始密码")
+		return nil
+	}
+
+	if cfg.UserName == "" || cfg.Password == "" {
+		return errors.New("root user name and password can not be empty")
+	}
+
+	_, err = a.CreateSuperUser(ctx, cfg.UserName, cfg.RealName, cfg.Password)
+	return err
+}
+
+// CreateSuperUser 在当前租户内创建超级管理员
+func (a *User) CreateSuperUser(ctx context.Context, userName, realName, password string) (*schema.IDResult, error) {
+	item, err := a.newSuperUser(ctx, userName, realName, password)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.CasbinSrv.UpdateUser(ctx, item.ID, func() error {
+		return a.saveSuperUser(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WithContext(logger.NewUserIDContext(ctx, item.ID)).Infof("创建超级管理员[%s]", item.UserName)
+	return schema.NewIDResult(item.ID), nil
+}
+
+// 校验用户名并生成超级管理员(密码哈希较慢，不在事务及权限策略锁内执行)
+func (a *User) newSuperUser(ctx context.Context, userName, realName, password string) (*schema.User, error) {
+	item := schema.User{
+		UserName: userName,
+		RealName: realName,
+		Status:   1,
+		IsSuper:  true,
+	}
+	if err := a.checkUserName(ctx, item); err != nil {
+		return nil, err
+	}
+
+	var err error
+	item.Password, err = a.PasswordPolicySrv.Hash(password)
+	if err != nil {
+		return nil, err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	now := time.Now()
+	item.ID = uuid.MustString()
+	item.PasswordChangedAt = &now
+	return &item, nil
+}
+
+// 保存超级管理员(不更新权限策略，在外部事务中调用时需在提交后同步)
+func (a *User) saveSuperUser(ctx context.Context, item *schema.User) error {
+	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		err := a.UserModel.Create(ctx, *item)
+		if err != nil {
+			return err
+		}
+		return a.PasswordPolicySrv.Record(ctx, item.ID, item.Password)
+	})
+}
+
+// RevokeTenantSessions 撤销上下文中租户内所有用户的会话(租户停用或删除时)
+func (a *User) RevokeTenantSessions(ctx context.Context) error {
+	result, err := a.UserModel.Query(contextx.NewNoDataScope(ctx), schema.UserQueryParam{})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range result.Data {
+		if err := a.revokeSessions(ctx, item.ID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// QueryDeleted 查询回收站中的数据
+func (a *User) QueryDeleted(ctx context.Context, params schema.RecycleQueryParam) (*schema.RecycleQueryResult, error) {
+	return a.UserModel.QueryDeleted(ctx, params)
+}
+
+// Restore 从回收站恢复数据(删除期间已被删除的角色不再授权)
+func (a *User) Restore(ctx context.Context, id string) error {
+	oldItem, err := a.UserModel.GetDeleted(ctx, id)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	if err := a.checkUserName(ctx, *oldItem); err != nil {
+		return err
+	}
+
+	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserID: id,
+	})
+	if err != nil {
+		return err
+	}
+
+	var mRoles map[string]*schema.Role
+	if roleIDs := userRoleResult.Data.ToRoleIDs(); len(roleIDs) > 0 {
+		roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+			IDs: roleIDs,
+		})
+		if err != nil {
+			return err
+		}
+		mRoles = roleResult.Data.ToMap()
+	}
+
+	return a.CasbinSrv.UpdateUser(ctx, id, func() error {
+		return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+			for _, ur := range userRoleResult.Data {
+				if _, ok := mRoles[ur.RoleID]; ok {
+					continue
+				}
+
+				err := a.UserRoleModel.Delete(ctx, ur.ID)
+				if err != nil {
+					return err
+				}
+			}
+
+			return a.UserModel.Restore(ctx, id)
+		})
+	})
+}
+
+// Purge 彻底删除删除时间早于指定时间的数据(同时删除用户的角色授权、第三方账号、API密钥及安全数据)
+func (a *User) Purge(ctx context.Context, before time.Time) (int, error) {
+	ids, err := a.UserModel.QueryPurgeIDs(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.purge(ctx, ids)
+}
+
+// PurgeTenant 彻底删除上下文中租户的全部数据
+func (a *User) PurgeTenant(ctx context.Context) (int, error) {
+	ids, err := a.UserModel.DeleteTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(ids), a.purge(ctx, ids)
+}
+
+func (a *User) purge(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return a.TransModel.Exec(ctx, func(ctx context.Context) error {
+		for _, id := range ids {
+			err := a.UserRoleModel.DeleteByUserID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.PasswordHistoryModel.DeleteByUserID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.PasswordResetModel.DeleteByUserID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.UserIdentityModel.DeleteByUserID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.UserMFAModel.DeleteByUserID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = a.APIKeyModel.DeleteByUserID(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return a.UserModel.Purge(ctx, ids)
+	})
+}
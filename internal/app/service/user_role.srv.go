@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/repo"
+	"ginAdmin/internal/app/schema"
+	"ginAdmin/pkg/logger"
+	"github.com/google/wire"
+	"time"
+)
+
+// UserRoleSet 注入UserRole
+var UserRoleSet = wire.NewSet(wire.Struct(new(UserRole), "*"))
+
+// UserRole 用户角色授权(授权有效期管理)
+type UserRole struct {
+	UserRoleModel *repo.UserRole
+	UserModel     *repo.User
+	RoleModel     *repo.Role
+	CasbinSrv     *Casbin
+}
+
+// QueryExpiring 查询指定天数内即将到期的角色授权(限定为当前租户及数据权限范围内的用户，按失效时间升序)
+func (a *UserRole) QueryExpiring(ctx context.Context, params schema.UserRoleExpiringParam) (*schema.UserRoleExpiringQueryResult, error) {
+	now := time.Now()
+	until := now.AddDate(0, 0, params.Days)
+	result, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		PaginationParam: params.PaginationParam,
+		ExpireAfter:     &now,
+		ExpireBefore:    &until,
+		InDataScope:     true,
+	}, schema.UserRoleQueryOptions{
+		OrderFields: schema.NewOrderFields(schema.NewOrderField("valid_until", schema.OrderByASC)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	qr := &schema.UserRoleExpiringQueryResult{
+		PageResult: result.PageResult,
+	}
+	if len(result.Data) == 0 {
+		return qr, nil
+	}
+
+	userResult, err := a.UserModel.Query(contextx.NewNoDataScope(ctx), schema.UserQueryParam{
+		IDs: result.Data.ToUserIDs(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	mUsers := make(map[string]*schema.User, len(userResult.Data))
+	for _, user := range userResult.Data {
+		mUsers[user.ID] = user
+	}
+
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		IDs: result.Data.ToRoleIDs(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	mRoles := roleResult.Data.ToMap()
+
+	for _, ur := range result.Data {
+		item := &schema.UserRoleExpiring{
+			ID:         ur.ID,
+			UserID:     ur.UserID,
+			RoleID:     ur.RoleID,
+			ValidFrom:  ur.ValidFrom,
+			ValidUntil: ur.ValidUntil,
+		}
+		if user, ok := mUsers[ur.UserID]; ok {
+			item.UserName = user.UserName
+			item.RealName = user.RealName
+		}
+		if role, ok := mRoles[ur.RoleID]; ok {
+			item.RoleName = role.Name
+		}
+		qr.Data = append(qr.Data, item)
+	}
+	return qr, nil
+}
+
+// Sweep 将生效或失效时间处于(since, until]之间的角色授权同步到casbin权限策略
+func (a *UserRole) Sweep(ctx context.Context, since, until time.Time) error {
+	result, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		ChangedAfter:  &since,
+		ChangedBefore: &until,
+	})
+	if err != nil {
+		return err
+	}
+
+	userIDs := result.Data.ToUserIDs()
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	logger.WithContext(ctx).Infof("同步到达生效或失效时间的角色授权: %d个用户", len(userIDs))
+	return a.CasbinSrv.SyncUsers(ctx, userIDs...)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"ginAdmin/internal/app/contextx"
+	"ginAdmin/internal/app/model/gormx/entity"
+	"ginAdmin/pkg/auth/jwtauth"
+	"ginAdmin/pkg/auth/jwtauth/store/memory"
+	"ginAdmin/pkg/errors"
+	"testing"
+)
+
+func TestUserUpdateVersion(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.newUserSrv(jwtauth.New(memory.NewStore(0)), new(Casbin))
+	ctx := contextx.NewTenantID(context.Background(), "t1")
+
+	env.create(t, "t1", &entity.Dept{ID: "dept1", Name: "dept1", Status: 1})
+	env.create(t, "t1", &entity.User{ID: "user1", UserName: "user1", RealName: "user1", Status: 1, DeptID: "dept1"})
+
+	get := func() *entity.User {
+		var item entity.User
+		if err := env.DB.Where("id=?", "user1").First(&item).Error; err != nil {
+			t.Fatal(err)
+		}
+		return &item
+	}
+
+	// 移出部门与其它字段在同一次按版本号的更新中完成
+	item, err := a.Get(ctx, "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	item.RealName = "renamed"
+	item.DeptID = ""
+	if err := a.Update(ctx, "user1", *item); err != nil {
+		t.Fatal(err)
+	}
+	if u := get(); u.Version != 2 || u.RealName != "renamed" || u.DeptID != "" {
+		t.Fatalf("unexpected user: version=%d real_name=%s dept_id=%s", u.Version, u.RealName, u.DeptID)
+	}
+
+	// 使用过期的版本号(If-Match)时返回412，且不修改任何字段
+	item.DeptID = "dept1"
+	if err := a.Update(ctx, "user1", *item); err != errors.ErrPreconditionFailed {
+		t.Fatalf("expected ErrPreconditionFailed, got %v", err)
+	}
+	if err := a.UserModel.Update(ctx, "user1", *item); err != errors.ErrPreconditionFailed {
+		t.Fatalf("expected ErrPreconditionFailed, got %v", err)
+	}
+	if u := get(); u.Version != 2 || u.DeptID != "" {
+		t.Fatalf("stale update must not apply: version=%d dept_id=%s", u.Version, u.DeptID)
+	}
+
+	// 修改密码后之前读取的版本号失效
+	item.Version = 2
+	if err := a.PasswordPolicySrv.Change(ctx, "user1", "changed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Update(ctx, "user1", *item); err != errors.ErrPreconditionFailed {
+		t.Fatalf("expected ErrPreconditionFailed after password change, got %v", err)
+	}
+
+	if err := a.UserModel.UpdatePassword(ctx, "user1", "rehashed"); err != nil {
+		t.Fatal(err)
+	}
+	if u := get(); u.Version != 4 || u.Password != "rehashed" {
+		t.Fatalf("unexpected user: version=%d password=%s", u.Version, u.Password)
+	}
+}
@@ -18,7 +18,16 @@ func BuildInjector() (*Injector, func(), error) {
 		// mock.MockSet,
 		InitGormDB,
 		repo.RepoSet,
+		InitJWTKeySet,
 		InitAuth,
+		InitPassword,
+		InitPasswordPolicy,
+		InitLoginLockout,
+		InitPasswordResetLimiter,
+		InitNotifier,
+		InitIdentityProviders,
+		InitOIDCStore,
+		InitCasbinWatcher,
 		InitCasbin,
 		InitGinEngine,
 		service.ServiceSet,
@@ -1,7 +1,8 @@
 // Code generated by Wire. DO NOT EDIT.
 
 //go:generate go run github.com/google/wire/cmd/wire
-//+build !wireinject
+//go:build !wireinject
+// +build !wireinject
 
 package app
 
@@ -17,7 +18,11 @@ import (
 
 // BuildInjector 生成注入器
 func BuildInjector() (*Injector, func(), error) {
-	auther, cleanup, err := InitAuth()
+	keySet, err := InitJWTKeySet()
+	if err != nil {
+		return nil, nil, err
+	}
+	auther, cleanup, err := InitAuth(keySet)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -26,101 +31,334 @@ func BuildInjector() (*Injector, func(), error) {
 		cleanup()
 		return nil, nil, err
 	}
-	role := &repo.Role{
-		DB: db,
+	manager, err := InitPassword()
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
+	policy := InitPasswordPolicy()
+	limiter, cleanup3, err := InitLoginLockout()
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	passwordResetLimiter, cleanup4, err := InitPasswordResetLimiter()
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	role := repo.NewRole(db)
 	roleMenu := &repo.RoleMenu{
 		DB: db,
 	}
-	menuActionResource := &repo.MenuActionResource{
+	roleParent := &repo.RoleParent{
 		DB: db,
 	}
-	user := &repo.User{
+	menuActionResource := &repo.MenuActionResource{
 		DB: db,
 	}
+	user := repo.NewUser(db)
 	userRole := &repo.UserRole{
 		DB: db,
 	}
 	casbinAdapter := &adapter.CasbinAdapter{
 		RoleModel:         role,
 		RoleMenuModel:     roleMenu,
+		RoleParentModel:   roleParent,
 		MenuResourceModel: menuActionResource,
 		UserModel:         user,
 		UserRoleModel:     userRole,
 	}
-	syncedEnforcer, cleanup3, err := InitCasbin(casbinAdapter)
+	watcher, cleanup5, err := InitCasbinWatcher()
 	if err != nil {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	demo := &repo.Demo{
-		DB: db,
+	syncedEnforcer, cleanup6, err := InitCasbin(casbinAdapter, watcher)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
+	demo := repo.NewDemo(db)
 	serviceDemo := &service.Demo{
 		DemoModel: demo,
 	}
 	apiDemo := &api.Demo{
 		DemoSrv: serviceDemo,
 	}
-	menu := &repo.Menu{
+	dept := repo.NewDept(db)
+	roleDept := &repo.RoleDept{
 		DB: db,
 	}
+	dataScope := &service.DataScope{
+		UserModel:     user,
+		RoleModel:     role,
+		RoleDeptModel: roleDept,
+		DeptModel:     dept,
+	}
+	jwks := &api.JWKS{
+		KeySet: keySet,
+	}
+	menu := repo.NewMenu(db)
 	menuAction := &repo.MenuAction{
 		DB: db,
 	}
+	trans := &repo.Trans{
+		DB: db,
+	}
+	passwordHistory := &repo.PasswordHistory{
+		DB: db,
+	}
+	passwordPolicy := &service.PasswordPolicy{
+		Policy:               policy,
+		Password:             manager,
+		TransModel:           trans,
+		UserModel:            user,
+		PasswordHistoryModel: passwordHistory,
+	}
+	userMFA := &repo.UserMFA{
+		DB: db,
+	}
+	mfa := &service.MFA{
+		TransModel:   trans,
+		UserModel:    user,
+		UserMFAModel: userMFA,
+	}
+	lockout := &service.Lockout{
+		Limiter:   limiter,
+		UserModel: user,
+	}
+	tenant := repo.NewTenant(db)
+	serviceCasbin := &service.Casbin{
+		Enforcer: syncedEnforcer,
+		Adapter:  casbinAdapter,
+		Watcher:  watcher,
+	}
 	login := &service.Login{
-		Auth:            auther,
-		UserModel:       user,
-		UserRoleModel:   userRole,
-		RoleModel:       role,
-		RoleMenuModel:   roleMenu,
-		MenuModel:       menu,
-		MenuActionModel: menuAction,
+		Auth:              auther,
+		CasbinSrv:         serviceCasbin,
+		TenantModel:       tenant,
+		UserModel:         user,
+		UserRoleModel:     userRole,
+		RoleModel:         role,
+		RoleMenuModel:     roleMenu,
+		RoleParentModel:   roleParent,
+		MenuModel:         menu,
+		MenuActionModel:   menuAction,
+		MFASrv:            mfa,
+		LockoutSrv:        lockout,
+		Password:          manager,
+		PasswordPolicySrv: passwordPolicy,
 	}
-	apiLogin := &api.Login{
-		LoginSrv: login,
+	identityProviders := InitIdentityProviders()
+	storer, cleanup7, err := InitOIDCStore()
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
 	}
-	trans := &repo.Trans{
+	userIdentity := &repo.UserIdentity{
 		DB: db,
 	}
+	externalLogin := &service.ExternalLogin{
+		CasbinSrv:         serviceCasbin,
+		LoginSrv:          login,
+		Providers:         identityProviders,
+		Store:             storer,
+		TransModel:        trans,
+		UserModel:         user,
+		UserRoleModel:     userRole,
+		RoleModel:         role,
+		UserIdentityModel: userIdentity,
+	}
+	apiLogin := &api.Login{
+		LoginSrv:         login,
+		MFASrv:           mfa,
+		ExternalLoginSrv: externalLogin,
+	}
 	serviceMenu := &service.Menu{
 		TransModel:              trans,
+		CasbinSrv:               serviceCasbin,
 		MenuModel:               menu,
 		MenuActionModel:         menuAction,
 		MenuActionResourceModel: menuActionResource,
+		RoleMenuModel:           roleMenu,
 	}
 	apiMenu := &api.Menu{
 		MenuSrv: serviceMenu,
 	}
-	serviceRole := &service.Role{
-		Enforcer:      syncedEnforcer,
+	serviceDept := &service.Dept{
 		TransModel:    trans,
-		RoleModel:     role,
-		RoleMenuModel: roleMenu,
+		DeptModel:     dept,
+		RoleDeptModel: roleDept,
 		UserModel:     user,
 	}
+	apiDept := &api.Dept{
+		DeptSrv: serviceDept,
+	}
+	apiLockout := &api.Lockout{
+		LockoutSrv: lockout,
+	}
+	apiMFA := &api.MFA{
+		MFASrv: mfa,
+	}
+	serviceRole := &service.Role{
+		CasbinSrv:       serviceCasbin,
+		TransModel:      trans,
+		RoleModel:       role,
+		RoleMenuModel:   roleMenu,
+		RoleDeptModel:   roleDept,
+		RoleParentModel: roleParent,
+		DeptModel:       dept,
+		UserModel:       user,
+		UserRoleModel:   userRole,
+	}
+	passwordReset := &repo.PasswordReset{
+		DB: db,
+	}
+	notifier := InitNotifier()
+	servicePasswordReset := &service.PasswordReset{
+		Auth:               auther,
+		TransModel:         trans,
+		UserModel:          user,
+		PasswordResetModel: passwordReset,
+		PasswordPolicySrv:  passwordPolicy,
+		LoginSrv:           login,
+		Limiter:            passwordResetLimiter,
+		Notifier:           notifier,
+	}
+	apiPasswordReset := &api.PasswordReset{
+		PasswordResetSrv: servicePasswordReset,
+	}
+	permission := &service.Permission{
+		Enforcer:                syncedEnforcer,
+		UserModel:               user,
+		RoleModel:               role,
+		RoleMenuModel:           roleMenu,
+		MenuModel:               menu,
+		MenuActionModel:         menuAction,
+		MenuActionResourceModel: menuActionResource,
+	}
+	apiPermission := &api.Permission{
+		PermissionSrv: permission,
+	}
 	apiRole := &api.Role{
 		RoleSrv: serviceRole,
 	}
+	session := &service.Session{
+		Auth:      auther,
+		UserModel: user,
+	}
+	apiSession := &api.Session{
+		SessionSrv: session,
+	}
+	apiKey := &repo.APIKey{
+		DB: db,
+	}
 	serviceUser := &service.User{
-		Enforcer:      syncedEnforcer,
-		TransModel:    trans,
-		UserModel:     user,
-		UserRoleModel: userRole,
-		RoleModel:     role,
+		Auth:                 auther,
+		CasbinSrv:            serviceCasbin,
+		TransModel:           trans,
+		UserModel:            user,
+		UserRoleModel:        userRole,
+		RoleModel:            role,
+		DeptModel:            dept,
+		PasswordHistoryModel: passwordHistory,
+		UserIdentityModel:    userIdentity,
+		APIKeyModel:          apiKey,
+		UserMFAModel:         userMFA,
+		PasswordResetModel:   passwordReset,
+		PasswordPolicySrv:    passwordPolicy,
+	}
+	serviceTenant := &service.Tenant{
+		TransModel:  trans,
+		TenantModel: tenant,
+		CasbinSrv:   serviceCasbin,
+		DemoSrv:     serviceDemo,
+		DeptSrv:     serviceDept,
+		MenuSrv:     serviceMenu,
+		RoleSrv:     serviceRole,
+		UserSrv:     serviceUser,
+	}
+	apiTenant := &api.Tenant{
+		TenantSrv: serviceTenant,
+	}
+	recycleBin := &service.RecycleBin{
+		DemoSrv:   serviceDemo,
+		DeptSrv:   serviceDept,
+		MenuSrv:   serviceMenu,
+		RoleSrv:   serviceRole,
+		UserSrv:   serviceUser,
+		TenantSrv: serviceTenant,
 	}
 	apiUser := &api.User{
 		UserSrv: serviceUser,
 	}
+	serviceUserRole := &service.UserRole{
+		UserRoleModel: userRole,
+		UserModel:     user,
+		RoleModel:     role,
+		CasbinSrv:     serviceCasbin,
+	}
+	apiUserRole := &api.UserRole{
+		UserRoleSrv: serviceUserRole,
+	}
+	serviceAPIKey := &service.APIKey{
+		CasbinSrv:   serviceCasbin,
+		TenantModel: tenant,
+		UserModel:   user,
+		APIKeyModel: apiKey,
+	}
+	apiAPIKey := &api.APIKey{
+		APIKeySrv: serviceAPIKey,
+	}
+	auditLog := &repo.AuditLog{
+		DB: db,
+	}
+	serviceAuditLog := &service.AuditLog{
+		AuditLogModel: auditLog,
+	}
+	apiAuditLog := &api.AuditLog{
+		AuditLogSrv: serviceAuditLog,
+	}
 	routerRouter := &router.Router{
-		Auth:           auther,
-		CasbinEnforcer: syncedEnforcer,
-		DemoAPI:        apiDemo,
-		LoginAPI:       apiLogin,
-		MenuAPI:        apiMenu,
-		RoleAPI:        apiRole,
-		UserAPI:        apiUser,
+		Auth:             auther,
+		APIKeyAPI:        apiAPIKey,
+		APIKeySrv:        serviceAPIKey,
+		AuditLogAPI:      apiAuditLog,
+		CasbinEnforcer:   syncedEnforcer,
+		DataScopeSrv:     dataScope,
+		DemoAPI:          apiDemo,
+		DeptAPI:          apiDept,
+		JWKSAPI:          jwks,
+		LockoutAPI:       apiLockout,
+		LoginAPI:         apiLogin,
+		MenuAPI:          apiMenu,
+		MFAAPI:           apiMFA,
+		PasswordResetAPI: apiPasswordReset,
+		PermissionAPI:    apiPermission,
+		RoleAPI:          apiRole,
+		SessionAPI:       apiSession,
+		TenantAPI:        apiTenant,
+		TenantSrv:        serviceTenant,
+		UserAPI:          apiUser,
+		UserRoleAPI:      apiUserRole,
+		UserSrv:          serviceUser,
 	}
 	engine := InitGinEngine(routerRouter)
 	injector := &Injector{
@@ -128,8 +366,16 @@ func BuildInjector() (*Injector, func(), error) {
 		Auth:           auther,
 		CasbinEnforcer: syncedEnforcer,
 		MenuBll:        serviceMenu,
+		TenantBll:      serviceTenant,
+		UserBll:        serviceUser,
+		UserRoleBll:    serviceUserRole,
+		RecycleBinBll:  recycleBin,
 	}
 	return injector, func() {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
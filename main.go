@@ -4,6 +4,7 @@ import (
 	"context"
 	"ginAdmin/internal/app"
 	"ginAdmin/pkg/logger"
+	"os"
 )
 
 // VERSION 版本号
@@ -12,11 +13,26 @@ var VERSION = "1.0.0"
 func main() {
 	logger.SetVersion(VERSION)
 	ctx := logger.NewTagContext(context.Background(), "__main__")
-	app.Run(ctx,
+
+	// 数据库迁移：./ginAdmin migrate up [n] | down [n] | status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := app.Migrate(ctx, os.Args[2:], app.SetConfigFile("./configs/config.toml"))
+		if err != nil {
+			logger.WithContext(ctx).Errorf(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	err := app.Run(ctx,
 		app.SetConfigFile("./configs/config.toml"),
 		app.SetModelFile("./configs/model.conf"),
 		app.SetWWWDir("www"),
 		app.SetMenuFile("./configs/menu.yaml"),
 		app.SetVersion(VERSION),
 	)
+	if err != nil {
+		logger.WithContext(ctx).Errorf(err.Error())
+		os.Exit(1)
+	}
 }
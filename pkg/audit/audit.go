@@ -0,0 +1,318 @@
+package audit
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// 变更类型
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// 脱敏字段的记录值
+const maskValue = "******"
+
+// 变更前数据在语句中的存储键
+const oldRowsKey = "audit:old_rows"
+
+// Change 字段变更(新增时Old为空，删除时New为空)
+type Change struct {
+	Column string      `json:"column"`
+	Old    interface{} `json:"old"`
+	New    interface{} `json:"new"`
+}
+
+// Record 数据变更记录
+type Record struct {
+	Table    string                 // 数据表
+	RecordID string                 // 主键(联合主键以逗号分隔)
+	Action   string                 // 变更类型(create/update/delete)
+	Changes  []*Change              // 字段变更(按字段名排序)
+	Row      map[string]interface{} // 变更后的数据(硬删除时为变更前的数据，未脱敏，用于写入时读取租户等字段)
+}
+
+// WriteFunc 写入变更记录(db与数据变更使用相同的连接及上下文，处于同一事务中)
+type WriteFunc func(db *gorm.DB, records []*Record) error
+
+// Config 配置参数
+type Config struct {
+	IgnoreTables []string // 不记录变更的数据表(完整表名)
+	MaskColumns  []string // 脱敏字段(只记录是否变更，不记录值)
+	Write        WriteFunc
+}
+
+// New 创建数据变更审计插件
+func New(cfg Config) *Plugin {
+	p := &Plugin{
+		write:        cfg.Write,
+		ignoreTables: make(map[string]bool),
+		maskColumns:  make(map[string]bool),
+	}
+	for _, table := range cfg.IgnoreTables {
+		p.ignoreTables[table] = true
+	}
+	for _, column := range cfg.MaskColumns {
+		p.maskColumns[column] = true
+	}
+	return p
+}
+
+// Plugin 数据变更审计插件
+// 通过gorm回调在变更前后按主键查询数据并比较差异，变更记录在提交事务前写入，写入失败时数据变更将回滚
+type Plugin struct {
+	write        WriteFunc
+	ignoreTables map[string]bool
+	maskColumns  map[string]bool
+}
+
+// Name 插件名称
+func (p *Plugin) Name() string {
+	return "audit"
+}
+
+// Initialize 注册回调
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	const commit = "gorm:commit_or_rollback_transaction"
+
+	err := db.Callback().Create().After("gorm:after_create").Before(commit).Register("audit:after_create", p.afterCreate)
+	if err != nil {
+		return err
+	}
+
+	err = db.Callback().Update().After("gorm:before_update").Before("gorm:update").Register("audit:before_update", p.before)
+	if err != nil {
+		return err
+	}
+
+	err = db.Callback().Update().After("gorm:after_update").Before(commit).Register("audit:after_update", p.afterUpdate)
+	if err != nil {
+		return err
+	}
+
+	err = db.Callback().Delete().After("gorm:before_delete").Before("gorm:delete").Register("audit:before_delete", p.before)
+	if err != nil {
+		return err
+	}
+
+	return db.Callback().Delete().After("gorm:after_delete").Before(commit).Register("audit:after_delete", p.afterDelete)
+}
+
+func (p *Plugin) enabled(db *gorm.DB) bool {
+	stmt := db.Statement
+	return db.Error == nil && !db.DryRun && p.write != nil &&
+		stmt.Schema != nil && len(stmt.Schema.PrimaryFields) > 0 && !p.ignoreTables[stmt.Table]
+}
+
+// 记录新增的数据(按主键重新查询，包含数据库填充的默认值)
+func (p *Plugin) afterCreate(db *gorm.DB) {
+	if !p.enabled(db) {
+		return
+	}
+
+	stmt := db.Statement
+	_, values := schema.GetIdentityFieldValuesMap(stmt.ReflectValue, stmt.Schema.PrimaryFields)
+	if len(values) == 0 {
+		return
+	}
+
+	newRows, err := p.query(db, primaryKeyExpr(stmt.Schema, values))
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	p.record(db, ActionCreate, &rowSet{}, newRows)
+}
+
+// 变更前查询受影响的数据(条件为语句中的条件及模型中的主键)
+func (p *Plugin) before(db *gorm.DB) {
+	if !p.enabled(db) {
+		return
+	}
+
+	stmt := db.Statement
+	var exprs []clause.Expression
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			exprs = append(exprs, where.Exprs...)
+		}
+	}
+
+	if _, values := schema.GetIdentityFieldValuesMap(stmt.ReflectValue, stmt.Schema.PrimaryFields); len(values) > 0 {
+		exprs = append(exprs, primaryKeyExpr(stmt.Schema, values))
+	}
+
+	// 没有条件的语句将被gorm拒绝执行(允许全局更新时不记录)
+	if len(exprs) == 0 {
+		return
+	}
+
+	oldRows, err := p.query(db, exprs...)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	db.InstanceSet(oldRowsKey, oldRows)
+}
+
+func (p *Plugin) afterUpdate(db *gorm.DB) {
+	p.after(db, ActionUpdate)
+}
+
+func (p *Plugin) afterDelete(db *gorm.DB) {
+	p.after(db, ActionDelete)
+}
+
+// 变更后按主键重新查询数据(硬删除的数据查询不到，软删除的数据只记录删除时间的变化)
+func (p *Plugin) after(db *gorm.DB, action string) {
+	if !p.enabled(db) || db.RowsAffected == 0 {
+		return
+	}
+
+	v, ok := db.InstanceGet(oldRowsKey)
+	if !ok {
+		return
+	}
+
+	oldRows := v.(*rowSet)
+	if len(oldRows.keys) == 0 {
+		return
+	}
+
+	newRows, err := p.query(db, primaryKeyExpr(db.Statement.Schema, oldRows.values))
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	p.record(db, action, oldRows, newRows)
+}
+
+func (p *Plugin) record(db *gorm.DB, action string, oldRows, newRows *rowSet) {
+	keys := oldRows.keys
+	if action == ActionCreate {
+		keys = newRows.keys
+	}
+
+	var records []*Record
+	for _, key := range keys {
+		changes := p.diff(oldRows.rows[key], newRows.rows[key])
+		if len(changes) == 0 {
+			continue
+		}
+
+		row, ok := newRows.rows[key]
+		if !ok {
+			row = oldRows.rows[key]
+		}
+
+		records = append(records, &Record{
+			Table:    db.Statement.Table,
+			RecordID: key,
+			Action:   action,
+			Changes:  changes,
+			Row:      row,
+		})
+	}
+
+	if len(records) == 0 {
+		return
+	}
+
+	err := p.write(db.Session(&gorm.Session{NewDB: true}), records)
+	if err != nil {
+		db.AddError(fmt.Errorf("audit: %w", err))
+	}
+}
+
+// 比较变更前后的字段值
+func (p *Plugin) diff(oldRow, newRow map[string]interface{}) []*Change {
+	columns := make(map[string]bool, len(oldRow)+len(newRow))
+	for column := range oldRow {
+		columns[column] = true
+	}
+	for column := range newRow {
+		columns[column] = true
+	}
+
+	var changes []*Change
+	for column := range columns {
+		oldValue, newValue := oldRow[column], newRow[column]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		if p.maskColumns[column] {
+			oldValue, newValue = mask(oldValue), mask(newValue)
+		}
+		changes = append(changes, &Change{Column: column, Old: oldValue, New: newValue})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Column < changes[j].Column
+	})
+	return changes
+}
+
+func mask(v interface{}) interface{} {
+	if v == nil || v == "" {
+		return v
+	}
+	return maskValue
+}
+
+// 查询数据的当前值(不限定软删除的数据)
+func (p *Plugin) query(db *gorm.DB, exprs ...clause.Expression) (*rowSet, error) {
+	stmt := db.Statement
+
+	var rows []map[string]interface{}
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Model(reflect.New(stmt.Schema.ModelType).Interface()).
+		Table(stmt.Table).
+		Unscoped().
+		Clauses(clause.Where{Exprs: exprs}).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("audit: %w", err)
+	}
+
+	set := &rowSet{rows: make(map[string]map[string]interface{}, len(rows))}
+	for _, row := range rows {
+		values := make([]interface{}, len(stmt.Schema.PrimaryFields))
+		keys := make([]string, len(stmt.Schema.PrimaryFields))
+		for i, field := range stmt.Schema.PrimaryFields {
+			values[i] = row[field.DBName]
+			keys[i] = fmt.Sprint(values[i])
+		}
+
+		key := strings.Join(keys, ",")
+		if _, ok := set.rows[key]; ok {
+			continue
+		}
+		set.keys = append(set.keys, key)
+		set.values = append(set.values, values)
+		set.rows[key] = row
+	}
+	sort.Strings(set.keys)
+	return set, nil
+}
+
+// 按主键索引的数据
+type rowSet struct {
+	keys   []string
+	values [][]interface{}
+	rows   map[string]map[string]interface{}
+}
+
+func primaryKeyExpr(s *schema.Schema, values [][]interface{}) clause.Expression {
+	column, queryValues := schema.ToQueryValues(clause.CurrentTable, s.PrimaryFieldDBNames, values)
+	return clause.IN{Column: column, Values: queryValues}
+}
@@ -0,0 +1,193 @@
+package audit
+
+import (
+	"errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+type testItem struct {
+	ID        string `gorm:"primaryKey"`
+	Name      string
+	Secret    string
+	Version   int64 `gorm:"default:1"`
+	DeletedAt gorm.DeletedAt
+}
+
+type testLog struct {
+	ID       int64 `gorm:"primaryKey"`
+	RecordID string
+	Action   string
+}
+
+type testWriter struct {
+	records []*Record
+	err     error
+}
+
+func (w *testWriter) Write(db *gorm.DB, records []*Record) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	w.records = append(w.records, records...)
+	for _, item := range records {
+		err := db.Create(&testLog{RecordID: item.RecordID, Action: item.Action}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTestDB(t *testing.T, w *testWriter) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(new(testItem), new(testLog)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Use(New(Config{
+		IgnoreTables: []string{"test_logs"},
+		MaskColumns:  []string{"secret"},
+		Write:        w.Write,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func findChange(item *Record, column string) *Change {
+	for _, c := range item.Changes {
+		if c.Column == column {
+			return c
+		}
+	}
+	return nil
+}
+
+func countLogs(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	if err := db.Model(new(testLog)).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestPlugin(t *testing.T) {
+	w := new(testWriter)
+	db := newTestDB(t, w)
+
+	if err := db.Create(&testItem{ID: "a", Name: "foo", Secret: "s1"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(w.records) != 1 {
+		t.Fatalf("unexpected records: %d", len(w.records))
+	}
+	item := w.records[0]
+	if item.Table != "test_items" || item.RecordID != "a" || item.Action != ActionCreate {
+		t.Fatalf("unexpected record: %+v", item)
+	}
+	if item.Row["name"] != "foo" || item.Row["secret"] != "s1" {
+		t.Fatalf("unexpected row: %+v", item.Row)
+	}
+	if c := findChange(item, "version"); c == nil || c.Old != nil || c.New != int64(1) {
+		t.Fatalf("expected default value in create changes: %+v", c)
+	}
+	if c := findChange(item, "secret"); c == nil || c.New != maskValue {
+		t.Fatalf("expected masked value: %+v", c)
+	}
+
+	w.records = nil
+	result := db.Model(new(testItem)).Where("id=? AND version=?", "a", 1).Updates(map[string]interface{}{"name": "bar", "version": 2})
+	if result.Error != nil {
+		t.Fatal(result.Error)
+	}
+	if len(w.records) != 1 || w.records[0].Action != ActionUpdate || len(w.records[0].Changes) != 2 {
+		t.Fatalf("unexpected records: %+v", w.records)
+	}
+	if c := findChange(w.records[0], "name"); c.Old != "foo" || c.New != "bar" {
+		t.Fatalf("unexpected change: %+v", c)
+	}
+
+	// 未影响数据及未改变数据的更新不记录
+	w.records = nil
+	db.Model(new(testItem)).Where("id=? AND version=?", "a", 1).Update("name", "baz")
+	db.Model(new(testItem)).Where("id=?", "a").Update("name", "bar")
+	if len(w.records) != 0 {
+		t.Fatalf("unexpected records: %+v", w.records)
+	}
+
+	// 软删除只记录删除时间
+	if err := db.Where("id=?", "a").Delete(new(testItem)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(w.records) != 1 || w.records[0].Action != ActionDelete || len(w.records[0].Changes) != 1 || findChange(w.records[0], "deleted_at") == nil {
+		t.Fatalf("unexpected records: %+v", w.records)
+	}
+
+	w.records = nil
+	if err := db.Unscoped().Delete(&testItem{ID: "a"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(w.records) != 1 || findChange(w.records[0], "name").Old != "bar" || findChange(w.records[0], "name").New != nil {
+		t.Fatalf("unexpected records: %+v", w.records)
+	}
+	// 硬删除后查询不到数据，记录变更前的数据
+	if w.records[0].Row["name"] != "bar" {
+		t.Fatalf("unexpected row: %+v", w.records[0].Row)
+	}
+
+	if n := countLogs(t, db); n != 4 {
+		t.Fatalf("unexpected logs: %d", n)
+	}
+}
+
+func TestPluginRollback(t *testing.T) {
+	w := new(testWriter)
+	db := newTestDB(t, w)
+
+	if err := db.Create(&testItem{ID: "a", Name: "foo"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	w.err = errors.New("write failed")
+	if err := db.Model(new(testItem)).Where("id=?", "a").Update("name", "bar").Error; err == nil {
+		t.Fatal("expected write error")
+	}
+
+	var item testItem
+	if err := db.Where("id=?", "a").First(&item).Error; err != nil {
+		t.Fatal(err)
+	} else if item.Name != "foo" {
+		t.Fatalf("expected update to be rolled back: %s", item.Name)
+	}
+
+	// 外部事务中变更记录与数据一起回滚
+	w.err = nil
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&testItem{ID: "b", Name: "foo"}).Error; err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	if err == nil {
+		t.Fatal("expected rollback")
+	}
+
+	if n := countLogs(t, db); n != 1 {
+		t.Fatalf("unexpected logs: %d", n)
+	}
+}
@@ -0,0 +1,67 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Prefix API密钥的固定前缀(用于与JWT令牌区分)
+const Prefix = "gak_"
+
+// 可见前缀的随机字节数(编码后为12位十六进制)
+const keyIDBytes = 6
+
+// 密钥的随机字节数
+const secretBytes = 32
+
+// Generate 生成API密钥，返回完整密钥及可见前缀(完整密钥只在创建时返回一次)
+// 密钥格式：gak_<可见前缀>_<密钥>
+func Generate() (token, keyPrefix string, err error) {
+	id := make([]byte, keyIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	keyPrefix = Prefix + hex.EncodeToString(id)
+	token = keyPrefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return token, keyPrefix, nil
+}
+
+// IsAPIKey 是否是API密钥格式
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
+
+// ParsePrefix 解析密钥的可见前缀(格式无效时返回false)
+func ParsePrefix(token string) (string, bool) {
+	if !IsAPIKey(token) {
+		return "", false
+	}
+
+	//	密钥部分使用base64url编码，可能包含下划线，因此按可见前缀的固定长度截取
+	n := len(Prefix) + keyIDBytes*2
+	if len(token) <= n+1 || token[n] != '_' {
+		return "", false
+	}
+	return token[:n], true
+}
+
+// Hash 计算密钥的哈希值(sha256)
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify 校验密钥与哈希值是否匹配
+func Verify(token, tokenHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(token)), []byte(tokenHash)) == 1
+}
@@ -0,0 +1,47 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	token, keyPrefix, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsAPIKey(token) || !strings.HasPrefix(token, keyPrefix+"_") {
+		t.Fatalf("unexpected token: %s", token)
+	}
+
+	p, ok := ParsePrefix(token)
+	if !ok || p != keyPrefix {
+		t.Fatalf("unexpected prefix: %s", p)
+	}
+
+	if !Verify(token, Hash(token)) {
+		t.Fatal("expected token to match its hash")
+	}
+
+	other, _, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	} else if Verify(other, Hash(token)) {
+		t.Fatal("expected another token not to match")
+	}
+}
+
+func TestParsePrefix(t *testing.T) {
+	for _, token := range []string{
+		"",
+		"eyJhbGciOiJIUzUxMiIsInR5cCI6IkpXVCJ9.e30.sig",
+		"gak_",
+		"gak_abc_secret",
+		"gak_0123456789ab_",
+	} {
+		if _, ok := ParsePrefix(token); ok {
+			t.Fatalf("expected invalid token: %q", token)
+		}
+	}
+}
@@ -7,13 +7,16 @@ import (
 
 // 定义错误
 var (
-	ErrInvalidToken = errors.New("invalid token")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrRefreshTokenReused = errors.New("refresh token reused")
 )
 
 // TokenInfo 令牌信息
 type TokenInfo interface {
 	//	获取访问令牌
 	GetAccessToken() string
+	//	获取刷新令牌
+	GetRefreshToken() string
 	//	获取令牌类型
 	GetTokenType() string
 	// 获取令牌到期时间
@@ -22,10 +25,55 @@ type TokenInfo interface {
 	EncodeToJSON() ([]byte, error)
 }
 
+// ClientInfo 客户端信息(用于登记会话)
+type ClientInfo struct {
+	IP        string // 客户端IP
+	UserAgent string // 客户端标识
+}
+
+// Session 会话信息(每次登录生成一个会话，会话ID即令牌族ID)
+type Session struct {
+	ID        string `json:"id"`         // 会话ID
+	UserID    string `json:"user_id"`    // 用户ID
+	IP        string `json:"ip"`         // 登录IP
+	UserAgent string `json:"user_agent"` // 客户端标识
+	IssuedAt  int64  `json:"issued_at"`  // 登录时间(时间戳)
+	ActorID   string `json:"actor_id"`   // 模拟登录的实际操作者ID(被模拟用户的会话)
+	TargetID  string `json:"target_id"`  // 模拟登录的目标用户ID(实际操作者的会话)
+}
+
+type tenantCtx struct{}
+
+// NewTenantContext 设定签发令牌时写入的租户ID
+func NewTenantContext(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantCtx{}, tenantID)
+}
+
+// FromTenantContext 获取签发令牌时写入的租户ID
+func FromTenantContext(ctx context.Context) string {
+	v, _ := ctx.Value(tenantCtx{}).(string)
+	return v
+}
+
+// Identity 访问令牌中的身份信息
+type Identity struct {
+	UserID   string // 用户ID
+	TenantID string // 租户ID(签发令牌时未指定租户则为空)
+	ActorID  string // 实际操作者ID(模拟登录时为管理员ID，否则为空)
+}
+
+// IsImpersonated 是否是模拟登录
+func (a *Identity) IsImpersonated() bool {
+	return a.ActorID != ""
+}
+
 // Auther 认证接口
 type Auther interface {
-	// 生成令牌
-	GenerateToken(ctx context.Context, userId string) (TokenInfo, error)
+	// 生成令牌(同时登记一个新的会话，租户ID通过NewTenantContext指定)
+	GenerateToken(ctx context.Context, userID string, client ClientInfo) (TokenInfo, error)
+
+	//	刷新令牌(刷新令牌仅能使用一次，返回新的令牌及用户ID)
+	RefreshToken(ctx context.Context, refreshToken string) (TokenInfo, string, error)
 
 	//	销毁令牌
 	DestroyToken(ctx context.Context, accessToken string) error
@@ -33,6 +81,27 @@ type Auther interface {
 	//	解析用户ID
 	ParseUserID(ctx context.Context, accessToken string) (string, error)
 
+	//	解析访问令牌中的身份信息(包括模拟登录的实际操作者)
+	ParseIdentity(ctx context.Context, accessToken string) (*Identity, error)
+
+	//	生成模拟登录令牌(令牌同时携带被模拟的用户ID及实际操作者ID，不提供刷新令牌；会话同时登记在双方名下，撤销任一方的会话即失效)
+	GenerateImpersonationToken(ctx context.Context, userID, actorID string, client ClientInfo) (TokenInfo, error)
+
+	//	生成指定用途的挑战令牌(用于完成登录的后续步骤，例如两步验证，返回令牌及到期时间)
+	GenerateChallengeToken(ctx context.Context, userID, purpose string) (string, int64, error)
+
+	//	解析指定用途的挑战令牌中的用户ID(使用后应通过DestroyToken销毁)
+	ParseChallengeToken(ctx context.Context, challengeToken, purpose string) (string, error)
+
+	//	查询用户的有效会话列表
+	QuerySessions(ctx context.Context, userID string) ([]*Session, error)
+
+	//	撤销用户的指定会话
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+
+	//	撤销用户的所有会话
+	RevokeSessions(ctx context.Context, userID string) error
+
 	//	释放资源
 	Release() error
 }
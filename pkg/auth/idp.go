@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+)
+
+// ExternalIdentity 外部身份提供者认证后的用户身份
+type ExternalIdentity struct {
+	Provider      string // 身份提供者名称
+	Subject       string // 身份提供者中的用户唯一标识
+	UserName      string // 用户名(例如preferred_username)
+	Name          string // 显示名称
+	Email         string // 邮箱
+	EmailVerified bool   // 邮箱是否已验证
+}
+
+// IdentityProvider 外部身份提供者
+type IdentityProvider interface {
+	//	身份提供者名称
+	Name() string
+
+	//	生成授权地址(codeVerifier为PKCE校验码，nonce用于校验身份令牌)
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+
+	//	使用授权码换取用户身份
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*ExternalIdentity, error)
+}
+
+// IdentityProviders 外部身份提供者集合(以名称为键)
+type IdentityProviders map[string]IdentityProvider
@@ -3,27 +3,42 @@ package jwtauth
 import (
 	"context"
 	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/util/uuid"
 	jwt "github.com/dgrijalva/jwt-go"
+	"sort"
 	"time"
 )
 
 const defaultKey = "ginAdmin"
 
+// 令牌用途
+const (
+	accessTokenUse  = "access"
+	refreshTokenUse = "refresh"
+)
+
 type options struct {
-	tokenType     string
-	signingMethod jwt.SigningMethod
-	signingKey    interface{}
-	keyfunc       jwt.Keyfunc
-	expired       int
+	tokenType            string
+	signingMethod        jwt.SigningMethod
+	signingKey           interface{}
+	signingKeyID         string
+	keyfunc              jwt.Keyfunc
+	expired              int
+	refreshExpired       int
+	challengeExpired     int
+	impersonationExpired int
 }
 
 var defaultOptions = options{
-	tokenType:     "Bearer",
-	expired:       7200,
-	signingMethod: jwt.SigningMethodHS512,
-	signingKey:    []byte(defaultKey),
+	tokenType:            "Bearer",
+	expired:              7200,
+	refreshExpired:       604800,
+	challengeExpired:     300,
+	impersonationExpired: 900,
+	signingMethod:        jwt.SigningMethodHS512,
+	signingKey:           []byte(defaultKey),
 	keyfunc: func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, auth.ErrInvalidToken
 		}
 		return []byte(defaultKey), nil
@@ -47,6 +62,17 @@ func SetSigningKey(key interface{}) Option {
 	}
 }
 
+// SetKeySet 设定密钥集合(使用其中的签名密钥签名，并根据kid选择验签密钥)
+func SetKeySet(ks *KeySet) Option {
+	return func(o *options) {
+		key := ks.SigningKey()
+		o.signingMethod = key.Method
+		o.signingKey = key.PrivateKey
+		o.signingKeyID = key.ID
+		o.keyfunc = ks.Keyfunc
+	}
+}
+
 // SetKeyFunc 设置验证key的回调函数
 func SetKeyFunc(keyFunc jwt.Keyfunc) Option {
 	return func(o *options) {
@@ -61,6 +87,27 @@ func SetExpired(expired int) Option {
 	}
 }
 
+// SetRefreshExpired 设定刷新令牌过期时长(单位秒，默认604800)
+func SetRefreshExpired(expired int) Option {
+	return func(o *options) {
+		o.refreshExpired = expired
+	}
+}
+
+// SetChallengeExpired 设定挑战令牌过期时长(单位秒，默认300)
+func SetChallengeExpired(expired int) Option {
+	return func(o *options) {
+		o.challengeExpired = expired
+	}
+}
+
+// SetImpersonationExpired 设定模拟登录令牌过期时长(单位秒，默认900)
+func SetImpersonationExpired(expired int) Option {
+	return func(o *options) {
+		o.impersonationExpired = expired
+	}
+}
+
 // New 创建认证实例
 func New(store Storer, opts ...Option) *JWTAuth {
 	o := defaultOptions
@@ -80,42 +127,118 @@ type JWTAuth struct {
 	store Storer
 }
 
+// tokenClaims 令牌声明
+type tokenClaims struct {
+	jwt.StandardClaims
+	Use      string       `json:"use,omitempty"` // 令牌用途(access/refresh)
+	FamilyID string       `json:"fid,omitempty"` // 令牌族ID(同一次登录轮换出的令牌属于同一个令牌族)
+	Tenant   string       `json:"tid,omitempty"` // 租户ID
+	Actor    *actorClaims `json:"act,omitempty"` // 实际操作者(模拟登录时存在，参考RFC 8693)
+}
+
+// actorClaims 实际操作者声明
+type actorClaims struct {
+	Subject string `json:"sub"`
+}
+
+func (a *JWTAuth) refreshExpiration() time.Duration {
+	return time.Duration(a.opts.refreshExpired) * time.Second
+}
+
 // GenerateToken 生成令牌
-func (a *JWTAuth) GenerateToken(ctx context.Context, userID string) (auth.TokenInfo, error) {
+func (a *JWTAuth) GenerateToken(ctx context.Context, userID string, client auth.ClientInfo) (auth.TokenInfo, error) {
+	familyID := uuid.MustString()
+	refreshID := uuid.MustString()
+
+	err := a.callStore(func(store Storer) error {
+		if err := store.SetFamily(ctx, familyID, refreshID, a.refreshExpiration()); err != nil {
+			return err
+		}
+
+		//	顺带清理该用户已失效的会话
+		if _, err := a.querySessions(ctx, store, userID); err != nil {
+			return err
+		}
+
+		return store.SetSession(ctx, &auth.Session{
+			ID:        familyID,
+			UserID:    userID,
+			IP:        client.IP,
+			UserAgent: client.UserAgent,
+			IssuedAt:  time.Now().Unix(),
+		}, a.refreshExpiration())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.generateToken(userID, auth.FromTenantContext(ctx), familyID, refreshID)
+}
+
+// 生成访问令牌及刷新令牌
+func (a *JWTAuth) generateToken(userID, tenantID, familyID, refreshID string) (*tokenInfo, error) {
 	now := time.Now()
 	expiresAt := now.Add(time.Duration(a.opts.expired) * time.Second).Unix()
 
-	token := jwt.NewWithClaims(a.opts.signingMethod, &jwt.StandardClaims{
-		IssuedAt:  now.Unix(),
-		ExpiresAt: expiresAt,
-		NotBefore: now.Unix(),
-		Subject:   userID,
+	accessToken, err := a.signToken(&tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt,
+			NotBefore: now.Unix(),
+			Subject:   userID,
+		},
+		Use:      accessTokenUse,
+		FamilyID: familyID,
+		Tenant:   tenantID,
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	tokenString, err := token.SignedString(a.opts.signingKey)
+	refreshToken, err := a.signToken(&tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        refreshID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(a.refreshExpiration()).Unix(),
+			NotBefore: now.Unix(),
+			Subject:   userID,
+		},
+		Use:      refreshTokenUse,
+		FamilyID: familyID,
+		Tenant:   tenantID,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	tokenInfo := &tokenInfo{
-		ExpiresAt:   expiresAt,
-		TokenType:   a.opts.tokenType,
-		AccessToken: tokenString,
+		ExpiresAt:    expiresAt,
+		TokenType:    a.opts.tokenType,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 	}
-
 	return tokenInfo, nil
 }
 
+// 签名令牌
+func (a *JWTAuth) signToken(claims *tokenClaims) (string, error) {
+	token := jwt.NewWithClaims(a.opts.signingMethod, claims)
+	if kid := a.opts.signingKeyID; kid != "" {
+		token.Header["kid"] = kid
+	}
+	return token.SignedString(a.opts.signingKey)
+}
+
 // 解析令牌
-func (a *JWTAuth) parseToken(tokenString string) (*jwt.StandardClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, a.opts.keyfunc)
+func (a *JWTAuth) parseToken(tokenString string) (*tokenClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &tokenClaims{}, a.opts.keyfunc)
 	if err != nil {
 		return nil, err
 	} else if !token.Valid {
 		return nil, auth.ErrInvalidToken
 	}
 
-	return token.Claims.(*jwt.StandardClaims), nil
+	return token.Claims.(*tokenClaims), nil
 }
 
 func (a *JWTAuth) callStore(fn func(Storer) error) error {
@@ -125,6 +248,51 @@ func (a *JWTAuth) callStore(fn func(Storer) error) error {
 	return nil
 }
 
+// RefreshToken 刷新令牌
+func (a *JWTAuth) RefreshToken(ctx context.Context, refreshToken string) (auth.TokenInfo, string, error) {
+	if refreshToken == "" {
+		return nil, "", auth.ErrInvalidToken
+	}
+
+	claims, err := a.parseToken(refreshToken)
+	if err != nil {
+		return nil, "", auth.ErrInvalidToken
+	} else if claims.Use != refreshTokenUse || claims.FamilyID == "" {
+		return nil, "", auth.ErrInvalidToken
+	}
+
+	//	没有储存时无法轮换及检测重复使用，拒绝刷新
+	if a.store == nil {
+		return nil, "", ErrNoStore
+	}
+
+	refreshID := uuid.MustString()
+	err = a.callStore(func(store Storer) error {
+		exists, rotated, err := store.RotateFamily(ctx, claims.FamilyID, claims.Id, refreshID, a.refreshExpiration())
+		if err != nil {
+			return err
+		} else if !exists {
+			return auth.ErrInvalidToken
+		} else if !rotated {
+			//	刷新令牌已被使用过，说明令牌可能已泄露，撤销整个令牌族
+			if err := store.DeleteFamily(ctx, claims.FamilyID); err != nil {
+				return err
+			}
+			return auth.ErrRefreshTokenReused
+		}
+		return store.ExpireSession(ctx, claims.Subject, claims.FamilyID, a.refreshExpiration())
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	tokenInfo, err := a.generateToken(claims.Subject, claims.Tenant, claims.FamilyID, refreshID)
+	if err != nil {
+		return nil, "", err
+	}
+	return tokenInfo, claims.Subject, nil
+}
+
 // DestroyToken 销毁令牌
 func (a *JWTAuth) DestroyToken(ctx context.Context, tokenString string) error {
 	claims, err := a.parseToken(tokenString)
@@ -132,22 +300,170 @@ func (a *JWTAuth) DestroyToken(ctx context.Context, tokenString string) error {
 		return err
 	}
 
-	//	如果设定了存储，则将未过期的令牌放入
+	//	如果设定了存储，则将未过期的令牌放入，并撤销其所属的令牌族
 	return a.callStore(func(store Storer) error {
 		expired := time.Unix(claims.ExpiresAt, 0).Sub(time.Now())
-		return store.Set(ctx, tokenString, expired)
+		if err := store.Set(ctx, tokenString, expired); err != nil {
+			return err
+		}
+
+		if claims.FamilyID != "" {
+			return a.revokeSession(ctx, store, claims.Subject, claims.FamilyID)
+		}
+		return nil
 	})
 }
 
 // ParseUserID 解析用户ID
 func (a *JWTAuth) ParseUserID(ctx context.Context, tokenString string) (string, error) {
+	identity, err := a.ParseIdentity(ctx, tokenString)
+	if err != nil {
+		return "", err
+	}
+	return identity.UserID, nil
+}
+
+// ParseIdentity 解析访问令牌中的身份信息
+func (a *JWTAuth) ParseIdentity(ctx context.Context, tokenString string) (*auth.Identity, error) {
 	if tokenString == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	claims, err := a.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	} else if claims.Use != "" && claims.Use != accessTokenUse {
+		return nil, auth.ErrInvalidToken
+	}
+
+	err = a.callStore(func(store Storer) error {
+		if exists, err := store.Check(ctx, tokenString); err != nil {
+			return err
+		} else if exists {
+			return auth.ErrInvalidToken
+		}
+
+		if claims.FamilyID != "" {
+			if exists, err := store.CheckFamily(ctx, claims.FamilyID); err != nil {
+				return err
+			} else if !exists {
+				return auth.ErrInvalidToken
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &auth.Identity{UserID: claims.Subject, TenantID: claims.Tenant}
+	if claims.Actor != nil {
+		identity.ActorID = claims.Actor.Subject
+	}
+	return identity, nil
+}
+
+// GenerateImpersonationToken 生成模拟登录令牌(令牌属于独立的令牌族，会话同时登记在被模拟用户及实际操作者名下)
+func (a *JWTAuth) GenerateImpersonationToken(ctx context.Context, userID, actorID string, client auth.ClientInfo) (auth.TokenInfo, error) {
+	if userID == "" || actorID == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	//	没有储存时模拟登录令牌无法随用户禁用或下线撤销，拒绝签发
+	if a.store == nil {
+		return nil, ErrNoStore
+	}
+
+	now := time.Now()
+	expiration := time.Duration(a.opts.impersonationExpired) * time.Second
+	expiresAt := now.Add(expiration).Unix()
+	familyID := uuid.MustString()
+	tokenID := uuid.MustString()
+
+	err := a.callStore(func(store Storer) error {
+		if err := store.SetFamily(ctx, familyID, tokenID, expiration); err != nil {
+			return err
+		}
+
+		//	撤销被模拟用户或实际操作者的会话时均会删除该令牌族
+		session := auth.Session{
+			ID:        familyID,
+			IP:        client.IP,
+			UserAgent: client.UserAgent,
+			IssuedAt:  now.Unix(),
+		}
+		target, actor := session, session
+		target.UserID, target.ActorID = userID, actorID
+		actor.UserID, actor.TargetID = actorID, userID
+		if err := store.SetSession(ctx, &target, expiration); err != nil {
+			return err
+		}
+		return store.SetSession(ctx, &actor, expiration)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := a.signToken(&tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        tokenID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt,
+			NotBefore: now.Unix(),
+			Subject:   userID,
+		},
+		Use:      accessTokenUse,
+		FamilyID: familyID,
+		Tenant:   auth.FromTenantContext(ctx),
+		Actor:    &actorClaims{Subject: actorID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenInfo{
+		AccessToken: accessToken,
+		TokenType:   a.opts.tokenType,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// GenerateChallengeToken 生成挑战令牌(用途不能与访问令牌及刷新令牌相同)
+func (a *JWTAuth) GenerateChallengeToken(ctx context.Context, userID, purpose string) (string, int64, error) {
+	if !isChallengeUse(purpose) {
+		return "", 0, auth.ErrInvalidToken
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(a.opts.challengeExpired) * time.Second).Unix()
+
+	tokenString, err := a.signToken(&tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.MustString(),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt,
+			NotBefore: now.Unix(),
+			Subject:   userID,
+		},
+		Use: purpose,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return tokenString, expiresAt, nil
+}
+
+// ParseChallengeToken 解析挑战令牌中的用户ID
+func (a *JWTAuth) ParseChallengeToken(ctx context.Context, tokenString, purpose string) (string, error) {
+	if tokenString == "" || !isChallengeUse(purpose) {
 		return "", auth.ErrInvalidToken
 	}
 
 	claims, err := a.parseToken(tokenString)
 	if err != nil {
-		return "", err
+		return "", auth.ErrInvalidToken
+	} else if claims.Use != purpose {
+		return "", auth.ErrInvalidToken
 	}
 
 	err = a.callStore(func(store Storer) error {
@@ -164,6 +480,80 @@ func (a *JWTAuth) ParseUserID(ctx context.Context, tokenString string) (string,
 	return claims.Subject, nil
 }
 
+func isChallengeUse(purpose string) bool {
+	return purpose != "" && purpose != accessTokenUse && purpose != refreshTokenUse
+}
+
+// 查询用户的会话列表，并清理令牌族已失效的会话
+func (a *JWTAuth) querySessions(ctx context.Context, store Storer, userID string) ([]*auth.Session, error) {
+	sessions, err := store.QuerySessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*auth.Session, 0, len(sessions))
+	for _, item := range sessions {
+		exists, err := store.CheckFamily(ctx, item.ID)
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			if err := store.DeleteSession(ctx, userID, item.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		list = append(list, item)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].IssuedAt > list[j].IssuedAt
+	})
+	return list, nil
+}
+
+// 撤销会话(删除令牌族及会话信息)
+func (a *JWTAuth) revokeSession(ctx context.Context, store Storer, userID, sessionID string) error {
+	if err := store.DeleteFamily(ctx, sessionID); err != nil {
+		return err
+	}
+	return store.DeleteSession(ctx, userID, sessionID)
+}
+
+// QuerySessions 查询用户的有效会话列表
+func (a *JWTAuth) QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error) {
+	var list []*auth.Session
+	err := a.callStore(func(store Storer) error {
+		sessions, err := a.querySessions(ctx, store, userID)
+		list = sessions
+		return err
+	})
+	return list, err
+}
+
+// RevokeSession 撤销用户的指定会话
+func (a *JWTAuth) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	return a.callStore(func(store Storer) error {
+		return a.revokeSession(ctx, store, userID, sessionID)
+	})
+}
+
+// RevokeSessions 撤销用户的所有会话
+func (a *JWTAuth) RevokeSessions(ctx context.Context, userID string) error {
+	return a.callStore(func(store Storer) error {
+		sessions, err := store.QuerySessions(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range sessions {
+			if err := a.revokeSession(ctx, store, userID, item.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // Release 释放资源
 func (a *JWTAuth) Release() error {
 	return a.callStore(func(store Storer) error {
@@ -0,0 +1,243 @@
+package jwtauth
+
+import (
+	"context"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/jwtauth/store/memory"
+	"testing"
+)
+
+func TestRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	a := New(memory.NewStore(0))
+
+	first, err := a.GenerateToken(ctx, "user1", auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.ParseUserID(ctx, first.GetRefreshToken()); err != auth.ErrInvalidToken {
+		t.Fatalf("refresh token must not be accepted as access token, got %v", err)
+	}
+
+	second, userID, err := a.RefreshToken(ctx, first.GetRefreshToken())
+	if err != nil {
+		t.Fatal(err)
+	} else if userID != "user1" {
+		t.Fatalf("unexpected user id: %s", userID)
+	}
+
+	if _, _, err := a.RefreshToken(ctx, first.GetRefreshToken()); err != auth.ErrRefreshTokenReused {
+		t.Fatalf("expected reuse detection, got %v", err)
+	}
+
+	// 重复使用后整个令牌族都被撤销
+	if _, _, err := a.RefreshToken(ctx, second.GetRefreshToken()); err != auth.ErrInvalidToken {
+		t.Fatalf("expected revoked family, got %v", err)
+	}
+	if _, err := a.ParseUserID(ctx, second.GetAccessToken()); err != auth.ErrInvalidToken {
+		t.Fatalf("expected revoked access token, got %v", err)
+	}
+}
+
+func TestRefreshTokenWithoutStore(t *testing.T) {
+	ctx := context.Background()
+	a := New(nil)
+
+	tokenInfo, err := a.GenerateToken(ctx, "user1", auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := a.RefreshToken(ctx, tokenInfo.GetRefreshToken()); err != ErrNoStore {
+		t.Fatalf("expected ErrNoStore, got %v", err)
+	}
+}
+
+func TestDestroyTokenRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	a := New(memory.NewStore(0))
+
+	tokenInfo, err := a.GenerateToken(ctx, "user1", auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.DestroyToken(ctx, tokenInfo.GetAccessToken()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := a.RefreshToken(ctx, tokenInfo.GetRefreshToken()); err != auth.ErrInvalidToken {
+		t.Fatalf("expected invalid refresh token after logout, got %v", err)
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	ctx := context.Background()
+	a := New(memory.NewStore(0))
+
+	client := auth.ClientInfo{IP: "127.0.0.1", UserAgent: "test"}
+	first, err := a.GenerateToken(ctx, "user1", client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := a.GenerateToken(ctx, "user1", client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := a.QuerySessions(ctx, "user1")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	} else if sessions[0].IP != client.IP || sessions[0].UserAgent != client.UserAgent {
+		t.Fatalf("unexpected session: %+v", sessions[0])
+	}
+
+	// 登出后会话不再出现在列表中
+	if err := a.DestroyToken(ctx, first.GetAccessToken()); err != nil {
+		t.Fatal(err)
+	}
+	if sessions, err = a.QuerySessions(ctx, "user1"); err != nil {
+		t.Fatal(err)
+	} else if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+
+	if err := a.RevokeSessions(ctx, "user1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.ParseUserID(ctx, second.GetAccessToken()); err != auth.ErrInvalidToken {
+		t.Fatalf("expected revoked access token, got %v", err)
+	}
+	if sessions, err = a.QuerySessions(ctx, "user1"); err != nil {
+		t.Fatal(err)
+	} else if len(sessions) != 0 {
+		t.Fatalf("expected no sessions, got %d", len(sessions))
+	}
+}
+
+func TestChallengeToken(t *testing.T) {
+	ctx := context.Background()
+	a := New(memory.NewStore(0))
+
+	challenge, _, err := a.GenerateChallengeToken(ctx, "user1", "mfa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.ParseUserID(ctx, challenge); err != auth.ErrInvalidToken {
+		t.Fatalf("challenge token must not be accepted as access token, got %v", err)
+	}
+
+	if _, err := a.ParseChallengeToken(ctx, challenge, "password"); err != auth.ErrInvalidToken {
+		t.Fatalf("challenge token must not be accepted for another purpose, got %v", err)
+	}
+
+	userID, err := a.ParseChallengeToken(ctx, challenge, "mfa")
+	if err != nil {
+		t.Fatal(err)
+	} else if userID != "user1" {
+		t.Fatalf("unexpected user id: %s", userID)
+	}
+
+	// 挑战令牌销毁后不能再次使用
+	if err := a.DestroyToken(ctx, challenge); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.ParseChallengeToken(ctx, challenge, "mfa"); err != auth.ErrInvalidToken {
+		t.Fatalf("expected used challenge token to be rejected, got %v", err)
+	}
+}
+
+func TestImpersonationToken(t *testing.T) {
+	ctx := context.Background()
+	a := New(memory.NewStore(0))
+
+	tokenInfo, err := a.GenerateImpersonationToken(ctx, "user1", "admin1", auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	} else if tokenInfo.GetRefreshToken() != "" {
+		t.Fatal("impersonation token must not have a refresh token")
+	}
+
+	identity, err := a.ParseIdentity(ctx, tokenInfo.GetAccessToken())
+	if err != nil {
+		t.Fatal(err)
+	} else if identity.UserID != "user1" || identity.ActorID != "admin1" || !identity.IsImpersonated() {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	// 普通访问令牌不包含实际操作者
+	normal, err := a.GenerateToken(ctx, "user1", auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity, err = a.ParseIdentity(ctx, normal.GetAccessToken()); err != nil {
+		t.Fatal(err)
+	} else if identity.IsImpersonated() {
+		t.Fatalf("unexpected actor: %s", identity.ActorID)
+	}
+
+	if err := a.DestroyToken(ctx, tokenInfo.GetAccessToken()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.ParseIdentity(ctx, tokenInfo.GetAccessToken()); err != auth.ErrInvalidToken {
+		t.Fatalf("expected destroyed token to be rejected, got %v", err)
+	}
+
+	// 会话同时登记在双方名下，撤销任一方的会话即失效
+	for _, userID := range []string{"user1", "admin1"} {
+		tokenInfo, err := a.GenerateImpersonationToken(ctx, "user1", "admin1", auth.ClientInfo{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sessions, err := a.QuerySessions(ctx, "admin1")
+		if err != nil {
+			t.Fatal(err)
+		} else if len(sessions) != 1 || sessions[0].TargetID != "user1" {
+			t.Fatalf("unexpected actor sessions: %+v", sessions)
+		}
+
+		if err := a.RevokeSessions(ctx, userID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := a.ParseIdentity(ctx, tokenInfo.GetAccessToken()); err != auth.ErrInvalidToken {
+			t.Fatalf("expected token to be revoked with %s, got %v", userID, err)
+		}
+	}
+
+	if _, err := New(nil).GenerateImpersonationToken(ctx, "user1", "admin1", auth.ClientInfo{}); err != ErrNoStore {
+		t.Fatalf("expected ErrNoStore, got %v", err)
+	}
+}
+
+func TestTenantClaim(t *testing.T) {
+	ctx := auth.NewTenantContext(context.Background(), "tenant1")
+	a := New(memory.NewStore(0))
+
+	tokenInfo, err := a.GenerateToken(ctx, "user1", auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := a.ParseIdentity(context.Background(), tokenInfo.GetAccessToken())
+	if err != nil {
+		t.Fatal(err)
+	} else if identity.TenantID != "tenant1" {
+		t.Fatalf("unexpected tenant: %s", identity.TenantID)
+	}
+
+	// 刷新后的令牌保留原租户
+	refreshed, _, err := a.RefreshToken(context.Background(), tokenInfo.GetRefreshToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity, err = a.ParseIdentity(context.Background(), refreshed.GetAccessToken()); err != nil {
+		t.Fatal(err)
+	} else if identity.TenantID != "tenant1" {
+		t.Fatalf("unexpected tenant after refresh: %s", identity.TenantID)
+	}
+}
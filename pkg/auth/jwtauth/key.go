@@ -0,0 +1,346 @@
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"ginAdmin/pkg/auth"
+	jwt "github.com/dgrijalva/jwt-go"
+	"io/ioutil"
+	"math/big"
+)
+
+// Key 签名密钥
+type Key struct {
+	ID         string            // 密钥ID(对应令牌头部的kid)
+	Method     jwt.SigningMethod // 签名方式
+	PrivateKey interface{}       // 签名密钥(为空则仅用于验签，HMAC为共享密钥)
+	PublicKey  interface{}       // 验签密钥(HMAC为共享密钥)
+}
+
+// IsSymmetric 是否是对称密钥(HMAC)
+func (k *Key) IsSymmetric() bool {
+	_, ok := k.Method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// NewHMACKey 创建HMAC共享密钥
+func NewHMACKey(id string, method jwt.SigningMethod, secret []byte) *Key {
+	return &Key{
+		ID:         id,
+		Method:     method,
+		PrivateKey: secret,
+		PublicKey:  secret,
+	}
+}
+
+// LoadKey 从PEM文件中加载非对称密钥(publicKeyFile为空时从私钥中导出公钥)
+func LoadKey(id, method, privateKeyFile, publicKeyFile string) (*Key, error) {
+	m := jwt.GetSigningMethod(method)
+	if m == nil {
+		return nil, fmt.Errorf("jwtauth: unknown signing method %q", method)
+	} else if _, ok := m.(*jwt.SigningMethodHMAC); ok {
+		return nil, fmt.Errorf("jwtauth: signing method %q is not asymmetric", method)
+	}
+
+	key := &Key{ID: id, Method: m}
+	if privateKeyFile != "" {
+		buf, err := ioutil.ReadFile(privateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := ParsePrivateKeyPEM(buf)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := privateKey.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("jwtauth: key %q: unsupported key type %T", id, privateKey)
+		}
+		key.PrivateKey = privateKey
+		key.PublicKey = signer.Public()
+	}
+
+	if publicKeyFile != "" {
+		buf, err := ioutil.ReadFile(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey, err := ParsePublicKeyPEM(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		//	同时配置了私钥时，公钥必须与私钥匹配
+		if key.PublicKey != nil {
+			pub, ok := key.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+			if !ok || !pub.Equal(publicKey) {
+				return nil, fmt.Errorf("jwtauth: key %q: public key does not match private key", id)
+			}
+		}
+		key.PublicKey = publicKey
+	}
+
+	if key.PublicKey == nil {
+		return nil, fmt.Errorf("jwtauth: key %q has neither private nor public key", id)
+	} else if err := checkKeyType(m, key.PublicKey); err != nil {
+		return nil, fmt.Errorf("jwtauth: key %q: %s", id, err.Error())
+	}
+	return key, nil
+}
+
+// ParsePrivateKeyPEM 解析PEM格式的私钥(支持PKCS8/PKCS1/SEC1)
+func ParsePrivateKeyPEM(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwtauth: invalid PEM data")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("jwtauth: unsupported private key")
+}
+
+// ParsePublicKeyPEM 解析PEM格式的公钥(支持PKIX/PKCS1/证书)
+func ParsePublicKeyPEM(data []byte) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("jwtauth: invalid PEM data")
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+		return cert.PublicKey, nil
+	}
+	return nil, errors.New("jwtauth: unsupported public key")
+}
+
+// 检查密钥类型与签名方式是否匹配
+func checkKeyType(m jwt.SigningMethod, publicKey interface{}) error {
+	switch method := m.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		if _, ok := publicKey.(*rsa.PublicKey); !ok {
+			return fmt.Errorf("%s requires a RSA key", m.Alg())
+		}
+	case *jwt.SigningMethodECDSA:
+		key, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%s requires an ECDSA key", m.Alg())
+		} else if key.Curve.Params().BitSize != method.CurveBits {
+			return fmt.Errorf("%s requires a P-%d curve", m.Alg(), method.CurveBits)
+		}
+	case *SigningMethodEd25519:
+		if _, ok := publicKey.(ed25519.PublicKey); !ok {
+			return fmt.Errorf("%s requires an Ed25519 key", m.Alg())
+		}
+	}
+	return nil
+}
+
+// NewKeySet 创建密钥集合(signingKeyID为空则使用第一个可签名的密钥进行签名)
+func NewKeySet(signingKeyID string, keys ...*Key) (*KeySet, error) {
+	ks := &KeySet{
+		keys: make(map[string]*Key),
+	}
+
+	for _, key := range keys {
+		if _, ok := ks.keys[key.ID]; ok {
+			return nil, fmt.Errorf("jwtauth: duplicate key id %q", key.ID)
+		}
+		ks.keys[key.ID] = key
+		ks.list = append(ks.list, key)
+
+		if ks.signing == nil && signingKeyID == "" && key.PrivateKey != nil {
+			ks.signing = key
+		}
+	}
+
+	if signingKeyID != "" {
+		key, ok := ks.keys[signingKeyID]
+		if !ok {
+			return nil, fmt.Errorf("jwtauth: signing key %q not found", signingKeyID)
+		} else if key.PrivateKey == nil {
+			return nil, fmt.Errorf("jwtauth: signing key %q has no private key", signingKeyID)
+		}
+		ks.signing = key
+	}
+
+	if ks.signing == nil {
+		return nil, errors.New("jwtauth: no signing key")
+	}
+	return ks, nil
+}
+
+// KeySet 密钥集合(通过kid选择验签密钥，以支持密钥轮换)
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	list    []*Key
+}
+
+// SigningKey 获取当前的签名密钥
+func (s *KeySet) SigningKey() *Key {
+	return s.signing
+}
+
+// Keyfunc 根据令牌头部的kid获取验签密钥
+func (s *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, auth.ErrInvalidToken
+	} else if t.Method.Alg() != key.Method.Alg() {
+		return nil, auth.ErrInvalidToken
+	}
+	return key.PublicKey, nil
+}
+
+// JWKS 获取公开的验签公钥集合(不包含对称密钥)
+func (s *KeySet) JWKS() *JSONWebKeySet {
+	set := &JSONWebKeySet{Keys: make([]*JSONWebKey, 0, len(s.list))}
+	for _, key := range s.list {
+		if jwk := newJSONWebKey(key); jwk != nil {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// JSONWebKeySet JWK集合(RFC 7517)
+type JSONWebKeySet struct {
+	Keys []*JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey JWK公钥
+type JSONWebKey struct {
+	Kty string `json:"kty"`           // 密钥类型
+	Kid string `json:"kid,omitempty"` // 密钥ID
+	Use string `json:"use"`           // 用途
+	Alg string `json:"alg"`           // 签名算法
+	N   string `json:"n,omitempty"`   // RSA模数
+	E   string `json:"e,omitempty"`   // RSA指数
+	Crv string `json:"crv,omitempty"` // 曲线
+	X   string `json:"x,omitempty"`   // 曲线X坐标(Ed25519为公钥)
+	Y   string `json:"y,omitempty"`   // 曲线Y坐标
+}
+
+func newJSONWebKey(key *Key) *JSONWebKey {
+	jwk := &JSONWebKey{
+		Kid: key.ID,
+		Use: "sig",
+		Alg: key.Method.Alg(),
+	}
+
+	switch publicKey := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeBase64URL(publicKey.N.Bytes())
+		jwk.E = encodeBase64URL(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		params := publicKey.Curve.Params()
+		size := (params.BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = params.Name
+		jwk.X = encodeBase64URL(padBytes(publicKey.X.Bytes(), size))
+		jwk.Y = encodeBase64URL(padBytes(publicKey.Y.Bytes(), size))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encodeBase64URL(publicKey)
+	default:
+		return nil
+	}
+	return jwk
+}
+
+// PublicKey 解析JWK中的公钥(支持RSA、EC及Ed25519)
+func (k *JSONWebKey) PublicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URL(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URL(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported jwk curve: %s", k.Crv)
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URL(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	case "OKP":
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, err
+		} else if k.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("unsupported jwk curve: %s", k.Crv)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported jwk key type: %s", k.Kty)
+	}
+}
+
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func encodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	buf := make([]byte, size)
+	copy(buf[size-len(b):], b)
+	return buf
+}
@@ -0,0 +1,201 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"ginAdmin/pkg/auth"
+	jwt "github.com/dgrijalva/jwt-go"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestKeys(t *testing.T) []*Key {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []*Key{
+		{ID: "rsa", Method: jwt.SigningMethodRS256, PrivateKey: rsaKey, PublicKey: rsaKey.Public()},
+		{ID: "ec", Method: jwt.SigningMethodES256, PrivateKey: ecKey, PublicKey: ecKey.Public()},
+		{ID: "ed", Method: SigningMethodEdDSA, PrivateKey: edKey, PublicKey: edKey.Public()},
+	}
+}
+
+func TestKeySetSignAndVerify(t *testing.T) {
+	ctx := context.Background()
+	keys := newTestKeys(t)
+
+	for _, key := range keys {
+		ks, err := NewKeySet(key.ID, keys...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a := New(nil, SetKeySet(ks))
+		tokenInfo, err := a.GenerateToken(ctx, "user1", auth.ClientInfo{})
+		if err != nil {
+			t.Fatalf("%s: %v", key.ID, err)
+		}
+
+		userID, err := a.ParseUserID(ctx, tokenInfo.GetAccessToken())
+		if err != nil {
+			t.Fatalf("%s: %v", key.ID, err)
+		} else if userID != "user1" {
+			t.Fatalf("%s: unexpected user id %s", key.ID, userID)
+		}
+	}
+
+	jwks := (&KeySet{list: keys}).JWKS()
+	if len(jwks.Keys) != 3 {
+		t.Fatalf("unexpected jwks size %d", len(jwks.Keys))
+	}
+	for i, kty := range []string{"RSA", "EC", "OKP"} {
+		if jwks.Keys[i].Kty != kty || jwks.Keys[i].Kid != keys[i].ID {
+			t.Fatalf("unexpected jwk %+v", jwks.Keys[i])
+		}
+
+		// JWK解析出的公钥可以验证签名
+		publicKey, err := jwks.Keys[i].PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := keys[i].Method.Sign("payload", keys[i].PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		} else if err := keys[i].Method.Verify("payload", sig, publicKey); err != nil {
+			t.Fatalf("verify with jwk %s: %v", kty, err)
+		}
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	ctx := context.Background()
+	keys := newTestKeys(t)
+	legacy := NewHMACKey("", jwt.SigningMethodHS512, []byte("secret"))
+
+	oldKS, err := NewKeySet("", legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldToken, err := New(nil, SetKeySet(oldKS)).GenerateToken(ctx, "user1", auth.ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 轮换为RSA签名，旧的HMAC密钥仅用于验签
+	newKS, err := NewKeySet("rsa", legacy, keys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := New(nil, SetKeySet(newKS))
+	if _, err := a.ParseUserID(ctx, oldToken.GetAccessToken()); err != nil {
+		t.Fatalf("old token should still be valid: %v", err)
+	}
+
+	if jwks := newKS.JWKS(); len(jwks.Keys) != 1 {
+		t.Fatalf("symmetric keys must not be published, got %d keys", len(jwks.Keys))
+	}
+
+	// 使用RSA公钥作为HMAC密钥伪造的令牌必须被拒绝
+	pub, _ := x509.MarshalPKIXPublicKey(keys[0].PublicKey)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &tokenClaims{
+		StandardClaims: jwt.StandardClaims{Subject: "user1"},
+	})
+	forged.Header["kid"] = "rsa"
+	s, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.ParseUserID(ctx, s); err == nil {
+		t.Fatal("forged token should be rejected")
+	}
+}
+
+func TestLoadKeyUnsupportedType(t *testing.T) {
+	// X25519私钥只能用于密钥协商，不能用于签名
+	x25519Key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, err := x509.MarshalPKCS8PrivateKey(x25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "x25519.pem")
+	err = os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: buf}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadKey("x25519", "EdDSA", file, ""); err == nil || !strings.Contains(err.Error(), "unsupported key type") {
+		t.Fatalf("expected unsupported key type, got %v", err)
+	}
+}
+
+func TestLoadKeyMismatchedPublicKey(t *testing.T) {
+	dir := t.TempDir()
+	writeKey := func(name string, key *ecdsa.PrivateKey, public bool) string {
+		var block *pem.Block
+		if public {
+			buf, err := x509.MarshalPKIXPublicKey(key.Public())
+			if err != nil {
+				t.Fatal(err)
+			}
+			block = &pem.Block{Type: "PUBLIC KEY", Bytes: buf}
+		} else {
+			buf, err := x509.MarshalECPrivateKey(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: buf}
+		}
+
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+
+	key1, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	key2, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privateFile := writeKey("k1.pem", key1, false)
+
+	if _, err := LoadKey("k1", "ES256", privateFile, writeKey("k1.pub", key1, true)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKey("k1", "ES256", privateFile, writeKey("k2.pub", key2, true)); err == nil {
+		t.Fatal("expected mismatched public key to be rejected")
+	}
+}
+
+func TestNewKeySetSigningKeyWithoutPrivateKey(t *testing.T) {
+	keys := newTestKeys(t)
+	verifyOnly := &Key{ID: "old", Method: keys[0].Method, PublicKey: keys[0].PublicKey}
+
+	if _, err := NewKeySet("old", verifyOnly, keys[1]); err == nil {
+		t.Fatal("expected error for signing key without private key")
+	}
+	if _, err := NewKeySet("missing", keys...); err == nil {
+		t.Fatal("expected error for unknown signing key")
+	}
+}
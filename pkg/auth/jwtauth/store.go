@@ -2,15 +2,36 @@ package jwtauth
 
 import (
 	"context"
+	"errors"
+	"ginAdmin/pkg/auth"
 	"time"
 )
 
+// ErrNoStore 未设定令牌储存(刷新令牌的轮换及重复使用检测、模拟登录令牌的撤销依赖储存)
+var ErrNoStore = errors.New("jwtauth: token store is required to refresh or impersonate")
+
 // Storer 令牌储存接口
 type Storer interface {
 	//	储存令牌数据，并指定到期时间
 	Set(ctx context.Context, tokenString string, expiration time.Duration) error
 	//	检查令牌是否存在
 	Check(ctx context.Context, tokenString string) (bool, error)
+	//	创建令牌族，记录当前有效的刷新令牌ID，并指定到期时间
+	SetFamily(ctx context.Context, familyID, tokenID string, expiration time.Duration) error
+	//	轮换令牌族的刷新令牌ID(仅当当前有效ID与oldTokenID一致时)，返回令牌族是否存在及是否轮换成功
+	RotateFamily(ctx context.Context, familyID, oldTokenID, newTokenID string, expiration time.Duration) (bool, bool, error)
+	//	检查令牌族是否存在
+	CheckFamily(ctx context.Context, familyID string) (bool, error)
+	//	删除令牌族(撤销该令牌族下的所有令牌)
+	DeleteFamily(ctx context.Context, familyID string) error
+	//	保存会话信息，并指定到期时间(会话的有效性由同ID的令牌族决定，到期时间与令牌族一致)
+	SetSession(ctx context.Context, session *auth.Session, expiration time.Duration) error
+	//	延长会话信息的到期时间(令牌族轮换时同步延长)
+	ExpireSession(ctx context.Context, userID, sessionID string, expiration time.Duration) error
+	//	查询用户的会话信息列表
+	QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error)
+	//	删除用户的会话信息
+	DeleteSession(ctx context.Context, userID, sessionID string) error
 	//	关闭储存
 	Close() error
 }
@@ -0,0 +1,200 @@
+package buntdb
+
+import (
+	"context"
+	"fmt"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/util/json"
+	"github.com/tidwall/buntdb"
+	"time"
+)
+
+// NewStore 创建基于buntdb文件储存的实例
+func NewStore(path string) (*Store, error) {
+	db, err := buntdb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		db: db,
+	}, nil
+}
+
+// Store buntdb储存(过期的数据由buntdb自动清理)
+type Store struct {
+	db *buntdb.DB
+}
+
+func (s *Store) wrapperFamilyKey(familyID string) string {
+	return fmt.Sprintf("family:%s", familyID)
+}
+
+func (s *Store) wrapperSessionKey(userID, sessionID string) string {
+	return fmt.Sprintf("session:%s:%s", userID, sessionID)
+}
+
+func (s *Store) setOptions(expiration time.Duration) *buntdb.SetOptions {
+	if expiration > 0 {
+		return &buntdb.SetOptions{Expires: true, TTL: expiration}
+	}
+	return nil
+}
+
+func (s *Store) exists(key string) (bool, error) {
+	var exists bool
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		_, err := tx.Get(key)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+		exists = true
+		return nil
+	})
+	return exists, err
+}
+
+func (s *Store) delete(key string) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(key)
+		if err != nil && err != buntdb.ErrNotFound {
+			return err
+		}
+		return nil
+	})
+}
+
+// Set ...
+func (s *Store) Set(ctx context.Context, tokenString string, expiration time.Duration) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(tokenString, "1", s.setOptions(expiration))
+		return err
+	})
+}
+
+// Delete ...
+func (s *Store) Delete(ctx context.Context, tokenString string) (bool, error) {
+	var exists bool
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(tokenString)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+		exists = true
+		return nil
+	})
+	return exists, err
+}
+
+// Check ...
+func (s *Store) Check(ctx context.Context, tokenString string) (bool, error) {
+	return s.exists(tokenString)
+}
+
+// SetFamily ...
+func (s *Store) SetFamily(ctx context.Context, familyID, tokenID string, expiration time.Duration) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(s.wrapperFamilyKey(familyID), tokenID, s.setOptions(expiration))
+		return err
+	})
+}
+
+// RotateFamily ...
+func (s *Store) RotateFamily(ctx context.Context, familyID, oldTokenID, newTokenID string, expiration time.Duration) (bool, bool, error) {
+	var exists, rotated bool
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		key := s.wrapperFamilyKey(familyID)
+		v, err := tx.Get(key)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+
+		exists = true
+		if v != oldTokenID {
+			return nil
+		}
+
+		_, _, err = tx.Set(key, newTokenID, s.setOptions(expiration))
+		if err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	return exists, rotated, err
+}
+
+// CheckFamily ...
+func (s *Store) CheckFamily(ctx context.Context, familyID string) (bool, error) {
+	return s.exists(s.wrapperFamilyKey(familyID))
+}
+
+// DeleteFamily ...
+func (s *Store) DeleteFamily(ctx context.Context, familyID string) error {
+	return s.delete(s.wrapperFamilyKey(familyID))
+}
+
+// SetSession ...
+func (s *Store) SetSession(ctx context.Context, session *auth.Session, expiration time.Duration) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(s.wrapperSessionKey(session.UserID, session.ID), json.MarshalToString(session), s.setOptions(expiration))
+		return err
+	})
+}
+
+// ExpireSession ...
+func (s *Store) ExpireSession(ctx context.Context, userID, sessionID string, expiration time.Duration) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		key := s.wrapperSessionKey(userID, sessionID)
+		v, err := tx.Get(key)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+
+		_, _, err = tx.Set(key, v, s.setOptions(expiration))
+		return err
+	})
+}
+
+// QuerySessions ...
+func (s *Store) QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error) {
+	var list []*auth.Session
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		iterErr := tx.AscendKeys(s.wrapperSessionKey(userID, "*"), func(key, value string) bool {
+			item := new(auth.Session)
+			if err = json.Unmarshal([]byte(value), item); err != nil {
+				return false
+			}
+			list = append(list, item)
+			return true
+		})
+		if iterErr != nil {
+			return iterErr
+		}
+		return err
+	})
+	return list, err
+}
+
+// DeleteSession ...
+func (s *Store) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	return s.delete(s.wrapperSessionKey(userID, sessionID))
+}
+
+// Close ...
+func (s *Store) Close() error {
+	return s.db.Close()
+}
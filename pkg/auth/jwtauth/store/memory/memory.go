@@ -0,0 +1,238 @@
+package memory
+
+import (
+	"context"
+	"ginAdmin/pkg/auth"
+	"sync"
+	"time"
+)
+
+const defaultGCInterval = time.Minute
+
+// NewStore 创建基于内存储存的实例(gcInterval为清理过期数据的时间间隔，默认1分钟)
+func NewStore(gcInterval time.Duration) *Store {
+	if gcInterval <= 0 {
+		gcInterval = defaultGCInterval
+	}
+
+	s := &Store{
+		tokens:   make(map[string]item),
+		families: make(map[string]item),
+		sessions: make(map[string]map[string]sessionItem),
+		done:     make(chan struct{}),
+	}
+	go s.gc(gcInterval)
+	return s
+}
+
+type item struct {
+	value     string
+	expiredAt time.Time
+}
+
+func (i item) isExpired(now time.Time) bool {
+	return !i.expiredAt.IsZero() && now.After(i.expiredAt)
+}
+
+type sessionItem struct {
+	session auth.Session
+	item
+}
+
+// Store 内存储存(仅适用于单实例部署，进程重启后数据丢失)
+type Store struct {
+	lock      sync.RWMutex
+	tokens    map[string]item
+	families  map[string]item
+	sessions  map[string]map[string]sessionItem
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newItem(value string, expiration time.Duration) item {
+	i := item{value: value}
+	if expiration > 0 {
+		i.expiredAt = time.Now().Add(expiration)
+	}
+	return i
+}
+
+func (s *Store) gc(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.deleteExpired()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Store) deleteExpired() {
+	now := time.Now()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for k, v := range s.tokens {
+		if v.isExpired(now) {
+			delete(s.tokens, k)
+		}
+	}
+	for k, v := range s.families {
+		if v.isExpired(now) {
+			delete(s.families, k)
+		}
+	}
+	for userID, m := range s.sessions {
+		for k, v := range m {
+			if v.isExpired(now) {
+				delete(m, k)
+			}
+		}
+		if len(m) == 0 {
+			delete(s.sessions, userID)
+		}
+	}
+}
+
+func (s *Store) get(m map[string]item, key string) (item, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	v, ok := m[key]
+	if !ok || v.isExpired(time.Now()) {
+		return item{}, false
+	}
+	return v, true
+}
+
+// Set ...
+func (s *Store) Set(ctx context.Context, tokenString string, expiration time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tokens[tokenString] = newItem("1", expiration)
+	return nil
+}
+
+// Delete ...
+func (s *Store) Delete(ctx context.Context, tokenString string) (bool, error) {
+	_, exists := s.get(s.tokens, tokenString)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.tokens, tokenString)
+	return exists, nil
+}
+
+// Check ...
+func (s *Store) Check(ctx context.Context, tokenString string) (bool, error) {
+	_, exists := s.get(s.tokens, tokenString)
+	return exists, nil
+}
+
+// SetFamily ...
+func (s *Store) SetFamily(ctx context.Context, familyID, tokenID string, expiration time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.families[familyID] = newItem(tokenID, expiration)
+	return nil
+}
+
+// RotateFamily ...
+func (s *Store) RotateFamily(ctx context.Context, familyID, oldTokenID, newTokenID string, expiration time.Duration) (bool, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.families[familyID]
+	if !ok || v.isExpired(time.Now()) {
+		return false, false, nil
+	} else if v.value != oldTokenID {
+		return true, false, nil
+	}
+
+	s.families[familyID] = newItem(newTokenID, expiration)
+	return true, true, nil
+}
+
+// CheckFamily ...
+func (s *Store) CheckFamily(ctx context.Context, familyID string) (bool, error) {
+	_, exists := s.get(s.families, familyID)
+	return exists, nil
+}
+
+// DeleteFamily ...
+func (s *Store) DeleteFamily(ctx context.Context, familyID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.families, familyID)
+	return nil
+}
+
+// SetSession ...
+func (s *Store) SetSession(ctx context.Context, session *auth.Session, expiration time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	m, ok := s.sessions[session.UserID]
+	if !ok {
+		m = make(map[string]sessionItem)
+		s.sessions[session.UserID] = m
+	}
+	m[session.ID] = sessionItem{session: *session, item: newItem("", expiration)}
+	return nil
+}
+
+// ExpireSession ...
+func (s *Store) ExpireSession(ctx context.Context, userID, sessionID string, expiration time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if v, ok := s.sessions[userID][sessionID]; ok {
+		v.item = newItem("", expiration)
+		s.sessions[userID][sessionID] = v
+	}
+	return nil
+}
+
+// QuerySessions ...
+func (s *Store) QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	now := time.Now()
+	m := s.sessions[userID]
+	list := make([]*auth.Session, 0, len(m))
+	for _, v := range m {
+		if v.isExpired(now) {
+			continue
+		}
+		item := v.session
+		list = append(list, &item)
+	}
+	return list, nil
+}
+
+// DeleteSession ...
+func (s *Store) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if m, ok := s.sessions[userID]; ok {
+		delete(m, sessionID)
+		if len(m) == 0 {
+			delete(s.sessions, userID)
+		}
+	}
+	return nil
+}
+
+// Close ...
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"ginAdmin/pkg/auth"
+	"testing"
+	"time"
+)
+
+func TestStoreExpiration(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(10 * time.Millisecond)
+	defer s.Close()
+
+	_ = s.Set(ctx, "token", 20*time.Millisecond)
+	_ = s.SetFamily(ctx, "family", "id1", 20*time.Millisecond)
+	_ = s.SetSession(ctx, &auth.Session{ID: "family", UserID: "user"}, 20*time.Millisecond)
+
+	if ok, _ := s.Check(ctx, "token"); !ok {
+		t.Fatal("token should exist")
+	}
+	if exists, rotated, _ := s.RotateFamily(ctx, "family", "id0", "id2", time.Minute); !exists || rotated {
+		t.Fatal("rotation with a stale id must fail")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if ok, _ := s.Check(ctx, "token"); ok {
+		t.Fatal("token should be expired")
+	}
+	if ok, _ := s.CheckFamily(ctx, "family"); ok {
+		t.Fatal("family should be expired")
+	}
+	if list, _ := s.QuerySessions(ctx, "user"); len(list) != 0 {
+		t.Fatal("session should be expired")
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if len(s.tokens) != 0 || len(s.families) != 0 || len(s.sessions) != 0 {
+		t.Fatal("expired entries should be removed by gc")
+	}
+}
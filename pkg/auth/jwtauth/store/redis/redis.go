@@ -3,6 +3,8 @@ package redis
 import (
 	"context"
 	"fmt"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/util/json"
 	"github.com/go-redis/redis/v8"
 	"time"
 )
@@ -54,6 +56,26 @@ func (s *Store) wrapperKey(key string) string {
 	return fmt.Sprintf("%s%s", s.prefix, key)
 }
 
+func (s *Store) wrapperFamilyKey(familyID string) string {
+	return fmt.Sprintf("%sfamily:%s", s.prefix, familyID)
+}
+
+func (s *Store) wrapperSessionKey(userID string) string {
+	return fmt.Sprintf("%ssessions:%s", s.prefix, userID)
+}
+
+// rotateFamilyScript 原子地比较并轮换令牌族的刷新令牌ID(0:不存在 1:不一致 2:轮换成功)
+var rotateFamilyScript = redis.NewScript(`
+local v = redis.call("GET", KEYS[1])
+if not v then
+	return 0
+elseif v ~= ARGV[1] then
+	return 1
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+return 2
+`)
+
 // Set ...
 func (s *Store) Set(ctx context.Context, tokenString string, expiration time.Duration) error {
 	cmd := s.cli.Set(ctx, s.wrapperKey(tokenString), "1", expiration)
@@ -80,6 +102,85 @@ func (s *Store) Check(ctx context.Context, tokenString string) (bool, error) {
 	return cmd.Val() > 0, nil
 }
 
+// SetFamily ...
+func (s *Store) SetFamily(ctx context.Context, familyID, tokenID string, expiration time.Duration) error {
+	cmd := s.cli.Set(ctx, s.wrapperFamilyKey(familyID), tokenID, expiration)
+	return cmd.Err()
+}
+
+// RotateFamily ...
+func (s *Store) RotateFamily(ctx context.Context, familyID, oldTokenID, newTokenID string, expiration time.Duration) (bool, bool, error) {
+	keys := []string{s.wrapperFamilyKey(familyID)}
+	v, err := rotateFamilyScript.Run(ctx, s.cli, keys, oldTokenID, newTokenID, expiration.Milliseconds()).Int()
+	if err != nil {
+		return false, false, err
+	}
+
+	return v > 0, v == 2, nil
+}
+
+// CheckFamily ...
+func (s *Store) CheckFamily(ctx context.Context, familyID string) (bool, error) {
+	cmd := s.cli.Exists(ctx, s.wrapperFamilyKey(familyID))
+	if err := cmd.Err(); err != nil {
+		return false, err
+	}
+
+	return cmd.Val() > 0, nil
+}
+
+// DeleteFamily ...
+func (s *Store) DeleteFamily(ctx context.Context, familyID string) error {
+	cmd := s.cli.Del(ctx, s.wrapperFamilyKey(familyID))
+	return cmd.Err()
+}
+
+// SetSession 用户的会话保存在同一个哈希中，哈希的到期时间为最近登录或刷新的会话的到期时间(令牌族已失效的会话在查询时清理)
+func (s *Store) SetSession(ctx context.Context, session *auth.Session, expiration time.Duration) error {
+	key := s.wrapperSessionKey(session.UserID)
+	_, err := s.cli.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, session.ID, json.MarshalToString(session))
+		if expiration > 0 {
+			pipe.PExpire(ctx, key, expiration)
+		}
+		return nil
+	})
+	return err
+}
+
+// ExpireSession ...
+func (s *Store) ExpireSession(ctx context.Context, userID, sessionID string, expiration time.Duration) error {
+	if expiration <= 0 {
+		return nil
+	}
+	cmd := s.cli.PExpire(ctx, s.wrapperSessionKey(userID), expiration)
+	return cmd.Err()
+}
+
+// QuerySessions ...
+func (s *Store) QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error) {
+	cmd := s.cli.HGetAll(ctx, s.wrapperSessionKey(userID))
+	if err := cmd.Err(); err != nil {
+		return nil, err
+	}
+
+	list := make([]*auth.Session, 0, len(cmd.Val()))
+	for _, v := range cmd.Val() {
+		item := new(auth.Session)
+		if err := json.Unmarshal([]byte(v), item); err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+// DeleteSession ...
+func (s *Store) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	cmd := s.cli.HDel(ctx, s.wrapperSessionKey(userID), sessionID)
+	return cmd.Err()
+}
+
 // Close ...
 func (s *Store) Close() error {
 	return s.cli.Close()
@@ -0,0 +1,418 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"ginAdmin/pkg/auth"
+	"ginAdmin/pkg/auth/jwtauth"
+	jwt "github.com/dgrijalva/jwt-go"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 定义错误
+var (
+	ErrInvalidIDToken = errors.New("invalid id token")
+)
+
+// 身份令牌允许的签名算法(不允许none及HMAC)
+var validMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// Config OIDC身份提供者配置参数
+type Config struct {
+	Name         string   // 身份提供者名称
+	Issuer       string   // 签发者(用于服务发现，{Issuer}/.well-known/openid-configuration)
+	ClientID     string   // 客户端ID
+	ClientSecret string   // 客户端密钥(为空时作为公开客户端，仅使用PKCE)
+	RedirectURL  string   // 回调地址
+	Scopes       []string // 授权范围(默认openid profile email)
+}
+
+// Option 定义参数项
+type Option func(*Provider)
+
+// SetHTTPClient 设定HTTP客户端
+func SetHTTPClient(cli *http.Client) Option {
+	return func(p *Provider) {
+		p.client = cli
+	}
+}
+
+// SetLeeway 设定校验身份令牌时间时允许的误差
+func SetLeeway(leeway time.Duration) Option {
+	return func(p *Provider) {
+		p.leeway = leeway
+	}
+}
+
+// New 创建OIDC身份提供者(服务发现在首次使用时进行)
+func New(cfg Config, opts ...Option) *Provider {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+
+	p := &Provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		leeway: time.Minute,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+var _ auth.IdentityProvider = (*Provider)(nil)
+
+// Provider OIDC身份提供者(授权码模式+PKCE)
+type Provider struct {
+	cfg    Config
+	client *http.Client
+	leeway time.Duration
+
+	lock      sync.RWMutex
+	discovery *Discovery
+	keys      map[string]interface{}
+}
+
+// Discovery OIDC服务发现文档
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+}
+
+// NewCodeVerifier 生成PKCE校验码
+func NewCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// CodeChallenge 根据PKCE校验码生成S256挑战码
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Name 身份提供者名称
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// AuthCodeURL 生成授权地址
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", CodeChallenge(codeVerifier))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange 使用授权码换取令牌，并校验身份令牌
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*auth.ExternalIdentity, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.cfg.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	var token tokenResponse
+	status, err := p.doJSON(req, &token)
+	if err != nil {
+		return nil, err
+	} else if status != http.StatusOK || token.Error != "" {
+		return nil, fmt.Errorf("oidc token exchange failed: %d %s %s", status, token.Error, token.ErrorDescription)
+	} else if token.IDToken == "" {
+		return nil, fmt.Errorf("oidc token response missing id_token")
+	}
+
+	claims, err := p.VerifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.ExternalIdentity{
+		Provider:      p.cfg.Name,
+		Subject:       claims.Subject,
+		UserName:      claims.PreferredUsername,
+		Name:          claims.Name,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+	}, nil
+}
+
+// IDTokenClaims 身份令牌声明
+type IDTokenClaims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	ExpiresAt         int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	NotBefore         int64    `json:"nbf,omitempty"`
+	Nonce             string   `json:"nonce"`
+	AuthorizedParty   string   `json:"azp,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	EmailVerified     boolean  `json:"email_verified,omitempty"`
+}
+
+// Valid 由VerifyIDToken统一校验
+func (c *IDTokenClaims) Valid() error {
+	return nil
+}
+
+// VerifyIDToken 校验身份令牌(签名、签发者、受众、有效期及nonce)
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &jwt.Parser{ValidMethods: validMethods}
+	claims := new(IDTokenClaims)
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.getKey(ctx, d, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidIDToken, err.Error())
+	}
+
+	now := time.Now()
+	leeway := int64(p.leeway / time.Second)
+	switch {
+	case claims.Issuer != d.Issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %s", ErrInvalidIDToken, claims.Issuer)
+	case !claims.Audience.contains(p.cfg.ClientID):
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidIDToken)
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != "" && claims.AuthorizedParty != p.cfg.ClientID:
+		return nil, fmt.Errorf("%w: unexpected authorized party %s", ErrInvalidIDToken, claims.AuthorizedParty)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	case claims.ExpiresAt == 0 || now.Unix() > claims.ExpiresAt+leeway:
+		return nil, fmt.Errorf("%w: token is expired", ErrInvalidIDToken)
+	case claims.NotBefore > 0 && now.Unix() < claims.NotBefore-leeway:
+		return nil, fmt.Errorf("%w: token is not valid yet", ErrInvalidIDToken)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: unexpected nonce", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+// 获取验签公钥(密钥ID不存在时重新获取JWKS，以支持身份提供者轮换密钥)
+func (p *Provider) getKey(ctx context.Context, d *Discovery, kid string) (interface{}, error) {
+	p.lock.RLock()
+	key, ok := p.lookupKey(kid)
+	p.lock.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.keys = keys
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key: %s", kid)
+}
+
+func (p *Provider) lookupKey(kid string) (interface{}, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *Provider) fetchKeys(ctx context.Context, d *Discovery) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set jwtauth.JSONWebKeySet
+	if status, err := p.doJSON(req, &set); err != nil {
+		return nil, err
+	} else if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc fetch jwks failed: %d", status)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// 服务发现(成功后缓存结果)
+func (p *Provider) discover(ctx context.Context) (*Discovery, error) {
+	p.lock.RLock()
+	d := p.discovery
+	p.lock.RUnlock()
+	if d != nil {
+		return d, nil
+	}
+
+	wellKnown := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	d = new(Discovery)
+	if status, err := p.doJSON(req, d); err != nil {
+		return nil, err
+	} else if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc discovery failed: %d", status)
+	} else if d.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc issuer mismatch: expected %s, got %s", p.cfg.Issuer, d.Issuer)
+	} else if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery document is incomplete")
+	}
+
+	p.lock.Lock()
+	p.discovery = d
+	p.lock.Unlock()
+	return d, nil
+}
+
+func (p *Provider) doJSON(req *http.Request, v interface{}) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// audience 受众(可以是字符串或字符串数组)
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = audience{s}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(v string) bool {
+	for _, item := range a {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// boolean 兼容部分身份提供者以字符串返回的布尔值
+type boolean bool
+
+func (b *boolean) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	default:
+		*b = false
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"ginAdmin/pkg/auth/jwtauth"
+	jwt "github.com/dgrijalva/jwt-go"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 本地OIDC身份提供者桩服务
+type stubIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	keySet *jwtauth.KeySet
+
+	lock  sync.Mutex
+	codes map[string]url.Values // code -> 授权请求参数
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySet, err := jwtauth.NewKeySet("k1", &jwtauth.Key{
+		ID:         "k1",
+		Method:     jwt.SigningMethodRS256,
+		PrivateKey: key,
+		PublicKey:  key.Public(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &stubIdP{t: t, key: key, keySet: keySet, codes: make(map[string]url.Values)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/token", s.token)
+	s.server = httptest.NewServer(mux)
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *stubIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(Discovery{
+		Issuer:                s.server.URL,
+		AuthorizationEndpoint: s.server.URL + "/authorize",
+		TokenEndpoint:         s.server.URL + "/token",
+		JWKSURI:               s.server.URL + "/jwks",
+	})
+}
+
+func (s *stubIdP) jwks(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(s.keySet.JWKS())
+}
+
+// 模拟用户在授权页面完成登录，返回授权码
+func (s *stubIdP) authorize(authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	code := "code-" + u.Query().Get("state")
+	s.codes[code] = u.Query()
+	return code
+}
+
+func (s *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	s.lock.Lock()
+	params, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	s.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ok || CodeChallenge(r.PostForm.Get("code_verifier")) != params.Get("code_challenge") {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                s.server.URL,
+		"sub":                "external-1",
+		"aud":                []string{params.Get("client_id")},
+		"exp":                now.Add(time.Minute).Unix(),
+		"iat":                now.Unix(),
+		"nonce":              params.Get("nonce"),
+		"preferred_username": "alice",
+		"email":              "alice@example.com",
+		"email_verified":     true,
+	})
+	token.Header["kid"] = "k1"
+	idToken, err := token.SignedString(s.key)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func TestExchange(t *testing.T) {
+	ctx := context.Background()
+	idp := newStubIdP(t)
+	p := New(Config{
+		Name:        "stub",
+		Issuer:      idp.server.URL,
+		ClientID:    "client",
+		RedirectURL: "http://localhost/callback",
+	})
+
+	verifier, err := NewCodeVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authURL, err := p.AuthCodeURL(ctx, "state1", "nonce1", verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := p.Exchange(ctx, idp.authorize(authURL), verifier, "nonce1")
+	if err != nil {
+		t.Fatal(err)
+	} else if identity.Provider != "stub" || identity.Subject != "external-1" ||
+		identity.UserName != "alice" || identity.Email != "alice@example.com" || !identity.EmailVerified {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+
+	// 授权码只能使用一次
+	if _, err := p.Exchange(ctx, "code-state1", verifier, "nonce1"); err == nil {
+		t.Fatal("expected used code to be rejected")
+	}
+}
+
+func TestExchangeWrongVerifier(t *testing.T) {
+	ctx := context.Background()
+	idp := newStubIdP(t)
+	p := New(Config{Name: "stub", Issuer: idp.server.URL, ClientID: "client"})
+
+	verifier, _ := NewCodeVerifier()
+	authURL, err := p.AuthCodeURL(ctx, "state1", "nonce1", verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, _ := NewCodeVerifier()
+	if _, err := p.Exchange(ctx, idp.authorize(authURL), other, "nonce1"); err == nil {
+		t.Fatal("expected wrong code verifier to be rejected")
+	}
+}
+
+func TestExchangeWrongNonce(t *testing.T) {
+	ctx := context.Background()
+	idp := newStubIdP(t)
+	p := New(Config{Name: "stub", Issuer: idp.server.URL, ClientID: "client"})
+
+	verifier, _ := NewCodeVerifier()
+	authURL, err := p.AuthCodeURL(ctx, "state1", "nonce1", verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.Exchange(ctx, idp.authorize(authURL), verifier, "nonce2"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Fatalf("expected invalid id token, got %v", err)
+	}
+}
+
+func TestVerifyIDTokenAudience(t *testing.T) {
+	ctx := context.Background()
+	idp := newStubIdP(t)
+	p := New(Config{Name: "stub", Issuer: idp.server.URL, ClientID: "other"})
+
+	verifier, _ := NewCodeVerifier()
+	authURL, err := New(Config{Name: "stub", Issuer: idp.server.URL, ClientID: "client"}).
+		AuthCodeURL(ctx, "state1", "nonce1", verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.Exchange(ctx, idp.authorize(authURL), verifier, "nonce1"); !errors.Is(err, ErrInvalidIDToken) {
+		t.Fatalf("expected audience mismatch, got %v", err)
+	}
+}
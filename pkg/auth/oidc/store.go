@@ -0,0 +1,16 @@
+package oidc
+
+import (
+	"context"
+	"time"
+)
+
+// Storer 授权请求状态储存接口
+type Storer interface {
+	//	储存状态数据，并指定到期时间
+	Set(ctx context.Context, state string, value []byte, expiration time.Duration) error
+	//	取出状态数据(取出后即删除，不存在时返回nil)
+	Take(ctx context.Context, state string) ([]byte, error)
+	//	关闭储存
+	Close() error
+}
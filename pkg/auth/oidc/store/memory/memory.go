@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultGCInterval = time.Minute
+
+// NewStore 创建基于内存储存的实例(gcInterval为清理过期数据的时间间隔，默认1分钟)
+func NewStore(gcInterval time.Duration) *Store {
+	if gcInterval <= 0 {
+		gcInterval = defaultGCInterval
+	}
+
+	s := &Store{
+		items: make(map[string]item),
+		done:  make(chan struct{}),
+	}
+	go s.gc(gcInterval)
+	return s
+}
+
+type item struct {
+	value     []byte
+	expiredAt time.Time
+}
+
+// Store 内存储存(仅适用于单实例部署，进程重启后数据丢失)
+type Store struct {
+	lock      sync.Mutex
+	items     map[string]item
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *Store) gc(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			s.lock.Lock()
+			for k, v := range s.items {
+				if now.After(v.expiredAt) {
+					delete(s.items, k)
+				}
+			}
+			s.lock.Unlock()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Set ...
+func (s *Store) Set(ctx context.Context, state string, value []byte, expiration time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.items[state] = item{
+		value:     value,
+		expiredAt: time.Now().Add(expiration),
+	}
+	return nil
+}
+
+// Take ...
+func (s *Store) Take(ctx context.Context, state string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.items[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(s.items, state)
+
+	if time.Now().After(v.expiredAt) {
+		return nil, nil
+	}
+	return v.value, nil
+}
+
+// Close ...
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
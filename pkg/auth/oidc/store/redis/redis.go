@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// Config redis配置参数
+type Config struct {
+	Addr      string // 地址(IP:Port)
+	DB        int    // 数据库
+	Password  string // 密码
+	KeyPrefix string // 储存key的前缀
+}
+
+// NewStore 创建基于redis储存的实例
+func NewStore(cfg *Config) *Store {
+	cli := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		DB:       cfg.DB,
+		Password: cfg.Password,
+	})
+	return &Store{
+		cli:    cli,
+		prefix: cfg.KeyPrefix,
+	}
+}
+
+// Store redis储存
+type Store struct {
+	cli    *redis.Client
+	prefix string
+}
+
+func (s *Store) wrapperKey(key string) string {
+	return fmt.Sprintf("%s%s", s.prefix, key)
+}
+
+// takeScript 获取并删除
+var takeScript = redis.NewScript(`
+local v = redis.call("GET", KEYS[1])
+if v then
+	redis.call("DEL", KEYS[1])
+end
+return v
+`)
+
+// Set ...
+func (s *Store) Set(ctx context.Context, state string, value []byte, expiration time.Duration) error {
+	cmd := s.cli.Set(ctx, s.wrapperKey(state), value, expiration)
+	return cmd.Err()
+}
+
+// Take ...
+func (s *Store) Take(ctx context.Context, state string) ([]byte, error) {
+	v, err := takeScript.Run(ctx, s.cli, []string{s.wrapperKey(state)}).Text()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []byte(v), nil
+}
+
+// Close ...
+func (s *Store) Close() error {
+	return s.cli.Close()
+}
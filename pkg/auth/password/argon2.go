@@ -0,0 +1,127 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+// Argon2idName argon2id算法名称
+const Argon2idName = "argon2id"
+
+// NewArgon2id 创建argon2id算法(参数为0时使用默认值)
+func NewArgon2id(time, memory uint32, threads uint8) *Argon2id {
+	h := &Argon2id{
+		Time:    1,
+		Memory:  64 * 1024,
+		Threads: 4,
+		KeyLen:  32,
+		SaltLen: 16,
+	}
+	if time > 0 {
+		h.Time = time
+	}
+	if memory > 0 {
+		h.Memory = memory
+	}
+	if threads > 0 {
+		h.Threads = threads
+	}
+	return h
+}
+
+// Argon2id argon2id算法(哈希格式：$argon2id$v=19$m=65536,t=1,p=4$salt$key)
+type Argon2id struct {
+	Time    uint32 // 迭代次数
+	Memory  uint32 // 内存大小(单位KB)
+	Threads uint8  // 并行度
+	KeyLen  uint32 // 哈希长度
+	SaltLen uint32 // 盐长度
+}
+
+type argon2Params struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+var argon2Encoding = base64.RawStdEncoding
+
+// Name ...
+func (a *Argon2id) Name() string {
+	return Argon2idName
+}
+
+// Hash ...
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, a.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Threads, a.KeyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", prefix(Argon2idName), argon2.Version,
+		a.Memory, a.Time, a.Threads,
+		argon2Encoding.EncodeToString(salt),
+		argon2Encoding.EncodeToString(key)), nil
+}
+
+// Match ...
+func (a *Argon2id) Match(encoded string) bool {
+	return hasPrefix(encoded, Argon2idName)
+}
+
+// Verify ...
+func (a *Argon2id) Verify(encoded, password string) (bool, error) {
+	p, err := a.decode(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	key := argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+	return subtle.ConstantTimeCompare(key, p.key) == 1, nil
+}
+
+// NeedsRehash ...
+func (a *Argon2id) NeedsRehash(encoded string) bool {
+	p, err := a.decode(encoded)
+	if err != nil {
+		return true
+	}
+	return p.time != a.Time || p.memory != a.Memory || p.threads != a.Threads ||
+		uint32(len(p.key)) != a.KeyLen || uint32(len(p.salt)) != a.SaltLen
+}
+
+func (a *Argon2id) decode(encoded string) (*argon2Params, error) {
+	// ["", "argon2id", "v=19", "m=65536,t=1,p=4", salt, key]
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != Argon2idName {
+		return nil, fmt.Errorf("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, fmt.Errorf("invalid argon2id hash: %w", err)
+	} else if version != argon2.Version {
+		return nil, fmt.Errorf("unsupported argon2 version: %d", version)
+	}
+
+	p := new(argon2Params)
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return nil, fmt.Errorf("invalid argon2id hash: %w", err)
+	}
+
+	var err error
+	if p.salt, err = argon2Encoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("invalid argon2id hash: %w", err)
+	}
+	if p.key, err = argon2Encoding.DecodeString(parts[5]); err != nil {
+		return nil, fmt.Errorf("invalid argon2id hash: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,64 @@
+package password
+
+import (
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+// BcryptName bcrypt算法名称
+const BcryptName = "bcrypt"
+
+// NewBcrypt 创建bcrypt算法(cost为0时使用默认值)
+func NewBcrypt(cost int) *Bcrypt {
+	if cost <= 0 {
+		cost = bcrypt.DefaultCost
+	}
+	return &Bcrypt{Cost: cost}
+}
+
+// Bcrypt bcrypt算法(哈希格式：$2a$10$...)
+type Bcrypt struct {
+	Cost int // 计算成本
+}
+
+// Name ...
+func (a *Bcrypt) Name() string {
+	return BcryptName
+}
+
+// Hash ...
+func (a *Bcrypt) Hash(password string) (string, error) {
+	b, err := bcrypt.GenerateFromPassword([]byte(password), a.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Match ...
+func (a *Bcrypt) Match(encoded string) bool {
+	for _, v := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(encoded, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify ...
+func (a *Bcrypt) Verify(encoded, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// NeedsRehash ...
+func (a *Bcrypt) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != a.Cost
+}
@@ -0,0 +1,69 @@
+package password
+
+import (
+	"errors"
+	"strings"
+)
+
+// 定义错误
+var (
+	ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+)
+
+// Hasher 密码哈希算法
+type Hasher interface {
+	//	算法名称
+	Name() string
+	//	生成带算法标识的密码哈希
+	Hash(password string) (string, error)
+	//	检查是否为该算法生成的哈希
+	Match(encoded string) bool
+	//	校验密码
+	Verify(encoded, password string) (bool, error)
+	//	检查哈希参数是否与当前设定不一致(需要重新生成哈希)
+	NeedsRehash(encoded string) bool
+}
+
+// New 创建密码管理实例(使用hasher生成哈希，校验时兼容others中的算法)
+func New(hasher Hasher, others ...Hasher) *Manager {
+	return &Manager{
+		hasher:  hasher,
+		hashers: append([]Hasher{hasher}, others...),
+	}
+}
+
+// Manager 密码管理
+type Manager struct {
+	hasher  Hasher
+	hashers []Hasher
+}
+
+// Hash 使用当前算法生成密码哈希
+func (m *Manager) Hash(password string) (string, error) {
+	return m.hasher.Hash(password)
+}
+
+// Verify 校验密码，并返回是否需要使用当前算法重新生成哈希
+func (m *Manager) Verify(encoded, password string) (bool, bool, error) {
+	for _, h := range m.hashers {
+		if !h.Match(encoded) {
+			continue
+		}
+
+		ok, err := h.Verify(encoded, password)
+		if err != nil || !ok {
+			return false, false, err
+		}
+		return true, h != m.hasher || h.NeedsRehash(encoded), nil
+	}
+	return false, false, ErrUnknownAlgorithm
+}
+
+// 算法标识的前缀，例如：$argon2id$...
+func prefix(name string) string {
+	return "$" + name + "$"
+}
+
+func hasPrefix(encoded, name string) bool {
+	return strings.HasPrefix(encoded, prefix(name))
+}
@@ -0,0 +1,80 @@
+package password
+
+import (
+	"ginAdmin/pkg/util/hash"
+	"strings"
+	"testing"
+)
+
+func TestManager(t *testing.T) {
+	argon := NewArgon2id(1, 1024, 1)
+	bc := NewBcrypt(4)
+	m := New(argon, bc, NewLegacySHA1())
+
+	encoded, err := m.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Fatalf("unexpected hash: %s", encoded)
+	}
+
+	if ok, rehash, err := m.Verify(encoded, "secret"); err != nil || !ok || rehash {
+		t.Fatalf("verify current hash: ok=%v rehash=%v err=%v", ok, rehash, err)
+	}
+	if ok, _, err := m.Verify(encoded, "wrong"); err != nil || ok {
+		t.Fatalf("verify wrong password: ok=%v err=%v", ok, err)
+	}
+
+	// 其它算法及旧版SHA1哈希可以校验，但需要重新生成哈希
+	bcEncoded, _ := bc.Hash("secret")
+	for _, v := range []string{bcEncoded, hash.SHA1String("secret")} {
+		if ok, rehash, err := m.Verify(v, "secret"); err != nil || !ok || !rehash {
+			t.Fatalf("verify %s: ok=%v rehash=%v err=%v", v, ok, rehash, err)
+		}
+	}
+
+	// 参数变更后需要重新生成哈希
+	if ok, rehash, _ := New(NewArgon2id(2, 1024, 1)).Verify(encoded, "secret"); !ok || !rehash {
+		t.Fatalf("expected rehash after parameter change: ok=%v rehash=%v", ok, rehash)
+	}
+
+	if _, _, err := m.Verify("plain", "plain"); err != ErrUnknownAlgorithm {
+		t.Fatalf("expected unknown algorithm, got %v", err)
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	p := &Policy{
+		MinLength:        8,
+		RequireUpper:     true,
+		RequireLower:     true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		DisallowUserName: true,
+		BannedPasswords:  []string{"Passw0rd!"},
+	}
+
+	rules := func(list []Violation) string {
+		var s []string
+		for _, v := range list {
+			s = append(s, v.Rule)
+		}
+		return strings.Join(s, ",")
+	}
+
+	if list := p.Validate("Tr0ub4dor&3", "alice"); len(list) != 0 {
+		t.Fatalf("unexpected violations: %v", list)
+	}
+	if s := rules(p.Validate("abc", "alice")); s != "min_length,upper,digit,symbol" {
+		t.Fatalf("unexpected violations: %s", s)
+	}
+	if s := rules(p.Validate("passw0rd!", "alice")); s != "upper,banned" {
+		t.Fatalf("unexpected violations: %s", s)
+	}
+	if s := rules(p.Validate("Alice#2024x", "alice")); s != "user_name" {
+		t.Fatalf("unexpected violations: %s", s)
+	}
+	if s := rules(p.Validate("", "")); !strings.HasPrefix(s, "min_length") {
+		t.Fatalf("empty password must be rejected: %s", s)
+	}
+}
@@ -0,0 +1,97 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 密码策略规则
+const (
+	RuleMinLength = "min_length"
+	RuleUpper     = "upper"
+	RuleLower     = "lower"
+	RuleDigit     = "digit"
+	RuleSymbol    = "symbol"
+	RuleBanned    = "banned"
+	RuleUserName  = "user_name"
+	RuleHistory   = "history"
+)
+
+// Violation 违反的密码策略规则
+type Violation struct {
+	Rule    string `json:"rule"`    // 规则
+	Message string `json:"message"` // 说明
+}
+
+// Policy 密码策略
+type Policy struct {
+	MinLength        int           // 最小长度
+	RequireUpper     bool          // 必须包含大写字母
+	RequireLower     bool          // 必须包含小写字母
+	RequireDigit     bool          // 必须包含数字
+	RequireSymbol    bool          // 必须包含特殊字符
+	DisallowUserName bool          // 不能包含用户名
+	BannedPasswords  []string      // 禁止使用的密码(不区分大小写)
+	HistorySize      int           // 不能与最近多少次使用过的密码相同(由调用方校验)
+	MaxAge           time.Duration // 密码最长有效期(为0时不限制)
+}
+
+// Validate 校验密码，返回违反的所有规则
+func (p *Policy) Validate(password, userName string) []Violation {
+	var list []Violation
+	add := func(rule, msg string, args ...interface{}) {
+		list = append(list, Violation{Rule: rule, Message: fmt.Sprintf(msg, args...)})
+	}
+
+	if utf8.RuneCountInString(password) < p.MinLength || password == "" {
+		add(RuleMinLength, "密码长度不能少于%d位", p.MinLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		add(RuleUpper, "密码必须包含大写字母")
+	}
+	if p.RequireLower && !lower {
+		add(RuleLower, "密码必须包含小写字母")
+	}
+	if p.RequireDigit && !digit {
+		add(RuleDigit, "密码必须包含数字")
+	}
+	if p.RequireSymbol && !symbol {
+		add(RuleSymbol, "密码必须包含特殊字符")
+	}
+
+	for _, banned := range p.BannedPasswords {
+		if strings.EqualFold(password, banned) {
+			add(RuleBanned, "密码过于常见，请更换")
+			break
+		}
+	}
+
+	if p.DisallowUserName && userName != "" &&
+		strings.Contains(strings.ToLower(password), strings.ToLower(userName)) {
+		add(RuleUserName, "密码不能包含用户名")
+	}
+
+	return list
+}
+
+// IsExpired 检查密码是否已超过最长有效期
+func (p *Policy) IsExpired(changedAt time.Time) bool {
+	return p.MaxAge > 0 && !changedAt.IsZero() && time.Since(changedAt) > p.MaxAge
+}
@@ -0,0 +1,50 @@
+package password
+
+import (
+	"crypto/subtle"
+	"ginAdmin/pkg/util/hash"
+)
+
+// SHA1Name 旧版SHA1算法名称
+const SHA1Name = "sha1"
+
+// NewLegacySHA1 创建旧版无盐SHA1算法(仅用于校验历史数据，校验通过后应重新生成哈希)
+func NewLegacySHA1() *LegacySHA1 {
+	return &LegacySHA1{}
+}
+
+// LegacySHA1 旧版无盐SHA1算法(哈希格式：40位十六进制字符串，不带算法标识)
+type LegacySHA1 struct{}
+
+// Name ...
+func (a *LegacySHA1) Name() string {
+	return SHA1Name
+}
+
+// Hash ...
+func (a *LegacySHA1) Hash(password string) (string, error) {
+	return hash.SHA1String(password), nil
+}
+
+// Match ...
+func (a *LegacySHA1) Match(encoded string) bool {
+	if len(encoded) != 40 {
+		return false
+	}
+	for _, c := range encoded {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify ...
+func (a *LegacySHA1) Verify(encoded, password string) (bool, error) {
+	return subtle.ConstantTimeCompare([]byte(hash.SHA1String(password)), []byte(encoded)) == 1, nil
+}
+
+// NeedsRehash ...
+func (a *LegacySHA1) NeedsRehash(encoded string) bool {
+	return true
+}
@@ -5,11 +5,13 @@ import "github.com/pkg/errors"
 // 定义别名
 var (
 	New          = errors.New
+	Errorf       = errors.Errorf
 	Wrap         = errors.Wrap
 	Wrapf        = errors.Wrapf
 	WithStack    = errors.WithStack
 	WithMessage  = errors.WithMessage
 	WithMessagef = errors.WithMessagef
+	Is           = errors.Is
 )
 
 // 定义错误
@@ -22,11 +24,23 @@ var (
 	ErrInvalidPassword         = New400Response("无效的密码")
 	ErrInvalidUser             = New400Response("无效的用户")
 	ErrUserDisable             = New400Response("用户被禁用,请联系管理员")
+	ErrCaptchaRequired         = NewResponse(1001, 400, "请输入验证码")
+	ErrInvalidResetToken       = New400Response("无效或已过期的重置令牌")
+	ErrPasswordPolicy          = NewResponse(1002, 400, "密码不符合安全策略")
+	ErrInvalidOIDCState        = New400Response("无效或已过期的登录状态")
+	ErrExternalLogin           = New400Response("第三方登录失败")
+	ErrExternalUserNotBound    = NewResponse(1003, 400, "第三方账号未关联系统用户")
+	ErrInvalidTenant           = New400Response("无效的租户")
+	ErrTenantDisable           = New400Response("租户被禁用,请联系管理员")
+	ErrRoleCycle               = New400Response("角色继承关系不能形成循环")
 
-	ErrNoPerm          = NewResponse(401, 401, "无访问权限")
-	ErrInvalidToken    = NewResponse(9999, 401, "令牌失效")
-	ErrNotFound        = NewResponse(404, 404, "资源不存在")
-	ErrMethodNotAllow  = NewResponse(405, 405, "方法不被允许")
-	ErrTooManyRequests = NewResponse(429, 429, "请求过于频繁")
-	ErrInternalServer  = NewResponse(500, 500, "服务器发生错误")
+	ErrNoPerm                 = NewResponse(401, 401, "无访问权限")
+	ErrImpersonationForbidden = NewResponse(403, 403, "模拟登录时不允许此操作")
+	ErrInvalidToken           = NewResponse(9999, 401, "令牌失效")
+	ErrNotFound               = NewResponse(404, 404, "资源不存在")
+	ErrMethodNotAllow         = NewResponse(405, 405, "方法不被允许")
+	ErrPreconditionFailed     = NewResponse(412, 412, "资源已被修改，请刷新后重试")
+	ErrPreconditionRequired   = NewResponse(428, 428, "缺少资源版本(If-Match)")
+	ErrTooManyRequests        = NewResponse(429, 429, "请求过于频繁")
+	ErrInternalServer         = NewResponse(500, 500, "服务器发生错误")
 )
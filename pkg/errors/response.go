@@ -6,10 +6,11 @@ import (
 
 // ResponseError 定义响应错误
 type ResponseError struct {
-	Code       int    // 错误码
-	Message    string // 错误消息
-	StatusCode int    // 响应状态码
-	ERR        error  // 响应错误
+	Code       int         // 错误码
+	Message    string      // 错误消息
+	StatusCode int         // 响应状态码
+	Details    interface{} // 错误详情
+	ERR        error       // 响应错误
 }
 
 func (r *ResponseError) Error() string {
@@ -27,11 +28,23 @@ func UnWrapResponse(err error) *ResponseError {
 	return nil
 }
 
+// WithDetails 为响应错误附加错误详情(返回新的响应错误，不影响原错误)
+func WithDetails(err error, details interface{}) error {
+	v := UnWrapResponse(err)
+	if v == nil {
+		return err
+	}
+
+	res := *v
+	res.Details = details
+	return &res
+}
+
 // WrapResponse 包装响应错误
 func WrapResponse(err error, code, statusCode int, msg string, args ...interface{}) error {
 	res := &ResponseError{
 		Code:       code,
-		Message:    fmt.Sprintf(msg, args),
+		Message:    fmt.Sprintf(msg, args...),
 		ERR:        err,
 		StatusCode: statusCode,
 	}
@@ -0,0 +1,147 @@
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Rule 锁定规则
+type Rule struct {
+	MaxFailures     int64         // 触发锁定的失败次数
+	CaptchaFailures int64         // 需要验证码的失败次数(0表示不需要)
+	Window          time.Duration // 失败次数的统计窗口
+	LockDuration    time.Duration // 首次锁定时长(之后每次锁定时长翻倍)
+	MaxLockDuration time.Duration // 最大锁定时长
+}
+
+// Status 锁定状态
+type Status struct {
+	Failures  int64         // 统计窗口内的失败次数
+	LockedFor time.Duration // 剩余锁定时长(0表示未锁定)
+}
+
+// IsLocked 是否处于锁定状态
+func (s *Status) IsLocked() bool {
+	return s.LockedFor > 0
+}
+
+// New 创建登录失败锁定实例(rules的键为统计维度，例如：user/ip)
+func New(store Storer, rules map[string]Rule) *Limiter {
+	return &Limiter{
+		store: store,
+		rules: rules,
+	}
+}
+
+// Limiter 失败次数统计及锁定(锁定到期后再次触发时锁定时长按指数递增)
+type Limiter struct {
+	store Storer
+	rules map[string]Rule
+}
+
+func (a *Limiter) key(dimension, value, kind string) string {
+	return fmt.Sprintf("%s:%s:%s", dimension, kind, value)
+}
+
+func (a *Limiter) rule(dimension string) (Rule, error) {
+	rule, ok := a.rules[dimension]
+	if !ok {
+		return rule, fmt.Errorf("unknown lockout dimension: %s", dimension)
+	}
+	return rule, nil
+}
+
+// Status 查询锁定状态
+func (a *Limiter) Status(ctx context.Context, dimension, value string) (*Status, error) {
+	failures, err := a.store.Get(ctx, a.key(dimension, value, "failures"))
+	if err != nil {
+		return nil, err
+	}
+
+	lockedFor, err := a.store.TTL(ctx, a.key(dimension, value, "lock"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Status{
+		Failures:  failures,
+		LockedFor: lockedFor,
+	}, nil
+}
+
+// CaptchaRequired 检查失败次数是否已达到需要验证码的次数
+func (a *Limiter) CaptchaRequired(ctx context.Context, dimension, value string) (bool, error) {
+	rule, err := a.rule(dimension)
+	if err != nil {
+		return false, err
+	} else if rule.CaptchaFailures <= 0 {
+		return false, nil
+	}
+
+	failures, err := a.store.Get(ctx, a.key(dimension, value, "failures"))
+	if err != nil {
+		return false, err
+	}
+	return failures >= rule.CaptchaFailures, nil
+}
+
+// Fail 记录一次失败，返回本次触发的锁定时长(0表示未触发锁定)
+func (a *Limiter) Fail(ctx context.Context, dimension, value string) (time.Duration, error) {
+	rule, err := a.rule(dimension)
+	if err != nil {
+		return 0, err
+	}
+
+	failures, err := a.store.Incr(ctx, a.key(dimension, value, "failures"), rule.Window)
+	if err != nil {
+		return 0, err
+	} else if rule.MaxFailures <= 0 || failures < rule.MaxFailures {
+		return 0, nil
+	}
+
+	//	根据之前的锁定次数计算本次锁定时长
+	levelKey := a.key(dimension, value, "level")
+	level, err := a.store.Get(ctx, levelKey)
+	if err != nil {
+		return 0, err
+	}
+
+	duration := rule.LockDuration
+	for i := int64(0); i < level && (rule.MaxLockDuration <= 0 || duration < rule.MaxLockDuration); i++ {
+		duration *= 2
+	}
+	if rule.MaxLockDuration > 0 && duration > rule.MaxLockDuration {
+		duration = rule.MaxLockDuration
+	}
+
+	if err := a.store.Set(ctx, a.key(dimension, value, "lock"), 1, duration); err != nil {
+		return 0, err
+	}
+
+	//	锁定到期后的同等时长内再次触发锁定，锁定时长翻倍
+	if err := a.store.Set(ctx, levelKey, level+1, duration*2+rule.Window); err != nil {
+		return 0, err
+	}
+
+	return duration, a.store.Delete(ctx, a.key(dimension, value, "failures"))
+}
+
+// Reset 清除失败次数(例如：登录成功后)
+func (a *Limiter) Reset(ctx context.Context, dimension, value string) error {
+	return a.store.Delete(ctx, a.key(dimension, value, "failures"))
+}
+
+// Unlock 解除锁定并清除失败次数及锁定次数
+func (a *Limiter) Unlock(ctx context.Context, dimension, value string) error {
+	return a.store.Delete(ctx,
+		a.key(dimension, value, "failures"),
+		a.key(dimension, value, "lock"),
+		a.key(dimension, value, "level"),
+	)
+}
+
+// Release 释放资源
+func (a *Limiter) Release() error {
+	return a.store.Close()
+}
@@ -0,0 +1,65 @@
+package lockout
+
+import (
+	"context"
+	"ginAdmin/pkg/lockout/store/memory"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := New(memory.NewStore(0), map[string]Rule{
+		"user": {
+			MaxFailures:     3,
+			CaptchaFailures: 2,
+			Window:          time.Minute,
+			LockDuration:    time.Minute,
+			MaxLockDuration: 3 * time.Minute,
+		},
+	})
+
+	fail := func(n int) time.Duration {
+		var locked time.Duration
+		for i := 0; i < n; i++ {
+			d, err := l.Fail(ctx, "user", "u1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			locked = d
+		}
+		return locked
+	}
+
+	if d := fail(2); d != 0 {
+		t.Fatalf("unexpected lockout: %s", d)
+	}
+	if ok, _ := l.CaptchaRequired(ctx, "user", "u1"); !ok {
+		t.Fatal("expected captcha to be required")
+	}
+
+	if d := fail(1); d != time.Minute {
+		t.Fatalf("expected 1m lockout, got %s", d)
+	}
+	if s, _ := l.Status(ctx, "user", "u1"); !s.IsLocked() {
+		t.Fatal("expected locked status")
+	}
+
+	// 再次触发锁定时锁定时长翻倍，且不超过最大锁定时长
+	if d := fail(3); d != 2*time.Minute {
+		t.Fatalf("expected 2m lockout, got %s", d)
+	}
+	if d := fail(3); d != 3*time.Minute {
+		t.Fatalf("expected 3m lockout, got %s", d)
+	}
+
+	if err := l.Unlock(ctx, "user", "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := l.Status(ctx, "user", "u1"); s.IsLocked() || s.Failures != 0 {
+		t.Fatalf("expected unlocked status, got %+v", s)
+	}
+	if d := fail(3); d != time.Minute {
+		t.Fatalf("expected backoff to reset after unlock, got %s", d)
+	}
+}
@@ -0,0 +1,22 @@
+package lockout
+
+import (
+	"context"
+	"time"
+)
+
+// Storer 计数储存接口
+type Storer interface {
+	//	计数加1并返回当前值(计数新建时设定到期时间)
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	//	获取计数(不存在时返回0)
+	Get(ctx context.Context, key string) (int64, error)
+	//	设定计数，并指定到期时间
+	Set(ctx context.Context, key string, value int64, expiration time.Duration) error
+	//	获取剩余的到期时间(不存在时返回0)
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	//	删除计数
+	Delete(ctx context.Context, keys ...string) error
+	//	关闭储存
+	Close() error
+}
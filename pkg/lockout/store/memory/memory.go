@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultGCInterval = time.Minute
+
+// NewStore 创建基于内存储存的实例(gcInterval为清理过期数据的时间间隔，默认1分钟)
+func NewStore(gcInterval time.Duration) *Store {
+	if gcInterval <= 0 {
+		gcInterval = defaultGCInterval
+	}
+
+	s := &Store{
+		items: make(map[string]item),
+		done:  make(chan struct{}),
+	}
+	go s.gc(gcInterval)
+	return s
+}
+
+type item struct {
+	value     int64
+	expiredAt time.Time
+}
+
+func (i item) isExpired(now time.Time) bool {
+	return !i.expiredAt.IsZero() && now.After(i.expiredAt)
+}
+
+func newItem(value int64, expiration time.Duration) item {
+	i := item{value: value}
+	if expiration > 0 {
+		i.expiredAt = time.Now().Add(expiration)
+	}
+	return i
+}
+
+// Store 内存储存(仅适用于单实例部署，进程重启后数据丢失)
+type Store struct {
+	lock      sync.Mutex
+	items     map[string]item
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *Store) gc(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.deleteExpired()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Store) deleteExpired() {
+	now := time.Now()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for k, v := range s.items {
+		if v.isExpired(now) {
+			delete(s.items, k)
+		}
+	}
+}
+
+func (s *Store) get(key string) (item, bool) {
+	v, ok := s.items[key]
+	if !ok || v.isExpired(time.Now()) {
+		return item{}, false
+	}
+	return v, true
+}
+
+// Incr ...
+func (s *Store) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.get(key)
+	if !ok {
+		v = newItem(0, expiration)
+	}
+	v.value++
+	s.items[key] = v
+	return v.value, nil
+}
+
+// Get ...
+func (s *Store) Get(ctx context.Context, key string) (int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, _ := s.get(key)
+	return v.value, nil
+}
+
+// Set ...
+func (s *Store) Set(ctx context.Context, key string, value int64, expiration time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.items[key] = newItem(value, expiration)
+	return nil
+}
+
+// TTL ...
+func (s *Store) TTL(ctx context.Context, key string) (time.Duration, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.get(key)
+	if !ok || v.expiredAt.IsZero() {
+		return 0, nil
+	}
+	return time.Until(v.expiredAt), nil
+}
+
+// Delete ...
+func (s *Store) Delete(ctx context.Context, keys ...string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, key := range keys {
+		delete(s.items, key)
+	}
+	return nil
+}
+
+// Close ...
+func (s *Store) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
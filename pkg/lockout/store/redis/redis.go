@@ -0,0 +1,113 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// Config redis配置参数
+type Config struct {
+	Addr      string // 地址(IP:Port)
+	DB        int    // 数据库
+	Password  string // 密码
+	KeyPrefix string // 储存key的前缀
+}
+
+// NewStore 创建基于redis储存的实例
+func NewStore(cfg *Config) *Store {
+	cli := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		DB:       cfg.DB,
+		Password: cfg.Password,
+	})
+	return &Store{
+		cli:    cli,
+		prefix: cfg.KeyPrefix,
+	}
+}
+
+// NewStoreWithClient 使用redis客户端创建储存实例
+func NewStoreWithClient(cli *redis.Client, keyPrefix string) *Store {
+	return &Store{
+		cli:    cli,
+		prefix: keyPrefix,
+	}
+}
+
+// Store redis储存
+type Store struct {
+	cli    *redis.Client
+	prefix string
+}
+
+func (s *Store) wrapperKey(key string) string {
+	return fmt.Sprintf("%s%s", s.prefix, key)
+}
+
+// incrScript 计数加1，计数新建时设定到期时间
+var incrScript = redis.NewScript(`
+local v = redis.call("INCR", KEYS[1])
+if v == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return v
+`)
+
+// Incr ...
+func (s *Store) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	keys := []string{s.wrapperKey(key)}
+	return incrScript.Run(ctx, s.cli, keys, expiration.Milliseconds()).Int64()
+}
+
+// Get ...
+func (s *Store) Get(ctx context.Context, key string) (int64, error) {
+	v, err := s.cli.Get(ctx, s.wrapperKey(key)).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return v, nil
+}
+
+// Set ...
+func (s *Store) Set(ctx context.Context, key string, value int64, expiration time.Duration) error {
+	cmd := s.cli.Set(ctx, s.wrapperKey(key), value, expiration)
+	return cmd.Err()
+}
+
+// TTL ...
+func (s *Store) TTL(ctx context.Context, key string) (time.Duration, error) {
+	cmd := s.cli.PTTL(ctx, s.wrapperKey(key))
+	if err := cmd.Err(); err != nil {
+		return 0, err
+	}
+
+	//	key不存在或未设定到期时间时返回负数
+	if v := cmd.Val(); v > 0 {
+		return v, nil
+	}
+	return 0, nil
+}
+
+// Delete ...
+func (s *Store) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	wrapped := make([]string, len(keys))
+	for i, key := range keys {
+		wrapped[i] = s.wrapperKey(key)
+	}
+	cmd := s.cli.Del(ctx, wrapped...)
+	return cmd.Err()
+}
+
+// Close ...
+func (s *Store) Close() error {
+	return s.cli.Close()
+}
@@ -34,6 +34,7 @@ type LogItem struct {
 	Level      string    `gorm:"column:level;size:20;index;"`           // 日志级别
 	TraceID    string    `gorm:"column:trace_id;size:128;index;"`       // 跟踪ID
 	UserID     string    `gorm:"column:user_id;size:36;index;"`         // 用户ID
+	ActorID    string    `gorm:"column:actor_id;size:36;index;"`        // 实际操作者ID(模拟登录时)
 	Tag        string    `gorm:"column:tag;size:128;index;"`            // Tag
 	Version    string    `gorm:"column:version;index;size:64;"`         // 版本号
 	Message    string    `gorm:"column:message;size:1024;"`             // 消息
@@ -93,6 +94,10 @@ func (h *Hook) Exec(entry *logrus.Entry) error {
 		item.UserID, _ = v.(string)
 		delete(data, logger.UserIDKey)
 	}
+	if v, ok := data[logger.ActorIDKey]; ok {
+		item.ActorID, _ = v.(string)
+		delete(data, logger.ActorIDKey)
+	}
 	if v, ok := data[logger.TagKey]; ok {
 		item.Tag, _ = v.(string)
 		delete(data, logger.TagKey)
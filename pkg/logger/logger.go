@@ -12,6 +12,7 @@ import (
 const (
 	TraceIDKey = "trace_id"
 	UserIDKey  = "user_id"
+	ActorIDKey = "actor_id"
 	TagKey     = "tag"
 	VersionKey = "version"
 	StackKey   = "stack"
@@ -68,6 +69,7 @@ func AddHook(hook Hook) {
 type (
 	traceIDKey struct{}
 	userIDKey  struct{}
+	actorIDKey struct{}
 	tagKey     struct{}
 	stackKey   struct{}
 )
@@ -104,6 +106,22 @@ func FromUserIDContext(ctx context.Context) string {
 	return ""
 }
 
+// NewActorIDContext 创建实际操作者ID上下文(模拟登录时使用)
+func NewActorIDContext(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorIDKey{}, actorID)
+}
+
+// FromActorIDContext 从上下文中获取实际操作者ID
+func FromActorIDContext(ctx context.Context) string {
+	v := ctx.Value(actorIDKey{})
+	if v != nil {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
 // NewTagContext 创建Tag上下文
 func NewTagContext(ctx context.Context, tag string) context.Context {
 	return context.WithValue(ctx, tagKey{}, tag)
@@ -154,6 +172,10 @@ func WithContext(ctx context.Context) *Entry {
 		fields[UserIDKey] = v
 	}
 
+	if v := FromActorIDContext(ctx); v != "" {
+		fields[ActorIDKey] = v
+	}
+
 	if v := FromTagContext(ctx); v != "" {
 		fields[TagKey] = v
 	}
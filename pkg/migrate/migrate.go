@@ -0,0 +1,436 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 迁移文件名格式：{版本号}_{名称}.up.sql、{版本号}_{名称}.down.sql、{版本号}_{名称}.legacy.sql
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down|legacy)\.sql$`)
+
+// ErrLocked 迁移锁被其他实例持有
+var ErrLocked = errors.New("migrate: locked by another instance")
+
+// Migration 版本迁移
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+	Legacy  string // 旧版本数据库(未使用版本迁移创建的数据库)的升级语句，首次迁移检测到旧版本数据库时代替Up执行
+}
+
+// Load 加载目录下的迁移文件(按版本号升序)
+func Load(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mMigrations := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNameRegexp.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migrate: invalid file name %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		buf, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		item, ok := mMigrations[version]
+		if !ok {
+			item = &Migration{Version: version, Name: match[2]}
+			mMigrations[version] = item
+		} else if item.Name != match[2] {
+			return nil, fmt.Errorf("migrate: duplicate version %d (%s, %s)", version, item.Name, match[2])
+		}
+
+		switch match[3] {
+		case "up":
+			item.Up = string(buf)
+		case "down":
+			item.Down = string(buf)
+		default:
+			item.Legacy = string(buf)
+		}
+	}
+
+	list := make([]*Migration, 0, len(mMigrations))
+	for _, item := range mMigrations {
+		if strings.TrimSpace(item.Up) == "" {
+			return nil, fmt.Errorf("migrate: version %d has no up migration", item.Version)
+		}
+		list = append(list, item)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	return list, nil
+}
+
+// Config 迁移配置参数
+type Config struct {
+	Dialect     string            // 数据库类型(mysql/postgres/sqlite3)
+	Table       string            // 迁移记录表(迁移锁表为迁移记录表加_lock后缀)
+	Vars        map[string]string // 迁移语句中${name}形式的变量
+	LockTimeout time.Duration     // 等待迁移锁的最长时间
+	LockExpire  time.Duration     // 迁移锁的过期时间(持有锁的实例异常退出后，锁过期即可被其他实例获取)
+	// 检测是否为旧版本数据库(仅在尚未执行任何迁移时检测，为空表示不检测)
+	DetectLegacy func(ctx context.Context) (bool, error)
+}
+
+// Status 迁移状态
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time // 执行时间(为空表示未执行)
+	Unknown   bool       // 已执行但当前代码中不存在的迁移(数据库版本高于代码版本)
+}
+
+// New 创建迁移实例
+func New(db *sql.DB, migrations []*Migration, cfg Config) *Migrator {
+	if cfg.Table == "" {
+		cfg.Table = "schema_migration"
+	}
+	if cfg.LockTimeout <= 0 {
+		cfg.LockTimeout = time.Minute
+	}
+	if cfg.LockExpire <= 0 {
+		cfg.LockExpire = time.Minute * 10
+	}
+
+	var oldnew []string
+	for k, v := range cfg.Vars {
+		oldnew = append(oldnew, "${"+k+"}", v)
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		cfg:        cfg,
+		replacer:   strings.NewReplacer(oldnew...),
+	}
+}
+
+// Migrator 按版本顺序执行迁移，每个迁移及其执行记录在同一个事务中提交(mysql的DDL语句会隐式提交)
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+	cfg        Config
+	replacer   *strings.Replacer
+}
+
+// Up 执行未执行的迁移(n<=0时执行全部)，返回本次执行的迁移
+func (a *Migrator) Up(ctx context.Context, n int) ([]*Migration, error) {
+	unlock, err := a.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	list, err := a.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Migration
+	for i, item := range list {
+		if item.Unknown {
+			return nil, fmt.Errorf("migrate: version %d is applied but unknown to this build", item.Version)
+		} else if item.AppliedAt == nil {
+			pending = append(pending, a.migrations[i])
+		}
+	}
+	legacy := false
+	if len(pending) == len(list) && a.cfg.DetectLegacy != nil {
+		legacy, err = a.cfg.DetectLegacy(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if n > 0 && n < len(pending) {
+		pending = pending[:n]
+	}
+
+	for i, item := range pending {
+		query := item.Up
+		if legacy && item.Legacy != "" {
+			query = item.Legacy
+		}
+
+		err := a.exec(ctx, item, query, true)
+		if err != nil {
+			return pending[:i], err
+		}
+	}
+	return pending, nil
+}
+
+// Down 回滚最近执行的n个迁移(n<=0时回滚1个)，返回本次回滚的迁移
+func (a *Migrator) Down(ctx context.Context, n int) ([]*Migration, error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	unlock, err := a.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	list, err := a.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []*Migration
+	for i := len(list) - 1; i >= 0 && len(applied) < n; i-- {
+		item := list[i]
+		if item.AppliedAt == nil {
+			continue
+		} else if item.Unknown {
+			return nil, fmt.Errorf("migrate: version %d is applied but unknown to this build", item.Version)
+		}
+
+		m := a.migrations[i]
+		if strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("migrate: version %d has no down migration", m.Version)
+		}
+		applied = append(applied, m)
+	}
+
+	for i, item := range applied {
+		err := a.exec(ctx, item, item.Down, false)
+		if err != nil {
+			return applied[:i], err
+		}
+	}
+	return applied, nil
+}
+
+// Status 查询迁移状态(按版本号升序，代码中存在的迁移与a.migrations一一对应，未知的迁移排在最后)
+func (a *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	err := a.createTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf("SELECT version, name, applied_at FROM %s ORDER BY version", a.cfg.Table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mApplied := make(map[int64]*Status)
+	var applied []*Status
+	for rows.Next() {
+		item := new(Status)
+		var appliedAt time.Time
+		err := rows.Scan(&item.Version, &item.Name, &appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		item.AppliedAt = &appliedAt
+		mApplied[item.Version] = item
+		applied = append(applied, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	list := make([]*Status, 0, len(a.migrations))
+	for _, m := range a.migrations {
+		item := &Status{Version: m.Version, Name: m.Name}
+		if v, ok := mApplied[m.Version]; ok {
+			item.AppliedAt = v.AppliedAt
+			delete(mApplied, m.Version)
+		}
+		list = append(list, item)
+	}
+
+	for _, item := range applied {
+		if _, ok := mApplied[item.Version]; ok {
+			item.Unknown = true
+			list = append(list, item)
+		}
+	}
+	return list, nil
+}
+
+// Check 检查数据库是否已执行且仅执行了当前代码中的全部迁移
+func (a *Migrator) Check(ctx context.Context) error {
+	list, err := a.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending, unknown []string
+	for _, item := range list {
+		if item.Unknown {
+			unknown = append(unknown, strconv.FormatInt(item.Version, 10))
+		} else if item.AppliedAt == nil {
+			pending = append(pending, strconv.FormatInt(item.Version, 10))
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("migrate: schema is newer than this build (unknown versions: %s)", strings.Join(unknown, ","))
+	} else if len(pending) > 0 {
+		return fmt.Errorf("migrate: schema is out of date (pending versions: %s)", strings.Join(pending, ","))
+	}
+	return nil
+}
+
+func (a *Migrator) exec(ctx context.Context, m *Migration, query string, up bool) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range splitStatements(a.replacer.Replace(query)) {
+		_, err := tx.ExecContext(ctx, stmt)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migrate: version %d (%s): %w", m.Version, m.Name, err)
+		}
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, a.bind(fmt.Sprintf("INSERT INTO %s (version, name, applied_at) VALUES (?, ?, ?)", a.cfg.Table)),
+			m.Version, m.Name, time.Now())
+	} else {
+		_, err = tx.ExecContext(ctx, a.bind(fmt.Sprintf("DELETE FROM %s WHERE version=?", a.cfg.Table)), m.Version)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (a *Migrator) createTables(ctx context.Context) error {
+	timeType := "datetime"
+	switch a.cfg.Dialect {
+	case "mysql":
+		timeType = "datetime(3)"
+	case "postgres":
+		timeType = "timestamptz"
+	}
+
+	queries := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version bigint NOT NULL, name varchar(255) NOT NULL, applied_at %s NOT NULL, PRIMARY KEY (version))",
+			a.cfg.Table, timeType),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s_lock (id int NOT NULL, owner varchar(255) NOT NULL, expires_at %s NOT NULL, PRIMARY KEY (id))",
+			a.cfg.Table, timeType),
+	}
+	for _, query := range queries {
+		_, err := a.db.ExecContext(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 通过插入主键固定的记录获取迁移锁，返回释放锁的函数
+func (a *Migrator) lock(ctx context.Context) (func(), error) {
+	err := a.createTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s:%d", hostname, os.Getpid())
+	deadline := time.Now().Add(a.cfg.LockTimeout)
+
+	for {
+		now := time.Now()
+		_, err := a.db.ExecContext(ctx, a.bind(fmt.Sprintf("DELETE FROM %s_lock WHERE expires_at<?", a.cfg.Table)), now)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = a.db.ExecContext(ctx, a.bind(fmt.Sprintf("INSERT INTO %s_lock (id, owner, expires_at) VALUES (1, ?, ?)", a.cfg.Table)),
+			owner, now.Add(a.cfg.LockExpire))
+		if err == nil {
+			break
+		} else if now.After(deadline) {
+			return nil, fmt.Errorf("%w: %s", ErrLocked, err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Millisecond * 500):
+		}
+	}
+
+	return func() {
+		_, _ = a.db.ExecContext(context.Background(), a.bind(fmt.Sprintf("DELETE FROM %s_lock WHERE id=1 AND owner=?", a.cfg.Table)), owner)
+	}, nil
+}
+
+// 将?占位符转换为数据库对应的格式
+func (a *Migrator) bind(query string) string {
+	if a.cfg.Dialect != "postgres" {
+		return query
+	}
+
+	var sb strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// 按行尾的分号拆分语句(忽略注释行，不支持语句内部行尾出现分号的情况)
+func splitStatements(query string) []string {
+	var list []string
+	var sb strings.Builder
+	for _, line := range strings.Split(query, "\n") {
+		s := strings.TrimSpace(line)
+		if s == "" || strings.HasPrefix(s, "--") {
+			continue
+		}
+
+		sb.WriteString(line)
+		sb.WriteString("\n")
+		if strings.HasSuffix(s, ";") {
+			list = append(list, strings.TrimSpace(sb.String()))
+			sb.Reset()
+		}
+	}
+
+	if s := strings.TrimSpace(sb.String()); s != "" {
+		list = append(list, s)
+	}
+	return list
+}
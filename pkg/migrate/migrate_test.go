@@ -0,0 +1,239 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var testFS = fstest.MapFS{
+	"sqlite3/0001_init.up.sql":        {Data: []byte("CREATE TABLE ${prefix}a (id int);\n-- comment\nCREATE TABLE ${prefix}b (\n  id int\n);\n")},
+	"sqlite3/0001_init.down.sql":      {Data: []byte("DROP TABLE ${prefix}b;\nDROP TABLE ${prefix}a;\n")},
+	"sqlite3/0001_init.legacy.sql":    {Data: []byte("ALTER TABLE ${prefix}a ADD COLUMN name text;\nCREATE TABLE ${prefix}b (id int);\n")},
+	"sqlite3/0002_add_c.up.sql":       {Data: []byte("CREATE TABLE ${prefix}c (id int, name text);\n")},
+	"sqlite3/0002_add_c.down.sql":     {Data: []byte("DROP TABLE ${prefix}c;\n")},
+	"sqlite3/0010_backfill.up.sql":    {Data: []byte("INSERT INTO ${prefix}c (id, name) VALUES (1, 'x');\n")},
+	"sqlite3/0010_backfill.down.sql":  {Data: []byte("DELETE FROM ${prefix}c;\n")},
+	"invalid/0001_init.sql":           {Data: []byte("SELECT 1;")},
+	"duplicate/0001_a.up.sql":         {Data: []byte("SELECT 1;")},
+	"duplicate/0001_b.up.sql":         {Data: []byte("SELECT 1;")},
+	"missing_up/0001_init.down.sql":   {Data: []byte("SELECT 1;")},
+	"missing_down/0001_init.up.sql":   {Data: []byte("SELECT 1;")},
+	"missing_down/0002_next.up.sql":   {Data: []byte("SELECT 1;")},
+	"missing_down/0002_next.down.sql": {Data: []byte("SELECT 1;")},
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	gormDB, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := gormDB.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newTestMigrator(t *testing.T, db *sql.DB, dir string) *Migrator {
+	migrations, err := Load(testFS, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return New(db, migrations, Config{
+		Dialect:     "sqlite3",
+		Table:       "t_schema_migration",
+		Vars:        map[string]string{"prefix": "t_"},
+		LockTimeout: time.Millisecond * 100,
+	})
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS, "sqlite3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(migrations) != 3 {
+		t.Fatalf("unexpected migrations: %d", len(migrations))
+	}
+	for i, version := range []int64{1, 2, 10} {
+		if migrations[i].Version != version {
+			t.Fatalf("unexpected version at %d: %d", i, migrations[i].Version)
+		}
+	}
+	if migrations[0].Legacy == "" || migrations[1].Legacy != "" {
+		t.Fatalf("unexpected legacy migration: %+v", migrations[0])
+	}
+	if migrations[1].Name != "add_c" || migrations[1].Down == "" {
+		t.Fatalf("unexpected migration: %+v", migrations[1])
+	}
+
+	for _, dir := range []string{"invalid", "duplicate", "missing_up"} {
+		if _, err := Load(testFS, dir); err == nil {
+			t.Fatalf("expected error for %s", dir)
+		}
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	m := newTestMigrator(t, db, "sqlite3")
+
+	if err := m.Check(ctx); err == nil {
+		t.Fatal("expected pending migrations")
+	}
+
+	applied, err := m.Up(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(applied) != 2 {
+		t.Fatalf("unexpected applied: %d", len(applied))
+	}
+
+	applied, err = m.Up(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(applied) != 1 || applied[0].Version != 10 {
+		t.Fatalf("unexpected applied: %+v", applied)
+	}
+
+	if err := m.Check(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM t_c WHERE id=1").Scan(&name); err != nil || name != "x" {
+		t.Fatalf("unexpected backfill: %s, %v", name, err)
+	}
+
+	rolledBack, err := m.Down(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(rolledBack) != 2 || rolledBack[0].Version != 10 || rolledBack[1].Version != 2 {
+		t.Fatalf("unexpected rolled back: %+v", rolledBack)
+	}
+
+	list, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list[0].AppliedAt == nil || list[1].AppliedAt != nil || list[2].AppliedAt != nil {
+		t.Fatalf("unexpected status: %+v %+v %+v", list[0], list[1], list[2])
+	}
+
+	if _, err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// 数据库版本高于代码版本
+	old := newTestMigrator(t, db, "missing_down")
+	list, err = old.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(list) != 3 || !list[2].Unknown {
+		t.Fatalf("unexpected status: %d", len(list))
+	}
+	if err := old.Check(ctx); err == nil {
+		t.Fatal("expected unknown versions")
+	}
+	if _, err := old.Up(ctx, 0); err == nil {
+		t.Fatal("expected up to refuse unknown versions")
+	}
+}
+
+func TestMigratorLegacy(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// 未使用版本迁移创建的旧版本数据库
+	if _, err := db.Exec("CREATE TABLE t_a (id int)"); err != nil {
+		t.Fatal(err)
+	}
+
+	migrations, err := Load(testFS, "sqlite3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(db, migrations, Config{
+		Dialect: "sqlite3",
+		Table:   "t_schema_migration",
+		Vars:    map[string]string{"prefix": "t_"},
+		DetectLegacy: func(ctx context.Context) (bool, error) {
+			var n int
+			err := db.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master WHERE type='table' AND name='t_a'").Scan(&n)
+			return n > 0, err
+		},
+	})
+
+	applied, err := m.Up(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(applied) != 3 {
+		t.Fatalf("unexpected applied: %d", len(applied))
+	}
+
+	if _, err := db.Exec("INSERT INTO t_a (id, name) VALUES (1, 'x')"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Check(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigratorDownWithoutDownMigration(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMigrator(t, newTestDB(t), "missing_down")
+
+	if _, err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Down(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Down(ctx, 1); err == nil {
+		t.Fatal("expected error for missing down migration")
+	}
+}
+
+func TestMigratorLock(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	m := newTestMigrator(t, db, "sqlite3")
+
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Up(ctx, 0); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked: %v", err)
+	}
+
+	unlock()
+	if _, err := m.Up(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// 过期的锁可以被其他实例获取
+	_, err = db.Exec("INSERT INTO t_schema_migration_lock (id, owner, expires_at) VALUES (1, 'crashed', ?)", time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Down(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+}
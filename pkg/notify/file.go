@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"ginAdmin/pkg/util/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// NewFileNotifier 创建基于文件输出的通知(每条消息以一行JSON追加到文件中，仅用于开发及测试)
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+// FileNotifier 文件通知
+type FileNotifier struct {
+	lock sync.Mutex
+	path string
+}
+
+type fileMessage struct {
+	*Message
+	SentAt time.Time `json:"sent_at"`
+}
+
+// Notify ...
+func (a *FileNotifier) Notify(ctx context.Context, msg *Message) error {
+	b, err := json.Marshal(fileMessage{Message: msg, SentAt: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"ginAdmin/pkg/util/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify.log")
+	n := NewFileNotifier(path)
+
+	for _, to := range []string{"a@example.com", "b@example.com"} {
+		if err := n.Notify(context.Background(), &Message{To: to, Subject: "hi", Content: "body"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var list []Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			t.Fatal(err)
+		}
+		list = append(list, msg)
+	}
+
+	if len(list) != 2 || list[1].To != "b@example.com" || list[1].Content != "body" {
+		t.Fatalf("unexpected messages: %+v", list)
+	}
+}
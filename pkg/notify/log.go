@@ -0,0 +1,21 @@
+package notify
+
+import (
+	"context"
+	"ginAdmin/pkg/logger"
+)
+
+// NewLogNotifier 创建基于日志输出的通知(只输出接收者及主题，内容可能包含令牌等敏感信息，不输出到日志；仅用于开发及测试)
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// LogNotifier 日志通知
+type LogNotifier struct{}
+
+// Notify ...
+func (a *LogNotifier) Notify(ctx context.Context, msg *Message) error {
+	logger.WithContext(logger.NewTagContext(ctx, "__notify__")).
+		Infof("通知[%s]%s", msg.To, msg.Subject)
+	return nil
+}
@@ -0,0 +1,16 @@
+package notify
+
+import "context"
+
+// Message 通知消息
+type Message struct {
+	To      string `json:"to"`      // 接收者(例如：邮箱)
+	Subject string `json:"subject"` // 主题
+	Content string `json:"content"` // 内容
+}
+
+// Notifier 通知发送接口
+type Notifier interface {
+	//	发送通知
+	Notify(ctx context.Context, msg *Message) error
+}
@@ -0,0 +1,94 @@
+package local
+
+import (
+	"ginAdmin/pkg/watcher"
+	"github.com/casbin/casbin/v2/persist"
+	"sync"
+)
+
+var _ persist.Watcher = (*Watcher)(nil)
+
+// 进程内的所有watcher
+var (
+	mu       sync.RWMutex
+	watchers = make(map[string]*Watcher)
+)
+
+// NewWatcher 创建进程内的策略变更通知实例(仅适用于单实例部署，变更只通知同一进程内的其他实例)
+func NewWatcher() *Watcher {
+	w := &Watcher{
+		id:     watcher.NewID(),
+		notify: make(chan string, 1),
+		done:   make(chan struct{}),
+	}
+	go w.run()
+
+	mu.Lock()
+	watchers[w.id] = w
+	mu.Unlock()
+	return w
+}
+
+// Watcher 进程内策略变更通知
+type Watcher struct {
+	id       string
+	lock     sync.RWMutex
+	callback func(string)
+	notify   chan string
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (w *Watcher) run() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case payload := <-w.notify:
+			w.lock.RLock()
+			callback := w.callback
+			w.lock.RUnlock()
+
+			if callback != nil {
+				callback(payload)
+			}
+		}
+	}
+}
+
+// SetUpdateCallback 设置收到其他实例变更通知时的回调
+func (w *Watcher) SetUpdateCallback(callback func(string)) error {
+	w.lock.Lock()
+	w.callback = callback
+	w.lock.Unlock()
+	return nil
+}
+
+// Update 通知同一进程内的其他实例(已有待处理的通知时合并)
+func (w *Watcher) Update() error {
+	payload := watcher.Message{ID: w.id, Method: watcher.MethodUpdate}.Encode()
+
+	mu.RLock()
+	defer mu.RUnlock()
+	for id, item := range watchers {
+		if id == w.id {
+			continue
+		}
+
+		select {
+		case item.notify <- payload:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close 停止接收通知
+func (w *Watcher) Close() {
+	w.once.Do(func() {
+		mu.Lock()
+		delete(watchers, w.id)
+		mu.Unlock()
+		close(w.done)
+	})
+}
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"ginAdmin/pkg/watcher"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/go-redis/redis/v8"
+	"sync"
+)
+
+var _ persist.Watcher = (*Watcher)(nil)
+
+const defaultChannel = "casbin:policy"
+
+// Config redis配置参数
+type Config struct {
+	Addr     string // 地址(IP:Port)
+	Password string // 密码
+	Channel  string // 发布订阅的频道(默认casbin:policy，频道不区分数据库)
+}
+
+// NewWatcher 创建基于redis发布订阅的策略变更通知实例(订阅失败时返回错误)
+func NewWatcher(cfg *Config) (*Watcher, error) {
+	channel := cfg.Channel
+	if channel == "" {
+		channel = defaultChannel
+	}
+
+	cli := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+	})
+
+	ctx := context.Background()
+	ps := cli.Subscribe(ctx, channel)
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		_ = cli.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		id:      watcher.NewID(),
+		cli:     cli,
+		ps:      ps,
+		channel: channel,
+	}
+	go w.run()
+	return w, nil
+}
+
+// Watcher redis策略变更通知
+type Watcher struct {
+	id       string
+	cli      *redis.Client
+	ps       *redis.PubSub
+	channel  string
+	lock     sync.RWMutex
+	callback func(string)
+	once     sync.Once
+}
+
+// 接收其他实例的变更通知(忽略自身发出的消息)
+func (w *Watcher) run() {
+	for msg := range w.ps.Channel() {
+		m, err := watcher.Decode(msg.Payload)
+		if err != nil || m.ID == w.id {
+			continue
+		}
+
+		w.lock.RLock()
+		callback := w.callback
+		w.lock.RUnlock()
+
+		if callback != nil {
+			callback(msg.Payload)
+		}
+	}
+}
+
+// SetUpdateCallback 设置收到其他实例变更通知时的回调
+func (w *Watcher) SetUpdateCallback(callback func(string)) error {
+	w.lock.Lock()
+	w.callback = callback
+	w.lock.Unlock()
+	return nil
+}
+
+// Update 通知其他实例策略已变更
+func (w *Watcher) Update() error {
+	payload := watcher.Message{ID: w.id, Method: watcher.MethodUpdate}.Encode()
+	return w.cli.Publish(context.Background(), w.channel, payload).Err()
+}
+
+// Close 停止接收通知并关闭连接
+func (w *Watcher) Close() {
+	w.once.Do(func() {
+		_ = w.ps.Close()
+		_ = w.cli.Close()
+	})
+}
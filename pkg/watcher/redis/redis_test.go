@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"testing"
+	"time"
+)
+
+func TestWatcher(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	cfg := &Config{Addr: mr.Addr()}
+	w1, err := NewWatcher(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w1.Close()
+
+	w2, err := NewWatcher(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w2.Close()
+
+	ch1 := make(chan string, 1)
+	ch2 := make(chan string, 1)
+	_ = w1.SetUpdateCallback(func(s string) { ch1 <- s })
+	_ = w2.SetUpdateCallback(func(s string) { ch2 <- s })
+
+	if err := w1.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-ch2:
+	case <-time.After(time.Second):
+		t.Fatal("peer did not receive the update")
+	}
+
+	select {
+	case <-ch1:
+		t.Fatal("watcher received its own update")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNewWatcherUnavailable(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := mr.Addr()
+	mr.Close()
+
+	if _, err := NewWatcher(&Config{Addr: addr}); err == nil {
+		t.Fatal("expected subscribe error")
+	}
+}
@@ -0,0 +1,38 @@
+package watcher
+
+import (
+	"encoding/json"
+	"ginAdmin/pkg/util/uuid"
+)
+
+// 策略变更方式
+const (
+	MethodUpdate = "Update"
+)
+
+// Message 策略变更消息
+type Message struct {
+	ID     string `json:"id"`     // 发送方实例ID(用于忽略自身发出的消息)
+	Method string `json:"method"` // 变更方式
+}
+
+// NewID 生成实例ID
+func NewID() string {
+	return uuid.MustString()
+}
+
+// Encode 编码消息
+func (m Message) Encode() string {
+	buf, _ := json.Marshal(m)
+	return string(buf)
+}
+
+// Decode 解码消息
+func Decode(payload string) (*Message, error) {
+	var m Message
+	err := json.Unmarshal([]byte(payload), &m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}